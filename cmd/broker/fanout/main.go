@@ -22,8 +22,10 @@ import (
 
 	"cloud.google.com/go/pubsub"
 
+	"github.com/google/knative-gcp/pkg/broker/admin"
 	"github.com/google/knative-gcp/pkg/broker/config/volume"
 	"github.com/google/knative-gcp/pkg/broker/handler"
+	"github.com/google/knative-gcp/pkg/broker/podstatus"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"github.com/google/knative-gcp/pkg/utils"
 	"github.com/google/knative-gcp/pkg/utils/appcredentials"
@@ -31,6 +33,7 @@ import (
 	"github.com/google/knative-gcp/pkg/utils/mainhelper"
 
 	"go.uber.org/zap"
+	"knative.dev/pkg/system"
 )
 
 const (
@@ -59,6 +62,11 @@ type envConfig struct {
 
 	// Max to 10m.
 	TimeoutPerEvent time.Duration `envconfig:"TIMEOUT_PER_EVENT"`
+
+	// AdminToken is the bearer token to get the config snapshot from the
+	// health check port. The config snapshot is disabled if it's empty. It is
+	// never logged.
+	AdminToken string `envconfig:"ADMIN_TOKEN" json:"-"`
 }
 
 func main() {
@@ -85,6 +93,7 @@ func main() {
 	}
 
 	syncSignal := poolSyncSignal(ctx, targetsUpdateCh)
+	generation := &volume.Generation{}
 	syncPool, err := InitializeSyncPool(
 		ctx,
 		clients.ProjectID(projectID),
@@ -93,13 +102,17 @@ func main() {
 		[]volume.Option{
 			volume.WithPath(env.TargetsConfigPath),
 			volume.WithNotifyChan(targetsUpdateCh),
+			volume.WithGeneration(generation),
 		},
 		buildHandlerOptions(env)...,
 	)
 	if err != nil {
 		logger.Fatal("Failed to create fanout sync pool", zap.Error(err))
 	}
-	if _, err := handler.StartSyncPool(ctx, syncPool, syncSignal, env.MaxStaleDuration, handler.DefaultHealthCheckPort); err != nil {
+	reporter := podstatus.NewReporter(res.KubeClient, system.Namespace(), env.PodName, func() (int64, error) {
+		return generation.Load(), nil
+	})
+	if _, err := handler.StartSyncPool(ctx, syncPool, syncSignal, env.MaxStaleDuration, handler.DefaultHealthCheckPort, admin.Token(env.AdminToken), generation, reporter); err != nil {
 		logger.Fatalw("Failed to start fanout sync pool", zap.Error(err))
 	}
 
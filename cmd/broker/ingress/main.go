@@ -17,6 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"github.com/google/knative-gcp/pkg/broker/admin"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
+	"github.com/google/knative-gcp/pkg/broker/podstatus"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"github.com/google/knative-gcp/pkg/utils"
 	"github.com/google/knative-gcp/pkg/utils/appcredentials"
@@ -25,6 +28,7 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"go.uber.org/zap"
+	"knative.dev/pkg/system"
 )
 
 type envConfig struct {
@@ -33,6 +37,10 @@ type envConfig struct {
 
 	// Default 300Mi.
 	PublishBufferedByteLimit int `envconfig:"PUBLISH_BUFFERED_BYTES_LIMIT" default:"314572800"`
+
+	// AdminToken is the bearer token to get the config snapshot. The config
+	// snapshot is disabled if it's empty. It is never logged.
+	AdminToken string `envconfig:"ADMIN_TOKEN" json:"-"`
 }
 
 const (
@@ -59,6 +67,7 @@ func main() {
 	}
 	logger.Desugar().Info("Starting ingress handler", zap.Any("envConfig", env), zap.Any("Project ID", projectID))
 
+	generation := &volume.Generation{}
 	ingress, err := InitializeHandler(
 		ctx,
 		clients.Port(env.Port),
@@ -66,11 +75,19 @@ func main() {
 		metrics.PodName(env.PodName),
 		metrics.ContainerName(component),
 		publishSetting(logger.Desugar(), env),
+		admin.Token(env.AdminToken),
+		generation,
+		[]volume.Option{volume.WithGeneration(generation)},
 	)
 	if err != nil {
 		logger.Desugar().Fatal("Unable to create ingress handler: ", zap.Error(err))
 	}
 
+	reporter := podstatus.NewReporter(res.KubeClient, system.Namespace(), env.PodName, func() (int64, error) {
+		return generation.Load(), nil
+	})
+	go reporter.Run(ctx, podstatus.ConfigStatusReportPeriod)
+
 	logger.Desugar().Info("Starting ingress.", zap.Any("ingress", ingress))
 	if err := ingress.Start(ctx); err != nil {
 		logger.Desugar().Fatal("failed to start ingress: ", zap.Error(err))
@@ -22,6 +22,7 @@ import (
 	"context"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/google/knative-gcp/pkg/broker/admin"
 	"github.com/google/knative-gcp/pkg/broker/config/volume"
 	"github.com/google/knative-gcp/pkg/broker/ingress"
 	"github.com/google/knative-gcp/pkg/metrics"
@@ -36,10 +37,12 @@ func InitializeHandler(
 	podName metrics.PodName,
 	containerName metrics.ContainerName,
 	publishSettings pubsub.PublishSettings,
+	adminToken admin.Token,
+	generation *volume.Generation,
+	targetsVolumeOpts []volume.Option,
 ) (*ingress.Handler, error) {
 	panic(wire.Build(
 		ingress.HandlerSet,
-		wire.Value([]volume.Option(nil)),
 		volume.NewTargetsFromFile,
 	))
 }
@@ -27,8 +27,11 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/broker"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell"
 	"github.com/google/knative-gcp/pkg/reconciler/deployment"
+	"github.com/google/knative-gcp/pkg/reconciler/events/artifactregistry"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
+	"github.com/google/knative-gcp/pkg/reconciler/events/budgets"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -59,6 +62,9 @@ func Controllers(
 	schedulerController scheduler.Constructor,
 	pubsubController pubsub.Constructor,
 	buildController build.Constructor,
+	monitoringController monitoring.Constructor,
+	budgetsController budgets.Constructor,
+	artifactRegistryController artifactregistry.Constructor,
 	pullsubscriptionController staticpullsubscription.Constructor,
 	kedaPullsubscriptionController kedapullsubscription.Constructor,
 	topicController topic.Constructor,
@@ -74,6 +80,9 @@ func Controllers(
 		injection.ControllerConstructor(schedulerController),
 		injection.ControllerConstructor(pubsubController),
 		injection.ControllerConstructor(buildController),
+		injection.ControllerConstructor(monitoringController),
+		injection.ControllerConstructor(budgetsController),
+		injection.ControllerConstructor(artifactRegistryController),
 		injection.ControllerConstructor(pullsubscriptionController),
 		injection.ControllerConstructor(kedaPullsubscriptionController),
 		injection.ControllerConstructor(topicController),
@@ -23,8 +23,11 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/broker"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell"
 	"github.com/google/knative-gcp/pkg/reconciler/deployment"
+	"github.com/google/knative-gcp/pkg/reconciler/events/artifactregistry"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
+	"github.com/google/knative-gcp/pkg/reconciler/events/budgets"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -50,6 +53,9 @@ func InitializeControllers(ctx context.Context) ([]injection.ControllerConstruct
 		scheduler.NewConstructor,
 		pubsub.NewConstructor,
 		build.NewConstructor,
+		monitoring.NewConstructor,
+		budgets.NewConstructor,
+		artifactregistry.NewConstructor,
 		static.NewConstructor,
 		keda.NewConstructor,
 		topic.NewConstructor,
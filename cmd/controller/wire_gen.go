@@ -13,8 +13,11 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/broker"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell"
 	"github.com/google/knative-gcp/pkg/reconciler/deployment"
+	"github.com/google/knative-gcp/pkg/reconciler/events/artifactregistry"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
+	"github.com/google/knative-gcp/pkg/reconciler/events/budgets"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -49,6 +52,9 @@ func InitializeControllers(ctx context.Context) ([]injection.ControllerConstruct
 	schedulerConstructor := scheduler.NewConstructor(iamPolicyManager, storeSingleton)
 	pubsubConstructor := pubsub.NewConstructor(iamPolicyManager, storeSingleton)
 	buildConstructor := build.NewConstructor(iamPolicyManager, storeSingleton)
+	monitoringConstructor := monitoring.NewConstructor(iamPolicyManager, storeSingleton)
+	budgetsConstructor := budgets.NewConstructor(iamPolicyManager, storeSingleton)
+	artifactregistryConstructor := artifactregistry.NewConstructor(iamPolicyManager, storeSingleton)
 	staticConstructor := static.NewConstructor(iamPolicyManager, storeSingleton)
 	kedaConstructor := keda.NewConstructor(iamPolicyManager, storeSingleton)
 	dataresidencyStoreSingleton := &dataresidency.StoreSingleton{}
@@ -58,6 +64,6 @@ func InitializeControllers(ctx context.Context) ([]injection.ControllerConstruct
 	brokerConstructor := broker.NewConstructor(dataresidencyStoreSingleton)
 	deploymentConstructor := deployment.NewConstructor()
 	brokercellConstructor := brokercell.NewConstructor()
-	v2 := Controllers(constructor, storageConstructor, schedulerConstructor, pubsubConstructor, buildConstructor, staticConstructor, kedaConstructor, topicConstructor, channelConstructor, triggerConstructor, brokerConstructor, deploymentConstructor, brokercellConstructor)
+	v2 := Controllers(constructor, storageConstructor, schedulerConstructor, pubsubConstructor, buildConstructor, monitoringConstructor, budgetsConstructor, artifactregistryConstructor, staticConstructor, kedaConstructor, topicConstructor, channelConstructor, triggerConstructor, brokerConstructor, deploymentConstructor, brokercellConstructor)
 	return v2, nil
 }
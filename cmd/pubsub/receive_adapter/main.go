@@ -17,16 +17,21 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"time"
 
+	"cloud.google.com/go/pubsub"
 	"go.uber.org/zap"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/tracing"
 
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	intereventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
 	. "github.com/google/knative-gcp/pkg/pubsub/adapter"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	tracingconfig "github.com/google/knative-gcp/pkg/tracing"
@@ -58,10 +63,22 @@ type envConfig struct {
 	// Otherwise, only Sink is used (for either the sub.reply or sub.reply)
 	Transformer string `envconfig:"TRANSFORMER_URI"`
 
+	// Environment variable containing the reply URI, where the events replied
+	// by the sink are sent to.
+	Reply string `envconfig:"REPLY_URI"`
+
 	// Environment variable specifying the type of adapter to use.
 	// Used for CE conversion.
 	AdapterType string `envconfig:"ADAPTER_TYPE"`
 
+	// Environment variable containing the JSON encoded converter spec used
+	// by the custom adapter type.
+	ConverterConfig string `envconfig:"CONVERTER_CONFIG"`
+
+	// Environment variable specifying the HTTP mode (binary, structured or
+	// push) to send events in.
+	SendMode string `envconfig:"SEND_MODE"`
+
 	// Topic is the environment variable containing the PubSub Topic being
 	// subscribed to's name. In the form that is unique within the project.
 	// E.g. 'laconia', not 'projects/my-gcp-project/topics/laconia'.
@@ -99,6 +116,66 @@ type envConfig struct {
 
 	// Environment variable containing the resource group. E.g., storages.events.cloud.google.com.
 	ResourceGroup string `envconfig:"RESOURCE_GROUP" default:"pullsubscriptions.internal.pubsub.cloud.google.com" required:"true"`
+
+	// Environment variable containing the maximum number of events per batch.
+	// Only set if the PullSubscription enables batching.
+	BatchMaxEvents int `envconfig:"BATCH_MAX_EVENTS"`
+
+	// Environment variable containing the maximum time a batch is held before
+	// being delivered. E.g. '500ms'.
+	BatchMaxDelay time.Duration `envconfig:"BATCH_MAX_DELAY" default:"1s"`
+
+	// Environment variable containing the number of times a failed delivery is
+	// retried before the message is nacked.
+	DeliveryRetry int `envconfig:"DELIVERY_RETRY"`
+
+	// Environment variable containing the backoff policy between retries, either
+	// 'linear' or 'exponential'.
+	DeliveryBackoffPolicy string `envconfig:"DELIVERY_BACKOFF_POLICY" default:"exponential"`
+
+	// Environment variable containing the base delay between retries. E.g. '1s'.
+	DeliveryBackoffDelay time.Duration `envconfig:"DELIVERY_BACKOFF_DELAY" default:"1s"`
+
+	// Environment variable containing the id of the Pub/Sub topic where
+	// messages that cannot be converted to events are sent to.
+	DeadLetterTopic string `envconfig:"DEAD_LETTER_TOPIC_ID"`
+
+	// Environment variable containing the maximum number of delivered message
+	// IDs kept to skip redeliveries. Deduplication is disabled if unset.
+	DedupCacheSize int `envconfig:"DEDUP_CACHE_SIZE"`
+
+	// Environment variable containing how long delivered message IDs are
+	// kept. E.g. '10m'.
+	DedupTTL time.Duration `envconfig:"DEDUP_TTL" default:"1h"`
+
+	// MaxOutstandingMessages is the maximum number of unprocessed messages (unacknowledged but not yet expired).
+	MaxOutstandingMessages int `envconfig:"MAX_OUTSTANDING_MESSAGES"`
+
+	// MaxOutstandingBytes is the maximum size of unprocessed messages (unacknowledged but not yet expired).
+	MaxOutstandingBytes int `envconfig:"MAX_OUTSTANDING_BYTES"`
+
+	// NumGoroutines is the number of goroutines pulling messages from the subscription.
+	NumGoroutines int `envconfig:"NUM_GOROUTINES"`
+
+	// MaxExtension is the maximum period for which the ack deadline of a message is extended. E.g. '10m'.
+	MaxExtension time.Duration `envconfig:"MAX_EXTENSION"`
+
+	// Environment variable containing a base64 encoded json map of the
+	// attributes the events must match to be delivered.
+	FilterAttributesBase64 string `envconfig:"FILTER_ATTRIBUTES"`
+
+	// Environment variable containing the fraction of the events delivered
+	// among the ones matching the filter attributes. E.g. '0.1'.
+	SampleRate float64 `envconfig:"SAMPLE_RATE"`
+
+	// Environment variable containing the JSON encoded filter of the Cloud
+	// Build notifications delivered by the build adapter type.
+	BuildFilter string `envconfig:"BUILD_FILTER"`
+
+	// Environment variable containing the JSON encoded filter of the
+	// Artifact Registry and Container Registry image notifications delivered
+	// by the artifact registry adapter type.
+	ImageFilter string `envconfig:"IMAGE_FILTER"`
 }
 
 // TODO try to use the common main from broker.
@@ -159,14 +236,62 @@ func main() {
 		logger.Error("Failed to convert base64 extensions to map: %v", zap.Error(err))
 	}
 
+	// Convert base64 encoded json map to filter attributes map.
+	var filterAttributes map[string]string
+	if env.FilterAttributesBase64 != "" {
+		if filterAttributes, err = utils.Base64ToMap(env.FilterAttributesBase64); err != nil {
+			logger.Fatal("Failed to convert base64 filter attributes to map", zap.Error(err))
+		}
+	}
+
+	var customConverter *intereventsv1.ConverterSpec
+	if env.ConverterConfig != "" {
+		customConverter = &intereventsv1.ConverterSpec{}
+		if err := json.Unmarshal([]byte(env.ConverterConfig), customConverter); err != nil {
+			logger.Fatal("Failed to process converter config", zap.Error(err))
+		}
+	}
+
+	var buildFilter *gcpduckv1.BuildFilterSpec
+	if env.BuildFilter != "" {
+		buildFilter = &gcpduckv1.BuildFilterSpec{}
+		if err := json.Unmarshal([]byte(env.BuildFilter), buildFilter); err != nil {
+			logger.Fatal("Failed to process build filter", zap.Error(err))
+		}
+	}
+
+	var imageFilter *gcpduckv1.ImageFilterSpec
+	if env.ImageFilter != "" {
+		imageFilter = &gcpduckv1.ImageFilterSpec{}
+		if err := json.Unmarshal([]byte(env.ImageFilter), imageFilter); err != nil {
+			logger.Fatal("Failed to process image filter", zap.Error(err))
+		}
+	}
+
 	logger.Info("Initializing adapter", zap.String("projectID", projectID), zap.String("topicID", env.Topic), zap.String("subscriptionID", env.Subscription))
 
 	args := &AdapterArgs{
-		TopicID:        env.Topic,
-		ConverterType:  converters.ConverterType(env.AdapterType),
-		SinkURI:        env.Sink,
-		TransformerURI: env.Transformer,
-		Extensions:     extensions,
+		TopicID:           env.Topic,
+		ConverterType:     converters.ConverterType(env.AdapterType),
+		CustomConverter:   customConverter,
+		SendMode:          converters.ModeType(env.SendMode),
+		SinkURI:           env.Sink,
+		TransformerURI:    env.Transformer,
+		ReplyURI:          env.Reply,
+		Extensions:        extensions,
+		BatchMaxEvents:    env.BatchMaxEvents,
+		BatchMaxDelay:     env.BatchMaxDelay,
+		Retry:             env.DeliveryRetry,
+		BackoffPolicy:     eventingduckv1.BackoffPolicyType(env.DeliveryBackoffPolicy),
+		BackoffDelay:      env.DeliveryBackoffDelay,
+		DeadLetterTopicID: env.DeadLetterTopic,
+		DedupCacheSize:    env.DedupCacheSize,
+		DedupTTL:          env.DedupTTL,
+		ReceiveSettings:   buildReceiveSettings(env),
+		FilterAttributes:  filterAttributes,
+		SampleRate:        env.SampleRate,
+		BuildFilter:       buildFilter,
+		ImageFilter:       imageFilter,
 	}
 
 	adapter, err := InitializeAdapter(ctx,
@@ -194,6 +319,25 @@ func main() {
 	logger.Info("Exiting...")
 }
 
+// buildReceiveSettings returns the default receive settings, overridden by the
+// flow control settings that are set.
+func buildReceiveSettings(env envConfig) pubsub.ReceiveSettings {
+	rs := pubsub.DefaultReceiveSettings
+	if env.MaxOutstandingMessages != 0 {
+		rs.MaxOutstandingMessages = env.MaxOutstandingMessages
+	}
+	if env.MaxOutstandingBytes != 0 {
+		rs.MaxOutstandingBytes = env.MaxOutstandingBytes
+	}
+	if env.NumGoroutines > 0 {
+		rs.NumGoroutines = env.NumGoroutines
+	}
+	if env.MaxExtension > 0 {
+		rs.MaxExtension = env.MaxExtension
+	}
+	return rs
+}
+
 func flush(logger *zap.Logger) {
 	_ = logger.Sync()
 	metrics.FlushExporter()
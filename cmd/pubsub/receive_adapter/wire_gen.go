@@ -8,7 +8,6 @@ package main
 import (
 	"context"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter"
-	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 )
 
@@ -19,13 +18,18 @@ func InitializeAdapter(ctx context.Context, maxConnsPerHost clients.MaxConnsPerH
 	if err != nil {
 		return nil, err
 	}
-	subscription := adapter.NewPubSubSubscription(ctx, client, subscriptionID)
+	subscription := adapter.NewPubSubSubscription(ctx, client, subscriptionID, args)
+	topic := adapter.NewDeadLetterTopic(client, args)
 	httpClient := clients.NewHTTPClient(ctx, maxConnsPerHost)
-	converter := converters.NewPubSubConverter()
+	converter, err := adapter.NewConverter(args)
+	if err != nil {
+		return nil, err
+	}
+	dedupStore := adapter.NewDedupStore(args)
 	statsReporter, err := adapter.NewStatsReporter(name, namespace, resourceGroup)
 	if err != nil {
 		return nil, err
 	}
-	adapterAdapter := adapter.NewAdapter(ctx, projectID, namespace, name, resourceGroup, subscription, httpClient, converter, statsReporter, args)
+	adapterAdapter := adapter.NewAdapter(ctx, projectID, namespace, name, resourceGroup, subscription, topic, httpClient, converter, dedupStore, statsReporter, args)
 	return adapterAdapter, nil
 }
@@ -30,6 +30,11 @@ const (
 	// BrokerClass is the annotation value to use when creating a
 	// Google Cloud Broker object.
 	BrokerClass = "googlecloud"
+
+	// ProjectAnnotationKey is the annotation that places the broker's
+	// decouple and retry topics/subscriptions in the given GCP project
+	// instead of the controller's project.
+	ProjectAnnotationKey = "events.cloud.google.com/project"
 )
 
 // +genclient
@@ -107,6 +112,12 @@ type BrokerList struct {
 	Items []Broker `json:"items"`
 }
 
+// Project returns the GCP project the broker's topics and subscriptions live
+// in, or an empty string if the broker uses the controller's project.
+func (b *Broker) Project() string {
+	return b.GetAnnotations()[ProjectAnnotationKey]
+}
+
 // GetGroupVersionKind returns GroupVersionKind for Brokers
 func (b *Broker) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Broker")
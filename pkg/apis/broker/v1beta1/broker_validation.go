@@ -18,21 +18,59 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
+	"github.com/google/go-cmp/cmp"
 	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// projectIDRegex matches GCP project IDs, optionally domain-scoped.
+var projectIDRegex = regexp.MustCompile(`^([a-z][a-z0-9.-]*:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
 // Validate verifies that the Broker is valid.
 func (b *Broker) Validate(ctx context.Context) *apis.FieldError {
-	// We validate the GCP Broker's delivery spec. The eventing webhook will run
-	// the other usual validations.
+	// We validate the GCP Broker's delivery spec and project annotation. The
+	// eventing webhook will run the other usual validations.
+	errs := validateProjectAnnotation(b.Annotations)
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*Broker)
+		errs = errs.Also(b.CheckImmutableFields(ctx, original))
+	}
 	if b.Spec.Delivery == nil {
-		return nil
+		return errs
 	}
 	withNS := apis.AllowDifferentNamespace(apis.WithinParent(ctx, b.ObjectMeta))
-	return ValidateDeliverySpec(withNS, b.Spec.Delivery).ViaField("spec", "delivery")
+	return errs.Also(ValidateDeliverySpec(withNS, b.Spec.Delivery).ViaField("spec", "delivery"))
+}
+
+// CheckImmutableFields checks that the project annotation is not changed, as
+// the broker's topics and subscriptions cannot be moved between projects.
+func (b *Broker) CheckImmutableFields(ctx context.Context, original *Broker) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+	if diff := cmp.Diff(original.Project(), b.Project()); diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", ProjectAnnotationKey)},
+			Details: diff,
+		}
+	}
+	return nil
+}
+
+func validateProjectAnnotation(annotations map[string]string) *apis.FieldError {
+	project, ok := annotations[ProjectAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if !projectIDRegex.MatchString(project) {
+		return apis.ErrInvalidValue(project, fmt.Sprintf("metadata.annotations[%s]", ProjectAnnotationKey))
+	}
+	return nil
 }
 
 func ValidateDeliverySpec(ctx context.Context, spec *eventingduckv1beta1.DeliverySpec) *apis.FieldError {
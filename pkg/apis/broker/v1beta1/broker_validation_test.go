@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/pkg/apis"
@@ -139,6 +140,21 @@ func TestBroker_Validate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "valid project annotation",
+		broker: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{ProjectAnnotationKey: "tenant-project"},
+			},
+		},
+	}, {
+		name: "invalid project annotation",
+		broker: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{ProjectAnnotationKey: "Tenant_Project"},
+			},
+		},
+		want: apis.ErrInvalidValue("Tenant_Project", "metadata.annotations[events.cloud.google.com/project]"),
 	}}
 
 	for _, test := range tests {
@@ -150,3 +166,55 @@ func TestBroker_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBroker_CheckImmutableFields(t *testing.T) {
+	withProject := func(project string) *Broker {
+		b := &Broker{}
+		if project != "" {
+			b.Annotations = map[string]string{ProjectAnnotationKey: project}
+		}
+		return b
+	}
+	tests := []struct {
+		name     string
+		original *Broker
+		current  *Broker
+		wantErr  bool
+	}{{
+		name:     "nil original",
+		original: nil,
+		current:  withProject("tenant-project"),
+	}, {
+		name:     "project unchanged",
+		original: withProject("tenant-project"),
+		current:  withProject("tenant-project"),
+	}, {
+		name:     "project changed",
+		original: withProject("tenant-project"),
+		current:  withProject("other-project"),
+		wantErr:  true,
+	}, {
+		name:     "project added",
+		original: withProject(""),
+		current:  withProject("tenant-project"),
+		wantErr:  true,
+	}, {
+		name:     "project removed",
+		original: withProject("tenant-project"),
+		current:  withProject(""),
+		wantErr:  true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := apis.WithinUpdate(context.Background(), test.original)
+			if test.original == nil {
+				ctx = context.Background()
+			}
+			err := test.current.Validate(ctx)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
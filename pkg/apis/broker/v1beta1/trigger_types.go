@@ -33,6 +33,11 @@ const (
 	// InjectionAnnotation is the annotation key used to enable knative eventing injection for a namespace and automatically create a default broker.
 	// This will be used when the client creates a trigger paired with default broker and the default broker doesn't exist in the namespace
 	InjectionAnnotation = "knative-eventing-injection"
+	// RetryProjectAnnotationKey is the annotation recording the GCP project
+	// the Trigger's retry topic and subscription were created in when it isn't
+	// the controller's project, so that they are deleted from that project even
+	// once the Broker is gone.
+	RetryProjectAnnotationKey = "internal.events.cloud.google.com/retry-project"
 )
 
 // +genclient
@@ -71,6 +71,7 @@ func ToV1PubSubSpec(from duckv1beta1.PubSubSpec) duckv1.PubSubSpec {
 	to.IdentitySpec = ToV1IdentitySpec(from.IdentitySpec)
 	to.Secret = from.Secret
 	to.Project = from.Project
+	to.Dedup = ToV1DedupSpec(from.Dedup)
 	return to
 }
 
@@ -98,6 +99,27 @@ func FromV1PubSubSpec(from duckv1.PubSubSpec) duckv1beta1.PubSubSpec {
 	to.IdentitySpec = FromV1IdentitySpec(from.IdentitySpec)
 	to.Secret = from.Secret
 	to.Project = from.Project
+	to.Dedup = FromV1DedupSpec(from.Dedup)
+	return to
+}
+
+func ToV1DedupSpec(from *duckv1beta1.DedupSpec) *duckv1.DedupSpec {
+	if from == nil {
+		return nil
+	}
+	to := &duckv1.DedupSpec{}
+	to.CacheSize = from.CacheSize
+	to.TTL = from.TTL
+	return to
+}
+
+func FromV1DedupSpec(from *duckv1.DedupSpec) *duckv1beta1.DedupSpec {
+	if from == nil {
+		return nil
+	}
+	to := &duckv1beta1.DedupSpec{}
+	to.CacheSize = from.CacheSize
+	to.TTL = from.TTL
 	return to
 }
 
@@ -74,6 +74,13 @@ const (
 	// minimumKedaSubscriptionSize is the minimum allowed value for the KedaAutoscalingSubscriptionSizeAnnotation annotation.
 	minimumKedaSubscriptionSize = 5
 
+	// MinDeadLetterDeliveryAttempts and MaxDeadLetterDeliveryAttempts are the
+	// bounds Pub/Sub supports on the delivery attempts of a dead letter
+	// policy, which the delivery retry sets when a dead letter sink is
+	// configured. MinDeadLetterDeliveryAttempts is also the default.
+	MinDeadLetterDeliveryAttempts = 5
+	MaxDeadLetterDeliveryAttempts = 100
+
 	// defaultSecretName is the default secret name for the controller
 	DefaultSecretName = "google-cloud-key"
 )
@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -63,6 +64,133 @@ type PubSubSpec struct {
 	// If omitted, defaults to same as the cluster.
 	// +optional
 	Project string `json:"project,omitempty"`
+
+	// Delivery configures how delivering events to the sink is retried before
+	// giving up on them. Retry has two meanings. Without a DeadLetterSink, it
+	// is the number of times the receive adapter retries a failed delivery
+	// before nacking the message, and defaults to 0. With a DeadLetterSink,
+	// the receive adapter does not retry and it is the maximum number of
+	// Pub/Sub delivery attempts before the message is forwarded to the dead
+	// letter topic. It must then be between 5 and 100, and defaults to 5. In
+	// both cases, BackoffDelay also sets the retry policy of the underlying
+	// Pub/Sub subscription. If set, the DeadLetterSink must be a Pub/Sub topic
+	// URI, e.g. pubsub://my-topic.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Reply is a reference to an object that will resolve to a domain name or
+	// a URI where the events replied by the sink are sent to. Replies are
+	// dropped if omitted.
+	// +optional
+	Reply *duckv1.Destination `json:"reply,omitempty"`
+
+	// FlowControl configures how many messages the receive adapter pulls and
+	// processes concurrently. The Pub/Sub client defaults are used if omitted.
+	// +optional
+	FlowControl *FlowControlSpec `json:"flowControl,omitempty"`
+
+	// Filter selects the events delivered to the sink. The messages of the
+	// other events are acked without being delivered.
+	// +optional
+	Filter *EventFilterSpec `json:"filter,omitempty"`
+
+	// Dedup configures the receive adapter to skip the redeliveries of the
+	// messages it already delivered to the sink. Deduplication is disabled
+	// if omitted.
+	// +optional
+	Dedup *DedupSpec `json:"dedup,omitempty"`
+}
+
+// EventFilterSpec defines which events the receive adapter delivers.
+type EventFilterSpec struct {
+	// Attributes filters events by exact match on their CloudEvents attributes
+	// and extensions, with the same semantics as Trigger filters. An empty
+	// value matches any value, as long as the attribute is set.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// SampleRate is the fraction, between 0 (exclusive) and 1, of the events
+	// matching the attributes that are delivered, e.g. '0.1'. All of them are
+	// delivered if omitted.
+	// +optional
+	SampleRate *string `json:"sampleRate,omitempty"`
+}
+
+// BuildFilterSpec defines which Cloud Build notifications the receive adapter
+// delivers. A build matches if it matches every non-empty field, and it
+// matches a field if it has any of its values.
+type BuildFilterSpec struct {
+	// Statuses filters builds by status, e.g. 'SUCCESS' or 'FAILURE'.
+	// +optional
+	Statuses []string `json:"statuses,omitempty"`
+
+	// TriggerIDs filters builds by the ID of the build trigger that started
+	// them.
+	// +optional
+	TriggerIDs []string `json:"triggerIds,omitempty"`
+
+	// Tags filters builds by tag.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Images filters builds by the images they push, e.g.
+	// 'gcr.io/my-project/my-image'.
+	// +optional
+	Images []string `json:"images,omitempty"`
+}
+
+// ImageFilterSpec defines which Artifact Registry and Container Registry image
+// notifications the receive adapter delivers. An image matches if it matches
+// every non-empty field, and it matches a field if it matches any of its values.
+type ImageFilterSpec struct {
+	// Repositories filters images by the repository they are in, e.g.
+	// 'us-docker.pkg.dev/my-project/my-repo' or 'gcr.io/my-project'.
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Images filters images by name, regardless of their tag or digest, e.g.
+	// 'gcr.io/my-project/my-image'.
+	// +optional
+	Images []string `json:"images,omitempty"`
+}
+
+// FlowControlSpec defines the flow control settings of the streaming pull of
+// the receive adapter. Unset fields use the Pub/Sub client defaults.
+type FlowControlSpec struct {
+	// MaxOutstandingMessages is the maximum number of messages received but
+	// not yet acked or nacked. A negative value means no limit.
+	// +optional
+	MaxOutstandingMessages int32 `json:"maxOutstandingMessages,omitempty"`
+
+	// MaxOutstandingBytes is the maximum size of the messages received but
+	// not yet acked or nacked. A negative value means no limit.
+	// +optional
+	MaxOutstandingBytes int64 `json:"maxOutstandingBytes,omitempty"`
+
+	// NumGoroutines is the number of goroutines pulling messages from the
+	// subscription.
+	// +optional
+	NumGoroutines int32 `json:"numGoroutines,omitempty"`
+
+	// MaxExtension is the maximum period for which the ack deadline of a
+	// message is automatically extended, e.g. '10m'. Valid time units are
+	// `s`, `m`, `h`.
+	// +optional
+	MaxExtension *string `json:"maxExtension,omitempty"`
+}
+
+// DedupSpec defines how the receive adapter remembers the IDs of the messages
+// it delivered. The IDs are kept in memory, so the redeliveries to another
+// replica of the receive adapter are not skipped.
+type DedupSpec struct {
+	// CacheSize is the maximum number of delivered message IDs kept. The
+	// least recently delivered ones are forgotten first.
+	CacheSize int32 `json:"cacheSize"`
+
+	// TTL is how long delivered message IDs are kept, e.g. '1h'. Valid time
+	// units are `s`, `m`, `h`. Defaults to 1h.
+	// +optional
+	TTL *string `json:"ttl,omitempty"`
 }
 
 // PubSubStatus shows how we expect folks to embed Addressable in
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/knative-gcp/pkg/apis/duck"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate validates the fields shared by the sources and the
+// PullSubscriptions that configure the delivery of their events.
+func (current *PubSubSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if err := duck.ValidateDelivery(ctx, current.Delivery); err != nil {
+		errs = errs.Also(err)
+	}
+
+	if err := duck.ValidateReply(ctx, current.Reply); err != nil {
+		errs = errs.Also(err)
+	}
+
+	if current.FlowControl != nil {
+		errs = errs.Also(current.FlowControl.Validate(ctx).ViaField("flowControl"))
+	}
+
+	if current.Filter != nil {
+		errs = errs.Also(current.Filter.Validate(ctx).ViaField("filter"))
+	}
+
+	if current.Dedup != nil {
+		errs = errs.Also(current.Dedup.Validate(ctx).ViaField("dedup"))
+	}
+	return errs
+}
+
+func (fc *FlowControlSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if fc.NumGoroutines < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fc.NumGoroutines, "numGoroutines"))
+	}
+	if fc.MaxExtension != nil {
+		// If set, MaxExtension needs to parse to a valid positive duration.
+		me, err := time.ParseDuration(*fc.MaxExtension)
+		if err != nil || me <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*fc.MaxExtension, "maxExtension"))
+		}
+	}
+	return errs
+}
+
+func (d *DedupSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if d.CacheSize < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(d.CacheSize, 1, math.MaxInt32, "cacheSize"))
+	}
+	if d.TTL != nil {
+		// If set, TTL needs to parse to a valid positive duration.
+		ttl, err := time.ParseDuration(*d.TTL)
+		if err != nil || ttl <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*d.TTL, "ttl"))
+		}
+	}
+	return errs
+}
+
+func (f *EventFilterSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for k := range f.Attributes {
+		if k == "" {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "attributes"))
+		}
+	}
+	if f.SampleRate != nil {
+		// If set, SampleRate needs to parse to a number in (0, 1].
+		sr, err := strconv.ParseFloat(*f.SampleRate, 64)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*f.SampleRate, "sampleRate"))
+		} else if sr <= 0 || sr > 1 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(*f.SampleRate, 0, 1, "sampleRate"))
+		}
+	}
+	return errs
+}
+
+// buildStatuses are the statuses of Cloud Build builds.
+var buildStatuses = map[string]bool{
+	"STATUS_UNKNOWN": true,
+	"QUEUED":         true,
+	"WORKING":        true,
+	"SUCCESS":        true,
+	"FAILURE":        true,
+	"INTERNAL_ERROR": true,
+	"TIMEOUT":        true,
+	"CANCELLED":      true,
+	"EXPIRED":        true,
+}
+
+func (f *BuildFilterSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, status := range f.Statuses {
+		if !buildStatuses[status] {
+			errs = errs.Also(apis.ErrInvalidArrayValue(status, "statuses", i))
+		}
+	}
+	for i, id := range f.TriggerIDs {
+		if id == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(id, "triggerIds", i))
+		}
+	}
+	for i, tag := range f.Tags {
+		if tag == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(tag, "tags", i))
+		}
+	}
+	for i, image := range f.Images {
+		if image == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(image, "images", i))
+		}
+	}
+	return errs
+}
+
+func (f *ImageFilterSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, repository := range f.Repositories {
+		if repository == "" || strings.HasSuffix(repository, "/") {
+			errs = errs.Also(apis.ErrInvalidArrayValue(repository, "repositories", i))
+		}
+	}
+	for i, image := range f.Images {
+		if image == "" || strings.HasSuffix(image, "/") {
+			errs = errs.Also(apis.ErrInvalidArrayValue(image, "images", i))
+		}
+	}
+	return errs
+}
+
+// GetSampleRate parses SampleRate and returns 1, i.e. no sampling, if it is
+// not set or an error occurs.
+func (f *EventFilterSpec) GetSampleRate() float64 {
+	if f.SampleRate != nil {
+		if sr, err := strconv.ParseFloat(*f.SampleRate, 64); err == nil {
+			return sr
+		}
+	}
+	return 1
+}
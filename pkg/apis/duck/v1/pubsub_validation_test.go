@@ -0,0 +1,327 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestPubSubSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    PubSubSpec
+		wantErr bool
+	}{
+		"empty": {
+			spec:    PubSubSpec{},
+			wantErr: false,
+		},
+		"valid": {
+			spec: PubSubSpec{
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry: ptr.Int32(5),
+					DeadLetterSink: &duckv1.Destination{
+						URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+					},
+				},
+				Reply:       &duckv1.Destination{URI: apis.HTTP("reply")},
+				FlowControl: &FlowControlSpec{NumGoroutines: 2},
+				Filter:      &EventFilterSpec{SampleRate: ptr.String("0.5")},
+				Dedup:       &DedupSpec{CacheSize: 1000},
+			},
+			wantErr: false,
+		},
+		"invalid delivery": {
+			spec: PubSubSpec{
+				Delivery: &eventingduckv1.DeliverySpec{
+					DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls")},
+				},
+			},
+			wantErr: true,
+		},
+		"invalid reply": {
+			spec:    PubSubSpec{Reply: &duckv1.Destination{}},
+			wantErr: true,
+		},
+		"invalid flowControl": {
+			spec:    PubSubSpec{FlowControl: &FlowControlSpec{NumGoroutines: -1}},
+			wantErr: true,
+		},
+		"invalid filter": {
+			spec:    PubSubSpec{Filter: &EventFilterSpec{SampleRate: ptr.String("2")}},
+			wantErr: true,
+		},
+		"invalid dedup": {
+			spec:    PubSubSpec{Dedup: &DedupSpec{}},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlowControlSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    FlowControlSpec
+		wantErr bool
+	}{
+		"empty": {
+			spec:    FlowControlSpec{},
+			wantErr: false,
+		},
+		"valid": {
+			spec: FlowControlSpec{
+				MaxOutstandingMessages: 100,
+				MaxOutstandingBytes:    -1,
+				NumGoroutines:          2,
+				MaxExtension:           ptr.String("10m"),
+			},
+			wantErr: false,
+		},
+		"negative numGoroutines": {
+			spec:    FlowControlSpec{NumGoroutines: -1},
+			wantErr: true,
+		},
+		"invalid maxExtension": {
+			spec:    FlowControlSpec{MaxExtension: ptr.String("ten minutes")},
+			wantErr: true,
+		},
+		"negative maxExtension": {
+			spec:    FlowControlSpec{MaxExtension: ptr.String("-10m")},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDedupSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    DedupSpec
+		wantErr bool
+	}{
+		"valid": {
+			spec: DedupSpec{
+				CacheSize: 1000,
+				TTL:       ptr.String("10m"),
+			},
+			wantErr: false,
+		},
+		"without ttl": {
+			spec:    DedupSpec{CacheSize: 1000},
+			wantErr: false,
+		},
+		"zero cacheSize": {
+			spec:    DedupSpec{CacheSize: 0},
+			wantErr: true,
+		},
+		"negative cacheSize": {
+			spec:    DedupSpec{CacheSize: -1},
+			wantErr: true,
+		},
+		"invalid ttl": {
+			spec:    DedupSpec{CacheSize: 1000, TTL: ptr.String("an hour")},
+			wantErr: true,
+		},
+		"negative ttl": {
+			spec:    DedupSpec{CacheSize: 1000, TTL: ptr.String("-1h")},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventFilterSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    EventFilterSpec
+		wantErr bool
+	}{
+		"empty": {
+			spec:    EventFilterSpec{},
+			wantErr: false,
+		},
+		"valid": {
+			spec: EventFilterSpec{
+				Attributes: map[string]string{
+					"type":        "google.cloud.audit.log.v1.written",
+					"servicename": "",
+				},
+				SampleRate: ptr.String("0.25"),
+			},
+			wantErr: false,
+		},
+		"empty attribute name": {
+			spec:    EventFilterSpec{Attributes: map[string]string{"": "value"}},
+			wantErr: true,
+		},
+		"invalid sampleRate": {
+			spec:    EventFilterSpec{SampleRate: ptr.String("10%")},
+			wantErr: true,
+		},
+		"zero sampleRate": {
+			spec:    EventFilterSpec{SampleRate: ptr.String("0")},
+			wantErr: true,
+		},
+		"sampleRate above one": {
+			spec:    EventFilterSpec{SampleRate: ptr.String("1.5")},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildFilterSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    BuildFilterSpec
+		wantErr bool
+	}{
+		"empty": {
+			spec:    BuildFilterSpec{},
+			wantErr: false,
+		},
+		"valid": {
+			spec: BuildFilterSpec{
+				Statuses:   []string{"SUCCESS", "FAILURE"},
+				TriggerIDs: []string{"0e5a4f3c-2b1d-4e8a-9c7b-6d5e4f3a2b1c"},
+				Tags:       []string{"release"},
+				Images:     []string{"gcr.io/my-project/my-image"},
+			},
+			wantErr: false,
+		},
+		"unknown status": {
+			spec:    BuildFilterSpec{Statuses: []string{"success"}},
+			wantErr: true,
+		},
+		"empty trigger ID": {
+			spec:    BuildFilterSpec{TriggerIDs: []string{""}},
+			wantErr: true,
+		},
+		"empty tag": {
+			spec:    BuildFilterSpec{Tags: []string{"release", ""}},
+			wantErr: true,
+		},
+		"empty image": {
+			spec:    BuildFilterSpec{Images: []string{""}},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageFilterSpecValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    ImageFilterSpec
+		wantErr bool
+	}{
+		"empty": {
+			spec:    ImageFilterSpec{},
+			wantErr: false,
+		},
+		"valid": {
+			spec: ImageFilterSpec{
+				Repositories: []string{"us-docker.pkg.dev/my-project/my-repo", "gcr.io/my-project"},
+				Images:       []string{"gcr.io/my-project/my-image"},
+			},
+			wantErr: false,
+		},
+		"empty repository": {
+			spec:    ImageFilterSpec{Repositories: []string{""}},
+			wantErr: true,
+		},
+		"repository with trailing slash": {
+			spec:    ImageFilterSpec{Repositories: []string{"gcr.io/my-project/"}},
+			wantErr: true,
+		},
+		"empty image": {
+			spec:    ImageFilterSpec{Images: []string{"gcr.io/my-project/my-image", ""}},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Validate() got %v, want error=%v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventFilterSpecGetSampleRate(t *testing.T) {
+	testCases := map[string]struct {
+		sampleRate *string
+		want       float64
+	}{
+		"unset": {
+			want: 1,
+		},
+		"valid": {
+			sampleRate: ptr.String("0.1"),
+			want:       0.1,
+		},
+		"invalid": {
+			sampleRate: ptr.String("ten percent"),
+			want:       1,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			f := &EventFilterSpec{SampleRate: tc.sampleRate}
+			if got := f.GetSampleRate(); got != tc.want {
+				t.Errorf("GetSampleRate() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
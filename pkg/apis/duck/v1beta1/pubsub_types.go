@@ -63,6 +63,26 @@ type PubSubSpec struct {
 	// If omitted, defaults to same as the cluster.
 	// +optional
 	Project string `json:"project,omitempty"`
+
+	// Dedup configures the receive adapter to skip the redeliveries of the
+	// messages it already delivered to the sink. Deduplication is disabled
+	// if omitted.
+	// +optional
+	Dedup *DedupSpec `json:"dedup,omitempty"`
+}
+
+// DedupSpec defines how the receive adapter remembers the IDs of the messages
+// it delivered. The IDs are kept in memory, so the redeliveries to another
+// replica of the receive adapter are not skipped.
+type DedupSpec struct {
+	// CacheSize is the maximum number of delivered message IDs kept. The
+	// least recently delivered ones are forgotten first.
+	CacheSize int32 `json:"cacheSize"`
+
+	// TTL is how long delivered message IDs are kept, e.g. '1h'. Valid time
+	// units are `s`, `m`, `h`. Defaults to 1h.
+	// +optional
+	TTL *string `json:"ttl,omitempty"`
 }
 
 // PubSubStatus shows how we expect folks to embed Addressable in
@@ -28,13 +28,19 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 var (
 	// The name of a k8s ServiceAccount object must be a valid DNS subdomain name.
 	// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-subdomain-names
 	ksaValidationRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9\-]{0,61}[A-Za-z0-9])?$`)
+
+	// CloudEvents extension attribute names consist of lower-case letters and digits.
+	// https://github.com/cloudevents/spec/blob/v1.0/spec.md#attribute-naming-convention
+	extensionNameRegex = regexp.MustCompile(`^[a-z0-9]+$`)
 )
 
 // ValidateAutoscalingAnnotations validates the autoscaling annotations.
@@ -116,6 +122,56 @@ func CheckImmutableAutoscalingClassAnnotations(current *metav1.ObjectMeta, origi
 	return errs
 }
 
+// IsValidExtensionName reports whether name is a valid CloudEvents extension
+// attribute name.
+func IsValidExtensionName(name string) bool {
+	return extensionNameRegex.MatchString(name)
+}
+
+// ValidateDelivery validates the delivery spec of a source or PullSubscription.
+// With a dead letter sink, the retry is the number of Pub/Sub delivery attempts,
+// so it must be within the bounds Pub/Sub supports.
+func ValidateDelivery(ctx context.Context, delivery *eventingduckv1.DeliverySpec) *apis.FieldError {
+	if delivery == nil {
+		return nil
+	}
+	errs := delivery.Validate(ctx)
+	if delivery.DeadLetterSink != nil {
+		errs = errs.Also(validateDeadLetterSink(delivery.DeadLetterSink).ViaField("deadLetterSink"))
+		if delivery.Retry != nil && (*delivery.Retry < MinDeadLetterDeliveryAttempts || *delivery.Retry > MaxDeadLetterDeliveryAttempts) {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(*delivery.Retry, MinDeadLetterDeliveryAttempts, MaxDeadLetterDeliveryAttempts, "retry"))
+		}
+	}
+	return errs.ViaField("delivery")
+}
+
+// ValidateReply validates the reply destination of a source or PullSubscription.
+func ValidateReply(ctx context.Context, reply *duckv1.Destination) *apis.FieldError {
+	if reply == nil {
+		return nil
+	}
+	return reply.Validate(ctx).ViaField("reply")
+}
+
+// validateDeadLetterSink checks that the dead letter sink is a Pub/Sub topic,
+// in the form pubsub://<topic-id>.
+func validateDeadLetterSink(sink *duckv1.Destination) *apis.FieldError {
+	if sink.URI == nil {
+		return apis.ErrMissingField("uri")
+	}
+	if sink.URI.Scheme != "pubsub" {
+		return apis.ErrInvalidValue("Dead letter sink URI scheme should be pubsub", "uri")
+	}
+	topicID := sink.URI.Host
+	if topicID == "" {
+		return apis.ErrInvalidValue("Dead letter topic must not be empty", "uri")
+	}
+	if len(topicID) > 255 {
+		return apis.ErrInvalidValue("Dead letter topic maximum length is 255 characters", "uri")
+	}
+	return nil
+}
+
 // ValidateCredential checks secret and service account.
 func ValidateCredential(secret *corev1.SecretKeySelector, kServiceAccountName string) *apis.FieldError {
 	if secret != nil && kServiceAccountName != "" {
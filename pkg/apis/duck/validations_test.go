@@ -27,7 +27,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestValidateAutoscalingAnnotations(t *testing.T) {
@@ -345,3 +348,154 @@ func TestValidateCredential(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidExtensionName(t *testing.T) {
+	testCases := map[string]bool{
+		"myextension": true,
+		"ext1":        true,
+		"":            false,
+		"myExtension": false,
+		"my-ext":      false,
+		"my_ext":      false,
+	}
+	for name, want := range testCases {
+		if got := IsValidExtensionName(name); got != want {
+			t.Errorf("IsValidExtensionName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestValidateDelivery(t *testing.T) {
+	exponential := eventingduckv1.BackoffPolicyExponential
+	invalidPolicy := eventingduckv1.BackoffPolicyType("invalid")
+	testCases := []struct {
+		name     string
+		delivery *eventingduckv1.DeliverySpec
+		wantErr  bool
+	}{{
+		name:     "nil delivery",
+		delivery: nil,
+		wantErr:  false,
+	}, {
+		name: "valid delivery",
+		delivery: &eventingduckv1.DeliverySpec{
+			Retry:         ptr.Int32(3),
+			BackoffPolicy: &exponential,
+			BackoffDelay:  ptr.String("PT1S"),
+		},
+		wantErr: false,
+	}, {
+		name: "negative retry",
+		delivery: &eventingduckv1.DeliverySpec{
+			Retry: ptr.Int32(-1),
+		},
+		wantErr: true,
+	}, {
+		name: "invalid backoff policy",
+		delivery: &eventingduckv1.DeliverySpec{
+			BackoffPolicy: &invalidPolicy,
+		},
+		wantErr: true,
+	}, {
+		name: "invalid backoff delay",
+		delivery: &eventingduckv1.DeliverySpec{
+			BackoffDelay: ptr.String("1s"),
+		},
+		wantErr: true,
+	}, {
+		name: "valid dead letter sink",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+			},
+		},
+		wantErr: false,
+	}, {
+		name: "dead letter sink with retry",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+			},
+			Retry: ptr.Int32(10),
+		},
+		wantErr: false,
+	}, {
+		name: "dead letter sink with too few delivery attempts",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+			},
+			Retry: ptr.Int32(3),
+		},
+		wantErr: true,
+	}, {
+		name: "dead letter sink with too many delivery attempts",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+			},
+			Retry: ptr.Int32(101),
+		},
+		wantErr: true,
+	}, {
+		name: "dead letter sink without uri",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				Ref: &duckv1.KReference{Kind: "Service", Name: "dls", APIVersion: "v1"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "dead letter sink is not a pubsub topic",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: apis.HTTP("dls"),
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "dead letter sink with empty topic",
+		delivery: &eventingduckv1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{
+				URI: &apis.URL{Scheme: "pubsub"},
+			},
+		},
+		wantErr: true,
+	}}
+
+	for _, tc := range testCases {
+		errs := ValidateDelivery(context.Background(), tc.delivery)
+		got := errs != nil
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s: unexpected error (-want, +got) = %v", tc.name, diff)
+		}
+	}
+}
+
+func TestValidateReply(t *testing.T) {
+	testCases := []struct {
+		name    string
+		reply   *duckv1.Destination
+		wantErr bool
+	}{{
+		name:    "nil reply",
+		reply:   nil,
+		wantErr: false,
+	}, {
+		name:    "valid reply",
+		reply:   &duckv1.Destination{URI: apis.HTTP("reply")},
+		wantErr: false,
+	}, {
+		name:    "empty reply",
+		reply:   &duckv1.Destination{},
+		wantErr: true,
+	}}
+
+	for _, tc := range testCases {
+		errs := ValidateReply(context.Background(), tc.reply)
+		got := errs != nil
+		if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+			t.Errorf("%s: unexpected error (-want, +got) = %v", tc.name, diff)
+		}
+	}
+}
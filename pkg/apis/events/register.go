@@ -22,6 +22,9 @@ import "k8s.io/apimachinery/pkg/runtime/schema"
 const (
 	GroupName       = "events.cloud.google.com"
 	CloudBuildTopic = "cloud-builds"
+	// CloudArtifactRegistryTopic is the topic Artifact Registry and Container
+	// Registry publish image notifications to.
+	CloudArtifactRegistryTopic = "gcr"
 )
 
 var (
@@ -50,4 +53,19 @@ var (
 		Group:    GroupName,
 		Resource: "cloudbuildsources",
 	}
+	// CloudMonitoringAlertSourcesResource represents a CloudMonitoringAlertSource.
+	CloudMonitoringAlertSourcesResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "cloudmonitoringalertsources",
+	}
+	// CloudBillingBudgetSourcesResource represents a CloudBillingBudgetSource.
+	CloudBillingBudgetSourcesResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "cloudbillingbudgetsources",
+	}
+	// CloudArtifactRegistrySourcesResource represents a CloudArtifactRegistrySource.
+	CloudArtifactRegistrySourcesResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "cloudartifactregistrysources",
+	}
 )
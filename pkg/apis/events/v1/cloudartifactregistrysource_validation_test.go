@@ -0,0 +1,520 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	gcpauthtesthelper "github.com/google/knative-gcp/pkg/apis/configs/gcpauth/testhelper"
+
+	"github.com/google/knative-gcp/pkg/apis/duck"
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	metadatatesting "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var (
+	artifactRegistrySourceSpec = CloudArtifactRegistrySourceSpec{
+		PubSubSpec: gcpduckv1.PubSubSpec{
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "secret-name",
+				},
+				Key: "secret-key",
+			},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "foo",
+						Kind:       "bar",
+						Namespace:  "baz",
+						Name:       "qux",
+					},
+				},
+			},
+			Project: "my-eventing-project",
+		},
+	}
+
+	artifactRegistrySourceSpecWithKSA = CloudArtifactRegistrySourceSpec{
+		PubSubSpec: gcpduckv1.PubSubSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "foo",
+						Kind:       "bar",
+						Namespace:  "baz",
+						Name:       "qux",
+					},
+				},
+			},
+			IdentitySpec: gcpduckv1.IdentitySpec{
+				ServiceAccountName: "old-service-account",
+			},
+			Project: "my-eventing-project",
+		},
+	}
+)
+
+func TestCloudArtifactRegistrySourceCheckValidationFields(t *testing.T) {
+	testCases := map[string]struct {
+		spec  CloudArtifactRegistrySourceSpec
+		error bool
+	}{
+		"ok": {
+			spec:  artifactRegistrySourceSpec,
+			error: false,
+		},
+		"bad sink, name": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink.Ref.Name = ""
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, apiVersion": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink.Ref.APIVersion = ""
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, kind": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink.Ref.Kind = ""
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, empty": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, uri scheme": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{
+					URI: &apis.URL{
+						Host: "example.com",
+					},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, uri host": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{
+					URI: &apis.URL{
+						Scheme: "http",
+					},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad sink, uri and ref": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{
+					URI: &apis.URL{
+						Scheme: "http",
+						Host:   "example.com",
+					},
+					Ref: &duckv1.KReference{
+						Name: "foo",
+					},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"invalid secret, missing key": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.Secret = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "name",
+					},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"nil service account": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				return *obj
+			}(),
+			error: false,
+		},
+		"invalid k8s service account": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.ServiceAccountName = invalidServiceAccountName
+				return *obj
+			}(),
+			error: true,
+		},
+		"have k8s service account and secret at the same time": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.ServiceAccountName = validServiceAccountName
+				obj.Secret = &gcpauthtesthelper.Secret
+				return *obj
+			}(),
+			error: true,
+		},
+		"valid image filter": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.ImageFilter = &gcpduckv1.ImageFilterSpec{
+					Repositories: []string{"us-docker.pkg.dev/my-project/my-repo"},
+					Images:       []string{"gcr.io/my-project/my-image"},
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"invalid image filter repository": {
+			spec: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.ImageFilter = &gcpduckv1.ImageFilterSpec{
+					Repositories: []string{"gcr.io/my-project/"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.TODO())
+			if tc.error != (err != nil) {
+				t.Fatalf("Unexpected validation failure. Got %v", err)
+			}
+		})
+	}
+}
+
+func TestCloudArtifactRegistrySourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		orig              interface{}
+		updated           CloudArtifactRegistrySourceSpec
+		origAnnotation    map[string]string
+		updatedAnnotation map[string]string
+		allowed           bool
+	}{
+		"nil orig": {
+			updated: artifactRegistrySourceSpec,
+			allowed: true,
+		},
+		"ClusterName annotation changed": {
+			origAnnotation: map[string]string{
+				duck.ClusterNameAnnotation: metadatatesting.FakeClusterName + "old",
+			},
+			updatedAnnotation: map[string]string{
+				duck.ClusterNameAnnotation: metadatatesting.FakeClusterName + "new",
+			},
+			allowed: false,
+		},
+		"AnnotationClass annotation changed": {
+			origAnnotation: map[string]string{
+				duck.AutoscalingClassAnnotation: duck.KEDA,
+			},
+			updatedAnnotation: map[string]string{
+				duck.AutoscalingClassAnnotation: duck.KEDA + "new",
+			},
+			allowed: false,
+		},
+		"AnnotationClass annotation added": {
+			origAnnotation: map[string]string{},
+			updatedAnnotation: map[string]string{
+				duck.AutoscalingClassAnnotation: duck.KEDA,
+			},
+			allowed: false,
+		},
+		"AnnotationClass annotation deleted": {
+			origAnnotation: map[string]string{
+				duck.AutoscalingClassAnnotation: duck.KEDA,
+			},
+			updatedAnnotation: map[string]string{},
+			allowed:           false,
+		},
+		"Secret.Name changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "some-other-name",
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: artifactRegistrySourceSpec.Sink,
+					},
+				},
+			},
+			allowed: false,
+		},
+		"Secret.Key changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: "some-other-key",
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: artifactRegistrySourceSpec.Sink,
+					},
+				},
+			},
+			allowed: false,
+		},
+		"Project changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: "some-other-project",
+					SourceSpec: duckv1.SourceSpec{
+						Sink: artifactRegistrySourceSpec.Sink,
+					},
+				},
+			},
+			allowed: false,
+		},
+		"ServiceAccountName changed": {
+			orig: &artifactRegistrySourceSpecWithKSA,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					IdentitySpec: gcpduckv1.IdentitySpec{
+						ServiceAccountName: "new-service-account",
+					},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: artifactRegistrySourceSpecWithKSA.Sink,
+					},
+					Project: artifactRegistrySourceSpecWithKSA.Project,
+				},
+			},
+			allowed: false,
+		},
+		"ServiceAccountName added": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: artifactRegistrySourceSpec.Sink,
+					},
+					IdentitySpec: gcpduckv1.IdentitySpec{
+						ServiceAccountName: "old-service-account",
+					},
+				},
+			},
+			allowed: false,
+		},
+		"ClusterName annotation added": {
+			origAnnotation: nil,
+			updatedAnnotation: map[string]string{
+				duck.ClusterNameAnnotation: metadatatesting.FakeClusterName + "new",
+			},
+			allowed: true,
+		},
+		"Sink.APIVersion changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "some-other-api-version",
+								Kind:       artifactRegistrySourceSpec.Sink.Ref.Kind,
+								Namespace:  artifactRegistrySourceSpec.Sink.Ref.Namespace,
+								Name:       artifactRegistrySourceSpec.Sink.Ref.Name,
+							},
+						},
+					},
+				},
+			},
+			allowed: true,
+		},
+		"Sink.Kind changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: artifactRegistrySourceSpec.Sink.Ref.APIVersion,
+								Kind:       "some-other-kind",
+								Namespace:  artifactRegistrySourceSpec.Sink.Ref.Namespace,
+								Name:       artifactRegistrySourceSpec.Sink.Ref.Name,
+							},
+						},
+					},
+				},
+			},
+			allowed: true,
+		},
+		"Sink.Namespace changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: artifactRegistrySourceSpec.Sink.Ref.APIVersion,
+								Kind:       artifactRegistrySourceSpec.Sink.Ref.Kind,
+								Namespace:  "some-other-namespace",
+								Name:       artifactRegistrySourceSpec.Sink.Ref.Name,
+							},
+						},
+					},
+				},
+			},
+			allowed: true,
+		},
+		"Sink.Name changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: CloudArtifactRegistrySourceSpec{
+				PubSubSpec: gcpduckv1.PubSubSpec{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: artifactRegistrySourceSpec.Secret.Name,
+						},
+						Key: artifactRegistrySourceSpec.Secret.Key,
+					},
+					Project: artifactRegistrySourceSpec.Project,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: artifactRegistrySourceSpec.Sink.Ref.APIVersion,
+								Kind:       artifactRegistrySourceSpec.Sink.Ref.Kind,
+								Namespace:  artifactRegistrySourceSpec.Sink.Ref.Namespace,
+								Name:       "some-other-name",
+							},
+						},
+					},
+				},
+			},
+			allowed: true,
+		},
+		"ImageFilter changed": {
+			orig: &artifactRegistrySourceSpec,
+			updated: func() CloudArtifactRegistrySourceSpec {
+				obj := artifactRegistrySourceSpec.DeepCopy()
+				obj.ImageFilter = &gcpduckv1.ImageFilterSpec{
+					Images: []string{"gcr.io/my-project/my-image"},
+				}
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"no change": {
+			orig:    &artifactRegistrySourceSpec,
+			updated: artifactRegistrySourceSpec,
+			allowed: true,
+		},
+		"no spec": {
+			orig:    []string{"wrong"},
+			updated: artifactRegistrySourceSpec,
+			allowed: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var orig *CloudArtifactRegistrySource
+
+			if tc.origAnnotation != nil {
+				orig = &CloudArtifactRegistrySource{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: tc.origAnnotation,
+					},
+				}
+			} else if tc.orig != nil {
+				if spec, ok := tc.orig.(*CloudArtifactRegistrySourceSpec); ok {
+					orig = &CloudArtifactRegistrySource{
+						Spec: *spec,
+					}
+				}
+			}
+			updated := &CloudArtifactRegistrySource{
+				ObjectMeta: v1.ObjectMeta{
+					Annotations: tc.updatedAnnotation,
+				},
+				Spec: tc.updated,
+			}
+			err := updated.CheckImmutableFields(context.TODO(), orig)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
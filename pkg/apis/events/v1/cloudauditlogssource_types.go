@@ -74,15 +74,35 @@ type CloudAuditLogsSourceSpec struct {
 	// The CloudAuditLogsSource will pull events matching the following
 	// parameters:
 
-	// The GCP service providing audit logs. Required.
-	ServiceName string `json:"serviceName"`
+	// The GCP service providing audit logs. Required unless LogFilter is
+	// set.
+	ServiceName string `json:"serviceName,omitempty"`
 	// The name of the service method or operation. For API calls,
-	// this should be the name of the API method. Required.
-	MethodName string `json:"methodName"`
+	// this should be the name of the API method. Required unless LogFilter
+	// is set.
+	MethodName string `json:"methodName,omitempty"`
 	// The resource or collection that is the target of the
 	// operation. The name is a scheme-less URI, not including the
 	// API service name.
 	ResourceName string `json:"resourceName,omitempty"`
+
+	// Parent is the organization, folder or billing account whose audit
+	// logs, including the ones of its children, are delivered. It is in the
+	// form organizations/[ORGANIZATION_ID], folders/[FOLDER_ID] or
+	// billingAccounts/[BILLING_ACCOUNT_ID]. If omitted, the audit logs of
+	// the project of the source are delivered.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// LogFilter is a Cloud Logging advanced filter selecting the log
+	// entries to deliver, see
+	// https://cloud.google.com/logging/docs/view/advanced-queries. It is
+	// mutually exclusive with ServiceName, MethodName and ResourceName. When
+	// set, any log entry matching the filter is delivered as a
+	// google.cloud.logging.logEntry.v1.written event, rather than only audit
+	// logs.
+	// +optional
+	LogFilter string `json:"logFilter,omitempty"`
 }
 
 type CloudAuditLogsSourceStatus struct {
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -28,6 +29,13 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// auditLogsParentRegexp matches the resources whose audit logs can be
+// delivered through an aggregated sink.
+var auditLogsParentRegexp = regexp.MustCompile(`^(organizations|folders|billingAccounts)/[^/]+$`)
+
+// maxLogFilterLength is the longest filter a Cloud Logging sink accepts.
+const maxLogFilterLength = 20000
+
 func (current *CloudAuditLogsSource) Validate(ctx context.Context) *apis.FieldError {
 	err := current.Spec.Validate(ctx).ViaField("spec")
 
@@ -48,33 +56,100 @@ func (current *CloudAuditLogsSourceSpec) Validate(ctx context.Context) *apis.Fie
 		errs = errs.Also(err.ViaField("sink"))
 	}
 
-	// ServiceName [required]
-	if current.ServiceName == "" {
-		errs = errs.Also(apis.ErrMissingField("serviceName"))
+	if current.LogFilter != "" {
+		// LogFilter [optional], mutually exclusive with the audit log fields.
+		set := []string{"logFilter"}
+		if current.ServiceName != "" {
+			set = append(set, "serviceName")
+		}
+		if current.MethodName != "" {
+			set = append(set, "methodName")
+		}
+		if current.ResourceName != "" {
+			set = append(set, "resourceName")
+		}
+		if len(set) > 1 {
+			errs = errs.Also(apis.ErrMultipleOneOf(set...))
+		}
+		if err := validateLogFilter(current.LogFilter); err != nil {
+			errs = errs.Also(err.ViaField("logFilter"))
+		}
+	} else {
+		// ServiceName [required]
+		if current.ServiceName == "" {
+			errs = errs.Also(apis.ErrMissingField("serviceName"))
+		}
+		// MethodName [required]
+		if current.MethodName == "" {
+			errs = errs.Also(apis.ErrMissingField("methodName"))
+		}
 	}
-	// MethodName [required]
-	if current.MethodName == "" {
-		errs = errs.Also(apis.ErrMissingField("methodName"))
+
+	// Parent [optional]
+	if current.Parent != "" && !auditLogsParentRegexp.MatchString(current.Parent) {
+		errs = errs.Also(apis.ErrInvalidValue(current.Parent, "parent"))
 	}
 
 	if err := duck.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
 
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
 	return errs
 }
 
+// validateLogFilter performs a shallow syntax check of a Cloud Logging
+// advanced filter, so that obviously broken filters are rejected before the
+// sink creation fails: quotes must be terminated and parentheses balanced.
+func validateLogFilter(filter string) *apis.FieldError {
+	if len(filter) > maxLogFilterLength {
+		return apis.ErrOutOfBoundsValue(len(filter), 1, maxLogFilterLength, apis.CurrentField)
+	}
+	depth := 0
+	inQuotes := false
+	for i := 0; i < len(filter); i++ {
+		switch c := filter[i]; {
+		case inQuotes && c == '\\':
+			// Skip the escaped character.
+			i++
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth < 0 {
+				return invalidLogFilter(filter, "unbalanced parentheses")
+			}
+		}
+	}
+	if inQuotes {
+		return invalidLogFilter(filter, "unterminated string")
+	}
+	if depth != 0 {
+		return invalidLogFilter(filter, "unbalanced parentheses")
+	}
+	return nil
+}
+
+func invalidLogFilter(filter, details string) *apis.FieldError {
+	err := apis.ErrInvalidValue(filter, apis.CurrentField)
+	err.Details = details
+	return err
+}
+
 func (current *CloudAuditLogsSource) CheckImmutableFields(ctx context.Context, original *CloudAuditLogsSource) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 
 	var errs *apis.FieldError
-	// Modification of Topic, Secret, ServiceAccountName, Project, ServiceName, MethodName and ResourceName are not allowed.
+	// Modification of Topic, Secret, ServiceAccountName, Project, ServiceName, MethodName, ResourceName, Parent and LogFilter are not allowed.
 	// Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudAuditLogsSourceSpec{},
-			"Sink", "CloudEventOverrides")); diff != "" {
+			"Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup")); diff != "" {
 		errs = errs.Also(
 			&apis.FieldError{
 				Message: "Immutable fields changed (-old +new)",
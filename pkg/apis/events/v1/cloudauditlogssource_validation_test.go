@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/knative-gcp/pkg/apis/duck"
@@ -26,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 
 	gcpauthtesthelper "github.com/google/knative-gcp/pkg/apis/configs/gcpauth/testhelper"
 	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
@@ -156,6 +158,97 @@ func TestCloudAuditLogsSourceValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"ok filter": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.Filter = &gcpduckv1.EventFilterSpec{
+					Attributes: map[string]string{"methodname": "storage.buckets.create"},
+					SampleRate: ptr.String("0.1"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad filter, sampleRate": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.Filter = &gcpduckv1.EventFilterSpec{
+					SampleRate: ptr.String("-0.1"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok parent": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.Parent = "organizations/123456789"
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad parent": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.Parent = "projects/my-project"
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok log filter": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.ServiceName = ""
+				obj.MethodName = ""
+				obj.ResourceName = ""
+				obj.LogFilter = `resource.type="gce_instance" AND (severity>=ERROR OR textPayload:"(panic")`
+				return *obj
+			}(),
+			error: false,
+		},
+		"log filter with serviceName": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.ServiceName = ""
+				obj.ResourceName = ""
+				obj.LogFilter = `severity>=ERROR`
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad log filter, unbalanced parentheses": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.ServiceName = ""
+				obj.MethodName = ""
+				obj.ResourceName = ""
+				obj.LogFilter = `(severity>=ERROR OR severity=WARNING))`
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad log filter, unterminated string": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.ServiceName = ""
+				obj.MethodName = ""
+				obj.ResourceName = ""
+				obj.LogFilter = `textPayload:"panic \"`
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad log filter, too long": {
+			spec: func() CloudAuditLogsSourceSpec {
+				obj := auditLogsSourceSpec.DeepCopy()
+				obj.ServiceName = ""
+				obj.MethodName = ""
+				obj.ResourceName = ""
+				obj.LogFilter = strings.Repeat("a", maxLogFilterLength+1)
+				return *obj
+			}(),
+			error: true,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -241,6 +334,25 @@ func TestCloudAuditLogsSourceCheckImmutableFields(t *testing.T) {
 			},
 			allowed: false,
 		},
+		"Parent changed": {
+			orig: &auditLogsSourceSpec,
+			updated: CloudAuditLogsSourceSpec{
+				MethodName:   auditLogsSourceSpec.MethodName,
+				PubSubSpec:   auditLogsSourceSpec.PubSubSpec,
+				ResourceName: auditLogsSourceSpec.ResourceName,
+				ServiceName:  auditLogsSourceSpec.ServiceName,
+				Parent:       "folders/123456789",
+			},
+			allowed: false,
+		},
+		"LogFilter changed": {
+			orig: &auditLogsSourceSpec,
+			updated: CloudAuditLogsSourceSpec{
+				PubSubSpec: auditLogsSourceSpec.PubSubSpec,
+				LogFilter:  `severity>=ERROR`,
+			},
+			allowed: false,
+		},
 		"Project changed": {
 			orig: &auditLogsSourceSpec,
 			updated: CloudAuditLogsSourceSpec{
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCloudBillingBudgetSourceStatusIsReady(t *testing.T) {
+	tests := []struct {
+		name                string
+		s                   *CloudBillingBudgetSourceStatus
+		wantConditionStatus corev1.ConditionStatus
+		want                bool
+	}{{
+		name: "uninitialized",
+		s:    &CloudBillingBudgetSourceStatus{},
+		want: false,
+	}, {
+		name: "initialized",
+		s: func() *CloudBillingBudgetSourceStatus {
+			s := &CloudBillingBudgetSource{}
+			s.Status.InitializeConditions()
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionUnknown,
+	}, {
+		name: "budget not ready",
+		s: func() *CloudBillingBudgetSourceStatus {
+			s := &CloudBillingBudgetSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkBudgetNotReady("NotReady", "test message")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+	}, {
+		name: "budget unknown",
+		s: func() *CloudBillingBudgetSourceStatus {
+			s := &CloudBillingBudgetSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkBudgetUnknown("Unknown", "test message")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionUnknown,
+	}, {
+		name: "ready",
+		s: func() *CloudBillingBudgetSourceStatus {
+			s := &CloudBillingBudgetSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkBudgetReady("billingAccounts/012345-6789AB-CDEF01/budgets/budget-id")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantConditionStatus != "" {
+				gotConditionStatus := test.s.GetTopLevelCondition().Status
+				if gotConditionStatus != test.wantConditionStatus {
+					t.Errorf("unexpected condition status: want %v, got %v", test.wantConditionStatus, gotConditionStatus)
+				}
+			}
+			got := test.s.IsReady()
+			if got != test.want {
+				t.Errorf("unexpected readiness: want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	kngcpduck "github.com/google/knative-gcp/pkg/duck/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudBillingBudgetSource is a specification for a CloudBillingBudgetSource resource.
+type CloudBillingBudgetSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudBillingBudgetSourceSpec   `json:"spec"`
+	Status CloudBillingBudgetSourceStatus `json:"status"`
+}
+
+// Verify that CloudBillingBudgetSource matches various duck types.
+var (
+	_ apis.Convertible             = (*CloudBillingBudgetSource)(nil)
+	_ apis.Defaultable             = (*CloudBillingBudgetSource)(nil)
+	_ apis.Validatable             = (*CloudBillingBudgetSource)(nil)
+	_ runtime.Object               = (*CloudBillingBudgetSource)(nil)
+	_ kmeta.OwnerRefable           = (*CloudBillingBudgetSource)(nil)
+	_ resourcesemantics.GenericCRD = (*CloudBillingBudgetSource)(nil)
+	_ kngcpduck.Identifiable       = (*CloudBillingBudgetSource)(nil)
+	_ kngcpduck.PubSubable         = (*CloudBillingBudgetSource)(nil)
+	_ duckv1.KRShaped              = (*CloudBillingBudgetSource)(nil)
+)
+
+// CloudBillingBudgetSourceSpec is the spec for a CloudBillingBudgetSource resource.
+type CloudBillingBudgetSourceSpec struct {
+	// This brings in the PubSub based Source Specs. Includes:
+	// Sink, CloudEventOverrides, Secret and Project
+	gcpduckv1.PubSubSpec `json:",inline"`
+
+	// BillingAccount is the ID of the billing account of the budget, e.g.
+	// 012345-6789AB-CDEF01.
+	BillingAccount string `json:"billingAccount"`
+
+	// Budget is the ID of the budget whose notifications are sent. The topic
+	// of the source is set as the Pub/Sub topic of the budget.
+	Budget string `json:"budget"`
+}
+
+const (
+	// CloudBillingBudgetSourceConditionReady has status True when the
+	// CloudBillingBudgetSource is ready to send events.
+	CloudBillingBudgetSourceConditionReady = apis.ConditionReady
+
+	// BudgetReady has status True when the Pub/Sub topic of the
+	// CloudBillingBudgetSource has been set on the budget.
+	BudgetReady apis.ConditionType = "BudgetReady"
+)
+
+var billingBudgetCondSet = apis.NewLivingConditionSet(
+	gcpduckv1.PullSubscriptionReady,
+	gcpduckv1.TopicReady,
+	BudgetReady)
+
+// CloudBillingBudgetSourceStatus is the status for a CloudBillingBudgetSource resource.
+type CloudBillingBudgetSourceStatus struct {
+	// This brings in our GCP PubSub based events importers
+	// duck/v1 Status, SinkURI, ProjectID, TopicID and SubscriptionID
+	gcpduckv1.PubSubStatus `json:",inline"`
+
+	// BudgetName is the name of the budget whose notifications are sent
+	// to the topic, on success.
+	// +optional
+	BudgetName string `json:"budgetName,omitempty"`
+}
+
+func (*CloudBillingBudgetSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("CloudBillingBudgetSource")
+}
+
+// Methods for identifiable interface
+// IdentitySpec returns the IdentitySpec portion of the Spec.
+func (s *CloudBillingBudgetSource) IdentitySpec() *gcpduckv1.IdentitySpec {
+	return &s.Spec.IdentitySpec
+}
+
+// IdentityStatus returns the IdentityStatus portion of the Status.
+func (s *CloudBillingBudgetSource) IdentityStatus() *gcpduckv1.IdentityStatus {
+	return &s.Status.IdentityStatus
+}
+
+// ConditionSet returns the apis.ConditionSet of the embedding object.
+func (s *CloudBillingBudgetSource) ConditionSet() *apis.ConditionSet {
+	return &billingBudgetCondSet
+}
+
+// Methods for pubsubable interface
+// PubSubSpec returns the PubSubSpec portion of the Spec.
+func (s *CloudBillingBudgetSource) PubSubSpec() *gcpduckv1.PubSubSpec {
+	return &s.Spec.PubSubSpec
+}
+
+// PubSubStatus returns the PubSubStatus portion of the Status.
+func (s *CloudBillingBudgetSource) PubSubStatus() *gcpduckv1.PubSubStatus {
+	return &s.Status.PubSubStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudBillingBudgetSourceList is a list of CloudBillingBudgetSource resources.
+type CloudBillingBudgetSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CloudBillingBudgetSource `json:"items"`
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*CloudBillingBudgetSource) GetConditionSet() apis.ConditionSet {
+	return billingBudgetCondSet
+}
+
+// GetStatus retrieves the status of the CloudBillingBudgetSource. Implements the KRShaped interface.
+func (s *CloudBillingBudgetSource) GetStatus() *duckv1.Status {
+	return &s.Status.Status
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+)
+
+var billingBudgetSourceSpec = CloudBillingBudgetSourceSpec{
+	PubSubSpec: gcpduckv1.PubSubSpec{
+		Secret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: "secret-name",
+			},
+			Key: "secret-key",
+		},
+		SourceSpec: duckv1.SourceSpec{
+			Sink: duckv1.Destination{
+				Ref: &duckv1.KReference{
+					APIVersion: "foo",
+					Kind:       "bar",
+					Namespace:  "baz",
+					Name:       "qux",
+				},
+			},
+		},
+		Project: "my-eventing-project",
+	},
+	BillingAccount: "012345-6789AB-CDEF01",
+	Budget:         "budget-id",
+}
+
+func TestCloudBillingBudgetSourceSpecValidationFields(t *testing.T) {
+	testCases := map[string]struct {
+		spec  CloudBillingBudgetSourceSpec
+		error bool
+	}{
+		"ok": {
+			spec:  billingBudgetSourceSpec,
+			error: false,
+		},
+		"bad sink, empty": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{}
+				return *obj
+			}(),
+			error: true,
+		},
+		"missing billing account": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.BillingAccount = ""
+				return *obj
+			}(),
+			error: true,
+		},
+		"invalid billing account": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.BillingAccount = "billingAccounts/012345-6789AB-CDEF01"
+				return *obj
+			}(),
+			error: true,
+		},
+		"missing budget": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Budget = ""
+				return *obj
+			}(),
+			error: true,
+		},
+		"budget name instead of ID": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Budget = "billingAccounts/012345-6789AB-CDEF01/budgets/budget-id"
+				return *obj
+			}(),
+			error: true,
+		},
+		"invalid secret, missing key": {
+			spec: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Secret.Key = ""
+				return *obj
+			}(),
+			error: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.TODO())
+			if tc.error != (err != nil) {
+				t.Fatalf("Unexpected validation failure. Got %v", err)
+			}
+		})
+	}
+}
+
+func TestCloudBillingBudgetSourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		orig    *CloudBillingBudgetSourceSpec
+		updated CloudBillingBudgetSourceSpec
+		allowed bool
+	}{
+		"nil orig": {
+			updated: billingBudgetSourceSpec,
+			allowed: true,
+		},
+		"sink changed": {
+			orig: &billingBudgetSourceSpec,
+			updated: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Sink.Ref.Name = "new-sink"
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"budget changed": {
+			orig: &billingBudgetSourceSpec,
+			updated: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Budget = "other-budget-id"
+				return *obj
+			}(),
+			allowed: false,
+		},
+		"project changed": {
+			orig: &billingBudgetSourceSpec,
+			updated: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Project = "new-project"
+				return *obj
+			}(),
+			allowed: false,
+		},
+		"secret changed": {
+			orig: &billingBudgetSourceSpec,
+			updated: func() CloudBillingBudgetSourceSpec {
+				obj := billingBudgetSourceSpec.DeepCopy()
+				obj.Secret.Name = "new-secret"
+				return *obj
+			}(),
+			allowed: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var orig *CloudBillingBudgetSource
+			if tc.orig != nil {
+				orig = &CloudBillingBudgetSource{
+					Spec: *tc.orig,
+				}
+			}
+			updated := &CloudBillingBudgetSource{
+				Spec: tc.updated,
+			}
+			err := updated.CheckImmutableFields(context.TODO(), orig)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
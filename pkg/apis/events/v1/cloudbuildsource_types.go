@@ -48,6 +48,7 @@ var (
 	_ resourcesemantics.GenericCRD = (*CloudBuildSource)(nil)
 	_ kngcpduckv1.PubSubable       = (*CloudBuildSource)(nil)
 	_ kngcpduckv1.Identifiable     = (*CloudBuildSource)(nil)
+	_ kngcpduckv1.BuildFilterable  = (*CloudBuildSource)(nil)
 	_                              = duck.VerifyType(&CloudBuildSource{}, &duckv1.Conditions{})
 	_ duckv1.KRShaped              = (*CloudBuildSource)(nil)
 )
@@ -57,6 +58,12 @@ type CloudBuildSourceSpec struct {
 	// This brings in the PubSub based Source Specs. Includes:
 	// Sink, CloudEventOverrides, Secret and Project.
 	gcpduckv1.PubSubSpec `json:",inline"`
+
+	// BuildFilter selects the builds whose notifications are delivered to the
+	// sink. The notifications of the other builds are acked without being
+	// delivered. All of them are delivered if omitted.
+	// +optional
+	BuildFilter *gcpduckv1.BuildFilterSpec `json:"buildFilter,omitempty"`
 }
 
 const (
@@ -109,6 +116,11 @@ func (bs *CloudBuildSource) PubSubStatus() *gcpduckv1.PubSubStatus {
 	return &bs.Status.PubSubStatus
 }
 
+// BuildFilterSpec returns the filter of the Cloud Build notifications.
+func (bs *CloudBuildSource) BuildFilterSpec() *gcpduckv1.BuildFilterSpec {
+	return bs.Spec.BuildFilter
+}
+
 // ConditionSet returns the apis.ConditionSet of the embedding object.
 func (bs *CloudBuildSource) ConditionSet() *apis.ConditionSet {
 	return &buildCondSet
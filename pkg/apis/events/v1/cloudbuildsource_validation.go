@@ -50,6 +50,12 @@ func (current *CloudBuildSourceSpec) Validate(ctx context.Context) *apis.FieldEr
 		errs = errs.Also(err)
 	}
 
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
+	if current.BuildFilter != nil {
+		errs = errs.Also(current.BuildFilter.Validate(ctx).ViaField("buildFilter"))
+	}
+
 	return errs
 }
 
@@ -62,7 +68,7 @@ func (current *CloudBuildSource) CheckImmutableFields(ctx context.Context, origi
 	// Modification of Topic, Secret and Project are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudBuildSourceSpec{},
-			"Sink", "CloudEventOverrides")); diff != "" {
+			"Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup", "BuildFilter")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -192,6 +192,27 @@ func TestCloudBuildSourceCheckValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"valid build filter": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildFilter = &gcpduckv1.BuildFilterSpec{
+					Statuses: []string{"SUCCESS", "FAILURE"},
+					Tags:     []string{"release"},
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"invalid build filter status": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildFilter = &gcpduckv1.BuildFilterSpec{
+					Statuses: []string{"DONE"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -444,6 +465,17 @@ func TestCloudBuildSourceCheckImmutableFields(t *testing.T) {
 			},
 			allowed: true,
 		},
+		"BuildFilter changed": {
+			orig: &buildSourceSpec,
+			updated: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildFilter = &gcpduckv1.BuildFilterSpec{
+					Statuses: []string{"FAILURE"},
+				}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"no change": {
 			orig:    &buildSourceSpec,
 			updated: buildSourceSpec,
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCloudMonitoringAlertSourceStatusIsReady(t *testing.T) {
+	tests := []struct {
+		name                string
+		s                   *CloudMonitoringAlertSourceStatus
+		wantConditionStatus corev1.ConditionStatus
+		want                bool
+	}{{
+		name: "uninitialized",
+		s:    &CloudMonitoringAlertSourceStatus{},
+		want: false,
+	}, {
+		name: "initialized",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionUnknown,
+	}, {
+		name: "notification channel not ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelNotReady("NotReady", "test message")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+	}, {
+		name: "alert policies not ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelReady("projects/p/notificationChannels/1")
+			s.Status.MarkAlertPoliciesNotReady("NotReady", "test message")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+	}, {
+		name: "ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelReady("projects/p/notificationChannels/1")
+			s.Status.MarkAlertPoliciesReady([]string{"projects/p/alertPolicies/2"})
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantConditionStatus != "" {
+				gotConditionStatus := test.s.GetTopLevelCondition().Status
+				if gotConditionStatus != test.wantConditionStatus {
+					t.Errorf("unexpected condition status: want %v, got %v", test.wantConditionStatus, gotConditionStatus)
+				}
+			}
+			got := test.s.IsReady()
+			if got != test.want {
+				t.Errorf("unexpected readiness: want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	kngcpduck "github.com/google/knative-gcp/pkg/duck/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudMonitoringAlertSource is a specification for a CloudMonitoringAlertSource resource.
+type CloudMonitoringAlertSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudMonitoringAlertSourceSpec   `json:"spec"`
+	Status CloudMonitoringAlertSourceStatus `json:"status"`
+}
+
+// Verify that CloudMonitoringAlertSource matches various duck types.
+var (
+	_ apis.Convertible             = (*CloudMonitoringAlertSource)(nil)
+	_ apis.Defaultable             = (*CloudMonitoringAlertSource)(nil)
+	_ apis.Validatable             = (*CloudMonitoringAlertSource)(nil)
+	_ runtime.Object               = (*CloudMonitoringAlertSource)(nil)
+	_ kmeta.OwnerRefable           = (*CloudMonitoringAlertSource)(nil)
+	_ resourcesemantics.GenericCRD = (*CloudMonitoringAlertSource)(nil)
+	_ kngcpduck.Identifiable       = (*CloudMonitoringAlertSource)(nil)
+	_ kngcpduck.PubSubable         = (*CloudMonitoringAlertSource)(nil)
+	_ duckv1.KRShaped              = (*CloudMonitoringAlertSource)(nil)
+)
+
+// CloudMonitoringAlertSourceSpec is the spec for a CloudMonitoringAlertSource resource.
+type CloudMonitoringAlertSourceSpec struct {
+	// This brings in the PubSub based Source Specs. Includes:
+	// Sink, CloudEventOverrides, Secret and Project
+	gcpduckv1.PubSubSpec `json:",inline"`
+
+	// AlertPolicies are the IDs of the alert policies, in the project of the
+	// source, whose incidents are sent. The notification channel of the
+	// source is added to each of them.
+	AlertPolicies []string `json:"alertPolicies"`
+}
+
+const (
+	// CloudMonitoringAlertSourceConditionReady has status True when the
+	// CloudMonitoringAlertSource is ready to send events.
+	CloudMonitoringAlertSourceConditionReady = apis.ConditionReady
+
+	// NotificationChannelReady has status True when the Pub/Sub notification
+	// channel of the CloudMonitoringAlertSource has been successfully created.
+	NotificationChannelReady apis.ConditionType = "NotificationChannelReady"
+
+	// AlertPoliciesReady has status True when the notification channel has
+	// been added to all the alert policies of the CloudMonitoringAlertSource.
+	AlertPoliciesReady apis.ConditionType = "AlertPoliciesReady"
+)
+
+var monitoringAlertCondSet = apis.NewLivingConditionSet(
+	gcpduckv1.PullSubscriptionReady,
+	gcpduckv1.TopicReady,
+	NotificationChannelReady,
+	AlertPoliciesReady)
+
+// CloudMonitoringAlertSourceStatus is the status for a CloudMonitoringAlertSource resource.
+type CloudMonitoringAlertSourceStatus struct {
+	// This brings in our GCP PubSub based events importers
+	// duck/v1 Status, SinkURI, ProjectID, TopicID and SubscriptionID
+	gcpduckv1.PubSubStatus `json:",inline"`
+
+	// NotificationChannelName is the name of the created notification
+	// channel on success.
+	// +optional
+	NotificationChannelName string `json:"notificationChannelName,omitempty"`
+
+	// AlertPolicies are the names of the alert policies the notification
+	// channel was added to.
+	// +optional
+	AlertPolicies []string `json:"alertPolicies,omitempty"`
+}
+
+func (*CloudMonitoringAlertSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("CloudMonitoringAlertSource")
+}
+
+// Methods for identifiable interface
+// IdentitySpec returns the IdentitySpec portion of the Spec.
+func (s *CloudMonitoringAlertSource) IdentitySpec() *gcpduckv1.IdentitySpec {
+	return &s.Spec.IdentitySpec
+}
+
+// IdentityStatus returns the IdentityStatus portion of the Status.
+func (s *CloudMonitoringAlertSource) IdentityStatus() *gcpduckv1.IdentityStatus {
+	return &s.Status.IdentityStatus
+}
+
+// ConditionSet returns the apis.ConditionSet of the embedding object.
+func (s *CloudMonitoringAlertSource) ConditionSet() *apis.ConditionSet {
+	return &monitoringAlertCondSet
+}
+
+// Methods for pubsubable interface
+// PubSubSpec returns the PubSubSpec portion of the Spec.
+func (s *CloudMonitoringAlertSource) PubSubSpec() *gcpduckv1.PubSubSpec {
+	return &s.Spec.PubSubSpec
+}
+
+// PubSubStatus returns the PubSubStatus portion of the Status.
+func (s *CloudMonitoringAlertSource) PubSubStatus() *gcpduckv1.PubSubStatus {
+	return &s.Status.PubSubStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudMonitoringAlertSourceList is a list of CloudMonitoringAlertSource resources.
+type CloudMonitoringAlertSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CloudMonitoringAlertSource `json:"items"`
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*CloudMonitoringAlertSource) GetConditionSet() apis.ConditionSet {
+	return monitoringAlertCondSet
+}
+
+// GetStatus retrieves the status of the CloudMonitoringAlertSource. Implements the KRShaped interface.
+func (s *CloudMonitoringAlertSource) GetStatus() *duckv1.Status {
+	return &s.Status.Status
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/knative-gcp/pkg/apis/duck"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func (current *CloudMonitoringAlertSource) Validate(ctx context.Context) *apis.FieldError {
+	errs := current.Spec.Validate(ctx).ViaField("spec")
+
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*CloudMonitoringAlertSource)
+		errs = errs.Also(current.CheckImmutableFields(ctx, original))
+	}
+	return duck.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
+}
+
+func (current *CloudMonitoringAlertSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	// Sink [required]
+	if equality.Semantic.DeepEqual(current.Sink, duckv1.Destination{}) {
+		errs = errs.Also(apis.ErrMissingField("sink"))
+	} else if err := current.Sink.Validate(ctx); err != nil {
+		errs = errs.Also(err.ViaField("sink"))
+	}
+
+	// AlertPolicies [required]
+	if len(current.AlertPolicies) == 0 {
+		errs = errs.Also(apis.ErrMissingField("alertPolicies"))
+	}
+	seen := make(map[string]bool, len(current.AlertPolicies))
+	for i, p := range current.AlertPolicies {
+		// Policies are referred to by ID, as they must be in the project of
+		// the notification channel.
+		if p == "" || strings.Contains(p, "/") {
+			errs = errs.Also(apis.ErrInvalidArrayValue(p, "alertPolicies", i))
+		} else if seen[p] {
+			errs = errs.Also(&apis.FieldError{
+				Message: "duplicate alert policy",
+				Paths:   []string{apis.CurrentField},
+			}).ViaFieldIndex("alertPolicies", i)
+		}
+		seen[p] = true
+	}
+
+	if err := duck.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
+		errs = errs.Also(err)
+	}
+
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
+	return errs
+}
+
+func (current *CloudMonitoringAlertSource) CheckImmutableFields(ctx context.Context, original *CloudMonitoringAlertSource) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	// Modification of Secret, ServiceAccountName and Project are not allowed.
+	// The alert policies are mutable, the notification channel is added to
+	// and removed from them as needed.
+	if diff := cmp.Diff(original.Spec, current.Spec,
+		cmpopts.IgnoreFields(CloudMonitoringAlertSourceSpec{},
+			"Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup", "AlertPolicies")); diff != "" {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		})
+	}
+	// Modification of AutoscalingClassAnnotations is not allowed.
+	errs = duck.CheckImmutableAutoscalingClassAnnotations(&current.ObjectMeta, &original.ObjectMeta, errs)
+
+	// Modification of non-empty cluster name annotation is not allowed.
+	return duck.CheckImmutableClusterNameAnnotation(&current.ObjectMeta, &original.ObjectMeta, errs)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+)
+
+var monitoringAlertSourceSpec = CloudMonitoringAlertSourceSpec{
+	PubSubSpec: gcpduckv1.PubSubSpec{
+		Secret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: "secret-name",
+			},
+			Key: "secret-key",
+		},
+		SourceSpec: duckv1.SourceSpec{
+			Sink: duckv1.Destination{
+				Ref: &duckv1.KReference{
+					APIVersion: "foo",
+					Kind:       "bar",
+					Namespace:  "baz",
+					Name:       "qux",
+				},
+			},
+		},
+		Project: "my-eventing-project",
+	},
+	AlertPolicies: []string{"123", "456"},
+}
+
+func TestCloudMonitoringAlertSourceSpecValidationFields(t *testing.T) {
+	testCases := map[string]struct {
+		spec  CloudMonitoringAlertSourceSpec
+		error bool
+	}{
+		"ok": {
+			spec:  monitoringAlertSourceSpec,
+			error: false,
+		},
+		"bad sink, empty": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.Sink = duckv1.Destination{}
+				return *obj
+			}(),
+			error: true,
+		},
+		"missing alert policies": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.AlertPolicies = nil
+				return *obj
+			}(),
+			error: true,
+		},
+		"empty alert policy": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.AlertPolicies = []string{""}
+				return *obj
+			}(),
+			error: true,
+		},
+		"alert policy name instead of ID": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.AlertPolicies = []string{"projects/my-eventing-project/alertPolicies/123"}
+				return *obj
+			}(),
+			error: true,
+		},
+		"duplicate alert policy": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.AlertPolicies = []string{"123", "123"}
+				return *obj
+			}(),
+			error: true,
+		},
+		"invalid secret, missing key": {
+			spec: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.Secret.Key = ""
+				return *obj
+			}(),
+			error: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.spec.Validate(context.TODO())
+			if tc.error != (err != nil) {
+				t.Fatalf("Unexpected validation failure. Got %v", err)
+			}
+		})
+	}
+}
+
+func TestCloudMonitoringAlertSourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		orig    *CloudMonitoringAlertSourceSpec
+		updated CloudMonitoringAlertSourceSpec
+		allowed bool
+	}{
+		"nil orig": {
+			updated: monitoringAlertSourceSpec,
+			allowed: true,
+		},
+		"alert policy changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.AlertPolicies = []string{"789"}
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"project changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.Project = "new-project"
+				return *obj
+			}(),
+			allowed: false,
+		},
+		"secret changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func() CloudMonitoringAlertSourceSpec {
+				obj := monitoringAlertSourceSpec.DeepCopy()
+				obj.Secret.Name = "new-secret"
+				return *obj
+			}(),
+			allowed: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var orig *CloudMonitoringAlertSource
+			if tc.orig != nil {
+				orig = &CloudMonitoringAlertSource{
+					Spec: *tc.orig,
+				}
+			}
+			updated := &CloudMonitoringAlertSource{
+				Spec: tc.updated,
+			}
+			err := updated.CheckImmutableFields(context.TODO(), orig)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
@@ -77,6 +77,8 @@ func (current *CloudPubSubSourceSpec) Validate(ctx context.Context) *apis.FieldE
 		errs = errs.Also(err)
 	}
 
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
 	return errs
 }
 
@@ -89,7 +91,7 @@ func (current *CloudPubSubSource) CheckImmutableFields(ctx context.Context, orig
 	// Modification of Topic, Secret, AckDeadline, RetainAckedMessages, RetentionDuration, ServiceAccountName and Project are not allowed.
 	// Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
-		cmpopts.IgnoreFields(CloudPubSubSourceSpec{}, "Sink", "CloudEventOverrides")); diff != "" {
+		cmpopts.IgnoreFields(CloudPubSubSourceSpec{}, "Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -57,3 +57,21 @@ func (s *CloudSchedulerSourceStatus) MarkJobReady(jobName string) {
 	schedulerCondSet.Manage(s).MarkTrue(JobReady)
 	s.JobName = jobName
 }
+
+// MarkJobSynced sets the condition that the CloudSchedulerSource Job matches
+// the spec.
+func (s *CloudSchedulerSourceStatus) MarkJobSynced() {
+	schedulerCondSet.Manage(s).MarkTrue(JobSynced)
+}
+
+// MarkJobDriftCorrected sets the condition that the CloudSchedulerSource Job
+// had drifted from the spec, and was updated to match it.
+func (s *CloudSchedulerSourceStatus) MarkJobDriftCorrected(reason, messageFormat string, messageA ...interface{}) {
+	schedulerCondSet.Manage(s).MarkTrueWithReason(JobSynced, reason, messageFormat, messageA...)
+}
+
+// MarkJobNotSynced sets the condition that the CloudSchedulerSource Job
+// differs from the spec.
+func (s *CloudSchedulerSourceStatus) MarkJobNotSynced(reason, messageFormat string, messageA ...interface{}) {
+	schedulerCondSet.Manage(s).MarkFalse(JobSynced, reason, messageFormat, messageA...)
+}
@@ -129,6 +129,19 @@ func TestCloudSchedulerSourceStatusIsReady(t *testing.T) {
 		}(),
 		wantConditionStatus: corev1.ConditionTrue,
 		want:                true,
+	}, {
+		name: "ready, job not synced",
+		s: func() *CloudSchedulerSourceStatus {
+			s := &CloudSchedulerSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkJobReady("jobName")
+			s.Status.MarkJobNotSynced("Drift", "job drifted")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
 	}}
 
 	for _, test := range tests {
@@ -213,6 +226,37 @@ func TestCloudSchedulerSourceStatusGetCondition(t *testing.T) {
 			Type:   JobReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "job not synced",
+		s: func() *CloudSchedulerSourceStatus {
+			s := &CloudSchedulerSourceStatus{}
+			s.InitializeConditions()
+			s.MarkJobNotSynced("Drift", "test message")
+			return s
+		}(),
+		condQuery: JobSynced,
+		want: &apis.Condition{
+			Type:    JobSynced,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Drift",
+			Message: "test message",
+		},
+	}, {
+		name: "job drift corrected",
+		s: func() *CloudSchedulerSourceStatus {
+			s := &CloudSchedulerSourceStatus{}
+			s.InitializeConditions()
+			s.MarkJobNotSynced("Drift", "test message")
+			s.MarkJobDriftCorrected("Corrected", "test message")
+			return s
+		}(),
+		condQuery: JobSynced,
+		want: &apis.Condition{
+			Type:    JobSynced,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Corrected",
+			Message: "test message",
+		},
 	}}
 
 	for _, test := range tests {
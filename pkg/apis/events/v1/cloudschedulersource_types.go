@@ -75,6 +75,46 @@ type CloudSchedulerSourceSpec struct {
 
 	// What data to send
 	Data string `json:"data"`
+
+	// TimeZone is the time zone used to interpret the schedule, as a name
+	// from the tz database, for example "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RetryConfig is the policy used to retry the job when it fails.
+	// +optional
+	RetryConfig *SchedulerRetryConfig `json:"retryConfig,omitempty"`
+
+	// Paused pauses the job, so that it is not run until it is unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Attributes are added to the Pub/Sub messages published by the job,
+	// and become extensions of the CloudEvents. Keys must be valid
+	// CloudEvents extension names.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// Description of the job.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// SchedulerRetryConfig is the policy used to retry a job when it fails.
+type SchedulerRetryConfig struct {
+	// RetryCount is the number of times the job is retried, between 0 and 5.
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// MinBackoffDuration is the minimum time to wait before retrying the
+	// job, e.g. '5s'. Valid time units are `s`, `m`, `h`.
+	// +optional
+	MinBackoffDuration *string `json:"minBackoffDuration,omitempty"`
+
+	// MaxBackoffDuration is the maximum time to wait before retrying the
+	// job, e.g. '1h'. Valid time units are `s`, `m`, `h`.
+	// +optional
+	MaxBackoffDuration *string `json:"maxBackoffDuration,omitempty"`
 }
 
 const (
@@ -83,6 +123,10 @@ const (
 
 	// JobReady has status True when CloudSchedulerSource Job has been successfully created.
 	JobReady apis.ConditionType = "JobReady"
+
+	// JobSynced has status True when the CloudSchedulerSource Job matches the
+	// spec. It is informational, and does not affect the Ready condition.
+	JobSynced apis.ConditionType = "JobSynced"
 )
 
 var schedulerCondSet = apis.NewLivingConditionSet(
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,11 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+const (
+	// maxSchedulerRetryCount is the maximum number of retries of a Cloud Scheduler job.
+	maxSchedulerRetryCount = 5
+)
+
 func (current *CloudSchedulerSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := current.Spec.Validate(ctx).ViaField("spec")
 
@@ -62,10 +68,54 @@ func (current *CloudSchedulerSourceSpec) Validate(ctx context.Context) *apis.Fie
 		errs = errs.Also(apis.ErrMissingField("data"))
 	}
 
+	if current.RetryConfig != nil {
+		errs = errs.Also(current.RetryConfig.Validate(ctx).ViaField("retryConfig"))
+	}
+
+	for k := range current.Attributes {
+		// Attributes become CloudEvents extensions, which also rules out the
+		// reserved jobName attribute.
+		if !duck.IsValidExtensionName(k) {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "attributes", "must consist of lower-case letters and digits"))
+		}
+	}
+
 	if err := duck.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
 
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
+	return errs
+}
+
+func (current *SchedulerRetryConfig) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if current.RetryCount < 0 || current.RetryCount > maxSchedulerRetryCount {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(current.RetryCount, 0, maxSchedulerRetryCount, "retryCount"))
+	}
+	// If set, the backoff durations need to parse to valid positive durations.
+	var minBackoff, maxBackoff time.Duration
+	if current.MinBackoffDuration != nil {
+		d, err := time.ParseDuration(*current.MinBackoffDuration)
+		if err != nil || d <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*current.MinBackoffDuration, "minBackoffDuration"))
+		}
+		minBackoff = d
+	}
+	if current.MaxBackoffDuration != nil {
+		d, err := time.ParseDuration(*current.MaxBackoffDuration)
+		if err != nil || d <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*current.MaxBackoffDuration, "maxBackoffDuration"))
+		}
+		maxBackoff = d
+	}
+	if minBackoff > 0 && maxBackoff > 0 && minBackoff > maxBackoff {
+		errs = errs.Also(&apis.FieldError{
+			Message: "minBackoffDuration must not be greater than maxBackoffDuration",
+			Paths:   []string{"minBackoffDuration", "maxBackoffDuration"},
+		})
+	}
 	return errs
 }
 
@@ -75,10 +125,11 @@ func (current *CloudSchedulerSource) CheckImmutableFields(ctx context.Context, o
 	}
 
 	var errs *apis.FieldError
-	// Modification of Location, Schedule, Data, Secret, ServiceAccountName, Project are not allowed.
-	// Everything else is mutable.
+	// Modification of Location, Secret, ServiceAccountName, Project are not allowed.
+	// Everything else is mutable, changes to the job are applied in place.
 	if diff := cmp.Diff(original.Spec, current.Spec,
-		cmpopts.IgnoreFields(CloudSchedulerSourceSpec{}, "Sink", "CloudEventOverrides")); diff != "" {
+		cmpopts.IgnoreFields(CloudSchedulerSourceSpec{}, "Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup",
+			"Schedule", "Data", "TimeZone", "RetryConfig", "Paused", "Attributes", "Description")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -360,6 +361,61 @@ func TestCloudSchedulerSourceSpecValidationFields(t *testing.T) {
 			}
 			return fe
 		}(),
+	}, {
+		name: "valid job settings",
+		spec: func() *CloudSchedulerSourceSpec {
+			s := minimalCloudSchedulerSourceSpec.DeepCopy()
+			s.TimeZone = "America/New_York"
+			s.RetryConfig = &SchedulerRetryConfig{
+				RetryCount:         3,
+				MinBackoffDuration: ptr.String("5s"),
+				MaxBackoffDuration: ptr.String("1h"),
+			}
+			s.Paused = true
+			s.Attributes = map[string]string{"team": "billing"}
+			s.Description = "Nightly billing run"
+			return s
+		}(),
+		want: nil,
+	}, {
+		name: "invalid retry config",
+		spec: func() *CloudSchedulerSourceSpec {
+			s := minimalCloudSchedulerSourceSpec.DeepCopy()
+			s.RetryConfig = &SchedulerRetryConfig{
+				RetryCount:         6,
+				MinBackoffDuration: ptr.String("5"),
+				MaxBackoffDuration: ptr.String("-1h"),
+			}
+			return s
+		}(),
+		want: func() *apis.FieldError {
+			fe := apis.ErrOutOfBoundsValue(6, 0, 5, "retryConfig.retryCount")
+			fe = fe.Also(apis.ErrInvalidValue("5", "retryConfig.minBackoffDuration"))
+			fe = fe.Also(apis.ErrInvalidValue("-1h", "retryConfig.maxBackoffDuration"))
+			return fe
+		}(),
+	}, {
+		name: "min backoff greater than max backoff",
+		spec: func() *CloudSchedulerSourceSpec {
+			s := minimalCloudSchedulerSourceSpec.DeepCopy()
+			s.RetryConfig = &SchedulerRetryConfig{
+				MinBackoffDuration: ptr.String("1h"),
+				MaxBackoffDuration: ptr.String("5s"),
+			}
+			return s
+		}(),
+		want: &apis.FieldError{
+			Message: "minBackoffDuration must not be greater than maxBackoffDuration",
+			Paths:   []string{"retryConfig.minBackoffDuration", "retryConfig.maxBackoffDuration"},
+		},
+	}, {
+		name: "invalid attribute",
+		spec: func() *CloudSchedulerSourceSpec {
+			s := minimalCloudSchedulerSourceSpec.DeepCopy()
+			s.Attributes = map[string]string{"jobName": "job"}
+			return s
+		}(),
+		want: apis.ErrInvalidKeyName("jobName", "attributes", "must consist of lower-case letters and digits"),
 	}}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -434,7 +490,7 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       schedulerWithSecret.Data,
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Data changed": {
 			orig: &schedulerWithSecret,
@@ -444,7 +500,21 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       "some-other-data",
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
+		},
+		"Job settings changed": {
+			orig: &schedulerWithSecret,
+			updated: CloudSchedulerSourceSpec{
+				Location:    schedulerWithSecret.Location,
+				Schedule:    schedulerWithSecret.Schedule,
+				Data:        schedulerWithSecret.Data,
+				TimeZone:    "Europe/Paris",
+				Paused:      true,
+				Attributes:  map[string]string{"team": "billing"},
+				Description: "some-other-description",
+				PubSubSpec:  schedulerWithSecret.PubSubSpec,
+			},
+			allowed: true,
 		},
 		"Secret.Name changed": {
 			orig: &schedulerWithSecret,
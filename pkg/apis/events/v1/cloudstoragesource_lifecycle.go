@@ -53,5 +53,61 @@ func (s *CloudStorageSourceStatus) MarkNotificationUnknown(reason, messageFormat
 
 func (s *CloudStorageSourceStatus) MarkNotificationReady(notificationID string) {
 	s.NotificationID = notificationID
+	s.MarkNotificationsReady()
+}
+
+// MarkNotificationsReady sets the condition that GCS has been configured to
+// send the Notifications of all the buckets, whose IDs are already recorded.
+func (s *CloudStorageSourceStatus) MarkNotificationsReady() {
 	storageCondSet.Manage(s).MarkTrue(NotificationReady)
 }
+
+// MarkNotificationSynced sets the condition that the GCS notifications match
+// the spec.
+func (s *CloudStorageSourceStatus) MarkNotificationSynced() {
+	storageCondSet.Manage(s).MarkTrue(NotificationSynced)
+}
+
+// MarkNotificationDriftCorrected sets the condition that GCS notifications
+// had drifted from the spec, and were recreated to match it.
+func (s *CloudStorageSourceStatus) MarkNotificationDriftCorrected(reason, messageFormat string, messageA ...interface{}) {
+	storageCondSet.Manage(s).MarkTrueWithReason(NotificationSynced, reason, messageFormat, messageA...)
+}
+
+// MarkNotificationNotSynced sets the condition that GCS notifications differ
+// from the spec.
+func (s *CloudStorageSourceStatus) MarkNotificationNotSynced(reason, messageFormat string, messageA ...interface{}) {
+	storageCondSet.Manage(s).MarkFalse(NotificationSynced, reason, messageFormat, messageA...)
+}
+
+// BucketNotificationID returns the ID of the notification of bucket, or an
+// empty string if it was not created yet.
+func (s *CloudStorageSource) BucketNotificationID(bucket string) string {
+	if s.Spec.Bucket != "" {
+		return s.Status.NotificationID
+	}
+	for _, n := range s.Status.Notifications {
+		if n.Bucket == bucket {
+			return n.NotificationID
+		}
+	}
+	return ""
+}
+
+// SetBucketNotificationID records the ID of the notification of bucket.
+func (s *CloudStorageSource) SetBucketNotificationID(bucket, notificationID string) {
+	if s.Spec.Bucket != "" {
+		s.Status.NotificationID = notificationID
+		return
+	}
+	for i := range s.Status.Notifications {
+		if s.Status.Notifications[i].Bucket == bucket {
+			s.Status.Notifications[i].NotificationID = notificationID
+			return
+		}
+	}
+	s.Status.Notifications = append(s.Status.Notifications, BucketNotification{
+		Bucket:         bucket,
+		NotificationID: notificationID,
+	})
+}
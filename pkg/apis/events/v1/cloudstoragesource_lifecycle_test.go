@@ -129,6 +129,19 @@ func TestCloudStorageSourceStatusIsReady(t *testing.T) {
 		}(),
 		wantConditionStatus: corev1.ConditionTrue,
 		want:                true,
+	}, {
+		name: "ready, notification not synced",
+		s: func() *CloudStorageSourceStatus {
+			s := &CloudStorageSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationReady("notificationID")
+			s.Status.MarkNotificationNotSynced("Drift", "notification drifted")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
 	}}
 
 	for _, test := range tests {
@@ -213,6 +226,37 @@ func TestCloudStorageSourceStatusGetCondition(t *testing.T) {
 			Type:   NotificationReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "notification not synced",
+		s: func() *CloudStorageSourceStatus {
+			s := &CloudStorageSourceStatus{}
+			s.InitializeConditions()
+			s.MarkNotificationNotSynced("Drift", "test message")
+			return s
+		}(),
+		condQuery: NotificationSynced,
+		want: &apis.Condition{
+			Type:    NotificationSynced,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Drift",
+			Message: "test message",
+		},
+	}, {
+		name: "notification drift corrected",
+		s: func() *CloudStorageSourceStatus {
+			s := &CloudStorageSourceStatus{}
+			s.InitializeConditions()
+			s.MarkNotificationNotSynced("Drift", "test message")
+			s.MarkNotificationDriftCorrected("Corrected", "test message")
+			return s
+		}(),
+		condQuery: NotificationSynced,
+		want: &apis.Condition{
+			Type:    NotificationSynced,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Corrected",
+			Message: "test message",
+		},
 	}}
 
 	for _, test := range tests {
@@ -226,3 +270,35 @@ func TestCloudStorageSourceStatusGetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudStorageSourceBucketNotificationID(t *testing.T) {
+	s := &CloudStorageSource{Spec: CloudStorageSourceSpec{Bucket: "bucket"}}
+	s.SetBucketNotificationID("bucket", "1")
+	if got := s.BucketNotificationID("bucket"); got != "1" {
+		t.Errorf("unexpected notification ID, want %q, got %q", "1", got)
+	}
+	if s.Status.NotificationID != "1" || len(s.Status.Notifications) != 0 {
+		t.Errorf("unexpected status for a single bucket: %+v", s.Status)
+	}
+
+	s = &CloudStorageSource{Spec: CloudStorageSourceSpec{Buckets: []string{"bucket-1", "bucket-2"}}}
+	s.SetBucketNotificationID("bucket-1", "1")
+	s.SetBucketNotificationID("bucket-2", "2")
+	s.SetBucketNotificationID("bucket-1", "3")
+	want := []BucketNotification{{
+		Bucket:         "bucket-1",
+		NotificationID: "3",
+	}, {
+		Bucket:         "bucket-2",
+		NotificationID: "2",
+	}}
+	if diff := cmp.Diff(want, s.Status.Notifications); diff != "" {
+		t.Errorf("unexpected notifications (-want, +got) = %v", diff)
+	}
+	if got := s.BucketNotificationID("bucket-2"); got != "2" {
+		t.Errorf("unexpected notification ID, want %q, got %q", "2", got)
+	}
+	if got := s.BucketNotificationID("bucket-3"); got != "" {
+		t.Errorf("unexpected notification ID, want none, got %q", got)
+	}
+}
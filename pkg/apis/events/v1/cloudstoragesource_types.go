@@ -61,8 +61,14 @@ type CloudStorageSourceSpec struct {
 	// Sink, CloudEventOverrides, Secret and Project
 	gcpduckv1.PubSubSpec `json:",inline"`
 
-	// Bucket to subscribe to.
-	Bucket string `json:"bucket"`
+	// Bucket to subscribe to. Exactly one of Bucket or Buckets must be set.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+
+	// Buckets to subscribe to, a notification is created on each of them.
+	// Exactly one of Bucket or Buckets must be set.
+	// +optional
+	Buckets []string `json:"buckets,omitempty"`
 
 	// EventTypes to subscribe to. If unspecified, then subscribe to all events.
 	// +optional
@@ -71,6 +77,37 @@ type CloudStorageSourceSpec struct {
 	// ObjectNamePrefix limits the notifications to objects with this prefix
 	// +optional
 	ObjectNamePrefix string `json:"objectNamePrefix,omitempty"`
+
+	// PayloadFormat of the notifications, either JSON_API_V1 for the object
+	// metadata in JSON, or NONE for no payload, in which case the events have
+	// no data. Defaults to JSON_API_V1.
+	// +optional
+	PayloadFormat string `json:"payloadFormat,omitempty"`
+
+	// Attributes are added to the notifications, and become CloudEvents
+	// extensions of the events. Their keys must consist of lower-case letters
+	// and digits.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+const (
+	// CloudStorageSourceJSONPayload is the payload format of notifications
+	// carrying the object metadata in JSON.
+	CloudStorageSourceJSONPayload = "JSON_API_V1"
+	// CloudStorageSourceNoPayload is the payload format of notifications
+	// without payload.
+	CloudStorageSourceNoPayload = "NONE"
+)
+
+// BucketNotification is the notification of one of the buckets of a
+// CloudStorageSource.
+type BucketNotification struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// NotificationID is the ID that GCS identifies the notification as.
+	NotificationID string `json:"notificationId"`
 }
 
 const (
@@ -80,6 +117,10 @@ const (
 	// NotificationReady has status True when GCS has been configured properly to
 	// send Notification events.
 	NotificationReady apis.ConditionType = "NotificationReady"
+
+	// NotificationSynced has status True when the GCS notifications match the
+	// spec. It is informational, and does not affect the Ready condition.
+	NotificationSynced apis.ConditionType = "NotificationSynced"
 )
 
 var storageCondSet = apis.NewLivingConditionSet(
@@ -96,6 +137,19 @@ type CloudStorageSourceStatus struct {
 	// NotificationID is the ID that GCS identifies this notification as.
 	// +optional
 	NotificationID string `json:"notificationId,omitempty"`
+
+	// Notifications are the notifications of each bucket, when the source
+	// has a list of Buckets.
+	// +optional
+	Notifications []BucketNotification `json:"notifications,omitempty"`
+}
+
+// BucketNames returns the buckets of the source, either Bucket or Buckets.
+func (s *CloudStorageSourceSpec) BucketNames() []string {
+	if s.Bucket != "" {
+		return []string{s.Bucket}
+	}
+	return s.Buckets
 }
 
 func (storage *CloudStorageSource) GetGroupVersionKind() schema.GroupVersionKind {
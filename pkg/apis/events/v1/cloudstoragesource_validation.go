@@ -48,15 +48,43 @@ func (current *CloudStorageSourceSpec) Validate(ctx context.Context) *apis.Field
 		errs = errs.Also(err.ViaField("sink"))
 	}
 
-	// Bucket [required]
-	if current.Bucket == "" {
-		errs = errs.Also(apis.ErrMissingField("bucket"))
+	// Bucket or Buckets [required]
+	switch {
+	case current.Bucket == "" && len(current.Buckets) == 0:
+		errs = errs.Also(apis.ErrMissingOneOf("bucket", "buckets"))
+	case current.Bucket != "" && len(current.Buckets) != 0:
+		errs = errs.Also(apis.ErrMultipleOneOf("bucket", "buckets"))
+	}
+	seen := make(map[string]bool, len(current.Buckets))
+	for i, bucket := range current.Buckets {
+		if bucket == "" {
+			errs = errs.Also(apis.ErrMissingField(apis.CurrentField).ViaFieldIndex("buckets", i))
+		} else if seen[bucket] {
+			errs = errs.Also(apis.ErrGeneric("duplicate bucket", apis.CurrentField).ViaFieldIndex("buckets", i))
+		}
+		seen[bucket] = true
+	}
+
+	// PayloadFormat [optional]
+	switch current.PayloadFormat {
+	case "", CloudStorageSourceJSONPayload, CloudStorageSourceNoPayload:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.PayloadFormat, "payloadFormat"))
+	}
+
+	for k := range current.Attributes {
+		// Attributes become CloudEvents extensions.
+		if !duck.IsValidExtensionName(k) {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "attributes", "must consist of lower-case letters and digits"))
+		}
 	}
 
 	if err := duck.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
 
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
 	return errs
 }
 
@@ -66,11 +94,11 @@ func (current *CloudStorageSource) CheckImmutableFields(ctx context.Context, ori
 	}
 
 	var errs *apis.FieldError
-	// Modification of EventType, Secret, ServiceAccountName, Project, Bucket, PayloadFormat, EventType, ObjectNamePrefix are not allowed.
+	// Modification of EventType, Secret, ServiceAccountName, Project, Bucket, Buckets, PayloadFormat, EventType, ObjectNamePrefix, Attributes are not allowed.
 	// Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudStorageSourceSpec{},
-			"Sink", "CloudEventOverrides")); diff != "" {
+			"Sink", "CloudEventOverrides", "Delivery", "Reply", "FlowControl", "Filter", "Dedup")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -131,7 +131,7 @@ func TestValidationFields(t *testing.T) {
 		name: "empty",
 		s:    &CloudStorageSource{Spec: CloudStorageSourceSpec{}},
 		want: func() *apis.FieldError {
-			fe := apis.ErrMissingField("spec.bucket", "spec.sink")
+			fe := apis.ErrMissingField("spec.sink").Also(apis.ErrMissingOneOf("spec.bucket", "spec.buckets"))
 			return fe
 		}(),
 	}, {
@@ -161,7 +161,7 @@ func TestSpecValidationFields(t *testing.T) {
 		name: "empty",
 		spec: &CloudStorageSourceSpec{},
 		want: func() *apis.FieldError {
-			fe := apis.ErrMissingField("bucket", "sink")
+			fe := apis.ErrMissingField("sink").Also(apis.ErrMissingOneOf("bucket", "buckets"))
 			return fe
 		}(),
 	}, {
@@ -205,7 +205,62 @@ func TestSpecValidationFields(t *testing.T) {
 			},
 		},
 		want: func() *apis.FieldError {
-			fe := apis.ErrMissingField("bucket")
+			fe := apis.ErrMissingOneOf("bucket", "buckets")
+			return fe
+		}(),
+	}, {
+		name: "buckets",
+		spec: func() *CloudStorageSourceSpec {
+			obj := minimalCloudStorageSourceSpec.DeepCopy()
+			obj.Bucket = ""
+			obj.Buckets = []string{"bucket-1", "bucket-2"}
+			obj.PayloadFormat = CloudStorageSourceNoPayload
+			obj.Attributes = map[string]string{"team": "storage"}
+			return obj
+		}(),
+	}, {
+		name: "both bucket and buckets",
+		spec: func() *CloudStorageSourceSpec {
+			obj := minimalCloudStorageSourceSpec.DeepCopy()
+			obj.Buckets = []string{"bucket-1"}
+			return obj
+		}(),
+		want: func() *apis.FieldError {
+			fe := apis.ErrMultipleOneOf("bucket", "buckets")
+			return fe
+		}(),
+	}, {
+		name: "invalid buckets",
+		spec: func() *CloudStorageSourceSpec {
+			obj := minimalCloudStorageSourceSpec.DeepCopy()
+			obj.Bucket = ""
+			obj.Buckets = []string{"bucket-1", "", "bucket-1"}
+			return obj
+		}(),
+		want: func() *apis.FieldError {
+			fe := apis.ErrMissingField("buckets[1]").Also(apis.ErrGeneric("duplicate bucket", "buckets[2]"))
+			return fe
+		}(),
+	}, {
+		name: "invalid payload format",
+		spec: func() *CloudStorageSourceSpec {
+			obj := minimalCloudStorageSourceSpec.DeepCopy()
+			obj.PayloadFormat = "XML"
+			return obj
+		}(),
+		want: func() *apis.FieldError {
+			fe := apis.ErrInvalidValue("XML", "payloadFormat")
+			return fe
+		}(),
+	}, {
+		name: "invalid attribute",
+		spec: func() *CloudStorageSourceSpec {
+			obj := minimalCloudStorageSourceSpec.DeepCopy()
+			obj.Attributes = map[string]string{"bucketId": "foo"}
+			return obj
+		}(),
+		want: func() *apis.FieldError {
+			fe := apis.ErrInvalidKeyName("bucketId", "attributes", "must consist of lower-case letters and digits")
 			return fe
 		}(),
 	}, {
@@ -419,6 +474,38 @@ func TestCheckImmutableFields(t *testing.T) {
 			},
 			allowed: false,
 		},
+		"Buckets changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Buckets:          []string{storageSourceSpec.Bucket, "some-other-bucket"},
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				PubSubSpec:       storageSourceSpec.PubSubSpec,
+			},
+			allowed: false,
+		},
+		"PayloadFormat changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Bucket:           storageSourceSpec.Bucket,
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				PayloadFormat:    CloudStorageSourceNoPayload,
+				PubSubSpec:       storageSourceSpec.PubSubSpec,
+			},
+			allowed: false,
+		},
+		"Attributes changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Bucket:           storageSourceSpec.Bucket,
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				Attributes:       map[string]string{"team": "storage"},
+				PubSubSpec:       storageSourceSpec.PubSubSpec,
+			},
+			allowed: false,
+		},
 		"EventType changed": {
 			orig: &storageSourceSpec,
 			updated: CloudStorageSourceSpec{
@@ -39,6 +39,12 @@ func TestTypesImplements(t *testing.T) {
 		{instance: &CloudPubSubSource{}, iface: &v1.Conditions{}},
 		{instance: &CloudBuildSource{}, iface: &v1.Source{}},
 		{instance: &CloudBuildSource{}, iface: &v1.Conditions{}},
+		{instance: &CloudMonitoringAlertSource{}, iface: &v1.Source{}},
+		{instance: &CloudMonitoringAlertSource{}, iface: &v1.Conditions{}},
+		{instance: &CloudBillingBudgetSource{}, iface: &v1.Source{}},
+		{instance: &CloudBillingBudgetSource{}, iface: &v1.Conditions{}},
+		{instance: &CloudArtifactRegistrySource{}, iface: &v1.Source{}},
+		{instance: &CloudArtifactRegistrySource{}, iface: &v1.Conditions{}},
 	}
 	for _, tc := range testCases {
 		if err := duck.VerifyType(tc.instance, tc.iface); err != nil {
@@ -47,8 +47,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CloudAuditLogsSource{},
 		&CloudAuditLogsSourceList{},
+		&CloudBillingBudgetSource{},
+		&CloudBillingBudgetSourceList{},
+		&CloudArtifactRegistrySource{},
+		&CloudArtifactRegistrySourceList{},
 		&CloudBuildSource{},
 		&CloudBuildSourceList{},
+		&CloudMonitoringAlertSource{},
+		&CloudMonitoringAlertSourceList{},
 		&CloudPubSubSource{},
 		&CloudPubSubSourceList{},
 		&CloudSchedulerSource{},
@@ -59,8 +59,11 @@ func TestKnownTypes(t *testing.T) {
 	types := scheme.KnownTypes(SchemeGroupVersion)
 
 	for _, name := range []string{
+		"CloudArtifactRegistrySource",
 		"CloudAuditLogsSource",
+		"CloudBillingBudgetSource",
 		"CloudBuildSource",
+		"CloudMonitoringAlertSource",
 		"CloudPubSubSource",
 		"CloudSchedulerSource",
 		"CloudStorageSource",
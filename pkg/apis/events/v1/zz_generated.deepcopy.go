@@ -21,9 +21,126 @@ limitations under the License.
 package v1
 
 import (
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketNotification) DeepCopyInto(out *BucketNotification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketNotification.
+func (in *BucketNotification) DeepCopy() *BucketNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudArtifactRegistrySource) DeepCopyInto(out *CloudArtifactRegistrySource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudArtifactRegistrySource.
+func (in *CloudArtifactRegistrySource) DeepCopy() *CloudArtifactRegistrySource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudArtifactRegistrySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudArtifactRegistrySource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudArtifactRegistrySourceList) DeepCopyInto(out *CloudArtifactRegistrySourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudArtifactRegistrySource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudArtifactRegistrySourceList.
+func (in *CloudArtifactRegistrySourceList) DeepCopy() *CloudArtifactRegistrySourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudArtifactRegistrySourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudArtifactRegistrySourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudArtifactRegistrySourceSpec) DeepCopyInto(out *CloudArtifactRegistrySourceSpec) {
+	*out = *in
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.ImageFilter != nil {
+		in, out := &in.ImageFilter, &out.ImageFilter
+		*out = new(duckv1.ImageFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudArtifactRegistrySourceSpec.
+func (in *CloudArtifactRegistrySourceSpec) DeepCopy() *CloudArtifactRegistrySourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudArtifactRegistrySourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudArtifactRegistrySourceStatus) DeepCopyInto(out *CloudArtifactRegistrySourceStatus) {
+	*out = *in
+	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudArtifactRegistrySourceStatus.
+func (in *CloudArtifactRegistrySourceStatus) DeepCopy() *CloudArtifactRegistrySourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudArtifactRegistrySourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudAuditLogsSource) DeepCopyInto(out *CloudAuditLogsSource) {
 	*out = *in
@@ -119,6 +236,101 @@ func (in *CloudAuditLogsSourceStatus) DeepCopy() *CloudAuditLogsSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudBillingBudgetSource) DeepCopyInto(out *CloudBillingBudgetSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBillingBudgetSource.
+func (in *CloudBillingBudgetSource) DeepCopy() *CloudBillingBudgetSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudBillingBudgetSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudBillingBudgetSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudBillingBudgetSourceList) DeepCopyInto(out *CloudBillingBudgetSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudBillingBudgetSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBillingBudgetSourceList.
+func (in *CloudBillingBudgetSourceList) DeepCopy() *CloudBillingBudgetSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudBillingBudgetSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudBillingBudgetSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudBillingBudgetSourceSpec) DeepCopyInto(out *CloudBillingBudgetSourceSpec) {
+	*out = *in
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBillingBudgetSourceSpec.
+func (in *CloudBillingBudgetSourceSpec) DeepCopy() *CloudBillingBudgetSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudBillingBudgetSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudBillingBudgetSourceStatus) DeepCopyInto(out *CloudBillingBudgetSourceStatus) {
+	*out = *in
+	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBillingBudgetSourceStatus.
+func (in *CloudBillingBudgetSourceStatus) DeepCopy() *CloudBillingBudgetSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudBillingBudgetSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudBuildSource) DeepCopyInto(out *CloudBuildSource) {
 	*out = *in
@@ -184,6 +396,11 @@ func (in *CloudBuildSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudBuildSourceSpec) DeepCopyInto(out *CloudBuildSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.BuildFilter != nil {
+		in, out := &in.BuildFilter, &out.BuildFilter
+		*out = new(duckv1.BuildFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -214,6 +431,111 @@ func (in *CloudBuildSourceStatus) DeepCopy() *CloudBuildSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSource) DeepCopyInto(out *CloudMonitoringAlertSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSource.
+func (in *CloudMonitoringAlertSource) DeepCopy() *CloudMonitoringAlertSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudMonitoringAlertSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceList) DeepCopyInto(out *CloudMonitoringAlertSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudMonitoringAlertSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceList.
+func (in *CloudMonitoringAlertSourceList) DeepCopy() *CloudMonitoringAlertSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudMonitoringAlertSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceSpec) DeepCopyInto(out *CloudMonitoringAlertSourceSpec) {
+	*out = *in
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.AlertPolicies != nil {
+		in, out := &in.AlertPolicies, &out.AlertPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceSpec.
+func (in *CloudMonitoringAlertSourceSpec) DeepCopy() *CloudMonitoringAlertSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceStatus) DeepCopyInto(out *CloudMonitoringAlertSourceStatus) {
+	*out = *in
+	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	if in.AlertPolicies != nil {
+		in, out := &in.AlertPolicies, &out.AlertPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceStatus.
+func (in *CloudMonitoringAlertSourceStatus) DeepCopy() *CloudMonitoringAlertSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudPubSubSource) DeepCopyInto(out *CloudPubSubSource) {
 	*out = *in
@@ -384,6 +706,18 @@ func (in *CloudSchedulerSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudSchedulerSourceSpec) DeepCopyInto(out *CloudSchedulerSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.RetryConfig != nil {
+		in, out := &in.RetryConfig, &out.RetryConfig
+		*out = new(SchedulerRetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -479,11 +813,23 @@ func (in *CloudStorageSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudStorageSourceSpec) DeepCopyInto(out *CloudStorageSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.EventTypes != nil {
 		in, out := &in.EventTypes, &out.EventTypes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -501,6 +847,11 @@ func (in *CloudStorageSourceSpec) DeepCopy() *CloudStorageSourceSpec {
 func (in *CloudStorageSourceStatus) DeepCopyInto(out *CloudStorageSourceStatus) {
 	*out = *in
 	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]BucketNotification, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -513,3 +864,29 @@ func (in *CloudStorageSourceStatus) DeepCopy() *CloudStorageSourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerRetryConfig) DeepCopyInto(out *SchedulerRetryConfig) {
+	*out = *in
+	if in.MinBackoffDuration != nil {
+		in, out := &in.MinBackoffDuration, &out.MinBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackoffDuration != nil {
+		in, out := &in.MaxBackoffDuration, &out.MaxBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerRetryConfig.
+func (in *SchedulerRetryConfig) DeepCopy() *SchedulerRetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerRetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
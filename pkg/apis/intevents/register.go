@@ -43,6 +43,13 @@ const (
 	MinAckDeadline = 0 * time.Second
 	// MinAckDeadline is the maximum ack deadline (10 minutes) to validate the pullSubscription.
 	MaxAckDeadline = 10 * time.Minute
+	// DefaultBatchMaxDelay is the default time (1 second) a batch is held before it's delivered.
+	DefaultBatchMaxDelay = time.Second
+	// MaxBatchMaxDelay is the maximum time (1 minute) to validate the pullSubscription batching.
+	MaxBatchMaxDelay = time.Minute
+	// MaxBatchMaxEvents is the maximum number of events in a batch to validate the pullSubscription
+	// batching. It matches the default maximum number of outstanding Pub/Sub messages.
+	MaxBatchMaxEvents = 1000
 )
 
 var (
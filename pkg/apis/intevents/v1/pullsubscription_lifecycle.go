@@ -72,6 +72,28 @@ func (s *PullSubscriptionStatus) MarkNoTransformer(reason, messageFormat string,
 	pullSubscriptionCondSet.Manage(s).MarkFalse(PullSubscriptionConditionTransformerProvided, reason, messageFormat, messageA...)
 }
 
+// MarkReply sets the condition that the source has a reply configured.
+func (s *PullSubscriptionStatus) MarkReply(uri *apis.URL) {
+	s.ReplyURI = uri
+	if !uri.IsEmpty() {
+		pullSubscriptionCondSet.Manage(s).MarkTrue(PullSubscriptionConditionReplyProvided)
+	} else {
+		pullSubscriptionCondSet.Manage(s).MarkUnknown(PullSubscriptionConditionReplyProvided, "ReplyEmpty", "Reply has resolved to empty.")
+	}
+}
+
+// MarkNoReply sets the condition that the source does not have a reply configured.
+func (s *PullSubscriptionStatus) MarkNoReply(reason, messageFormat string, messageA ...interface{}) {
+	pullSubscriptionCondSet.Manage(s).MarkFalse(PullSubscriptionConditionReplyProvided, reason, messageFormat, messageA...)
+}
+
+// ClearReply removes the reply URI and condition, for PullSubscriptions without
+// a reply configured.
+func (s *PullSubscriptionStatus) ClearReply() {
+	s.ReplyURI = nil
+	pullSubscriptionCondSet.Manage(s).ClearCondition(PullSubscriptionConditionReplyProvided)
+}
+
 // MarkSubscribed sets the condition that the subscription has been created.
 func (s *PullSubscriptionStatus) MarkSubscribed(subscriptionID string) {
 	s.SubscriptionID = subscriptionID
@@ -395,6 +395,60 @@ func TestPullSubscriptionStatusGetCondition(t *testing.T) {
 			Reason:  "reason",
 			Message: "message",
 		},
+	}, {
+		name: "mark reply",
+		s: func() *PullSubscriptionStatus {
+			s := &PullSubscriptionStatus{}
+			s.InitializeConditions()
+			s.MarkReply(apis.HTTP("url"))
+			return s
+		}(),
+		condQuery: PullSubscriptionConditionReplyProvided,
+		want: &apis.Condition{
+			Type:   PullSubscriptionConditionReplyProvided,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark reply unknown",
+		s: func() *PullSubscriptionStatus {
+			s := &PullSubscriptionStatus{}
+			s.InitializeConditions()
+			s.MarkReply(nil)
+			return s
+		}(),
+		condQuery: PullSubscriptionConditionReplyProvided,
+		want: &apis.Condition{
+			Type:    PullSubscriptionConditionReplyProvided,
+			Status:  corev1.ConditionUnknown,
+			Reason:  "ReplyEmpty",
+			Message: "Reply has resolved to empty.",
+		},
+	}, {
+		name: "mark no reply",
+		s: func() *PullSubscriptionStatus {
+			s := &PullSubscriptionStatus{}
+			s.InitializeConditions()
+			s.MarkNoReply("reason", "%s", "message")
+			return s
+		}(),
+		condQuery: PullSubscriptionConditionReplyProvided,
+		want: &apis.Condition{
+			Type:    PullSubscriptionConditionReplyProvided,
+			Status:  corev1.ConditionFalse,
+			Reason:  "reason",
+			Message: "message",
+		},
+	}, {
+		name: "clear reply",
+		s: func() *PullSubscriptionStatus {
+			s := &PullSubscriptionStatus{}
+			s.InitializeConditions()
+			s.MarkReply(apis.HTTP("url"))
+			s.ClearReply()
+			return s
+		}(),
+		condQuery: PullSubscriptionConditionReplyProvided,
+		want:      nil,
 	}, {
 		name: "mark sink and deployed",
 		s: func() *PullSubscriptionStatus {
@@ -85,15 +85,128 @@ type PullSubscriptionSpec struct {
 	// +optional
 	RetentionDuration *string `json:"retentionDuration,omitempty"`
 
+	// EnableMessageOrdering enables message ordering on the Pub/Sub
+	// subscription, so that messages with the same ordering key are delivered
+	// to the sink one at a time, in the order they were published. Cannot be
+	// used together with Batching.
+	// +optional
+	EnableMessageOrdering bool `json:"enableMessageOrdering,omitempty"`
+
 	// Transformer is a reference to an object that will resolve to a domain
 	// name or a URI directly to use as the transformer or a URI directly.
 	// +optional
 	Transformer *duckv1.Destination `json:"transformer,omitempty"`
 
+	// Mode defines the encoding and structure of the payload of when the
+	// PullSubscription invokes the sink. Defaults to CloudEvents binary HTTP
+	// mode.
+	// +optional
+	Mode ModeType `json:"mode,omitempty"`
+
 	// AdapterType determines the type of receive adapter that a
 	// PullSubscription uses.
 	// +optional
 	AdapterType string `json:"adapterType,omitempty"`
+
+	// Batching makes the receive adapter deliver events to the sink in
+	// batches (application/cloudevents-batch+json) instead of one by one.
+	// All messages of a batch are acked or nacked together based on the sink
+	// response. Batching cannot be used together with Transformer.
+	// +optional
+	Batching *BatchingSpec `json:"batching,omitempty"`
+
+	// Converter declares how the received Pub/Sub messages are converted to
+	// CloudEvents. It takes precedence over AdapterType.
+	// +optional
+	Converter *ConverterSpec `json:"converter,omitempty"`
+
+	// BuildFilter selects the Cloud Build notifications delivered to the sink
+	// when AdapterType is the Cloud Build one. The notifications of the other
+	// builds are acked without being delivered.
+	// +optional
+	BuildFilter *v1.BuildFilterSpec `json:"buildFilter,omitempty"`
+
+	// ImageFilter selects the Artifact Registry and Container Registry image
+	// notifications delivered to the sink when AdapterType is the Artifact
+	// Registry one. The notifications of the other images are acked without
+	// being delivered.
+	// +optional
+	ImageFilter *v1.ImageFilterSpec `json:"imageFilter,omitempty"`
+}
+
+// ConverterSpec declares how a Pub/Sub message is converted to a CloudEvent.
+//
+// Type, Source and Subject are Go templates executed against the message,
+// which exposes .ID, .PublishTime, .Attributes, .OrderingKey, .Project and
+// .Topic. E.g. 'com.example.{{.Attributes.kind}}'.
+type ConverterSpec struct {
+	// Type is the template of the CloudEvent type.
+	Type string `json:"type"`
+
+	// Source is the template of the CloudEvent source.
+	Source string `json:"source"`
+
+	// Subject is the template of the CloudEvent subject. The subject is not
+	// set if empty.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Attributes maps Pub/Sub message attributes to CloudEvent attributes.
+	// Keys are message attribute names, values are either 'id', 'subject',
+	// 'time', 'dataschema' or the name of an extension. Unmapped message
+	// attributes are promoted to extensions if their names are valid
+	// extension names, and are dropped otherwise.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// DataPath is the dot-separated path of the field of the JSON payload
+	// to use as the CloudEvent data, e.g. 'payload.record'. The whole payload
+	// is used if empty.
+	// +optional
+	DataPath string `json:"dataPath,omitempty"`
+
+	// DataContentType is the content type of the CloudEvent data. Defaults
+	// to 'application/json' if DataPath is set, and to
+	// 'application/octet-stream' otherwise.
+	// +optional
+	DataContentType string `json:"dataContentType,omitempty"`
+}
+
+type ModeType string
+
+const (
+	// ModeCloudEventsBinary will use CloudEvents binary HTTP mode.
+	ModeCloudEventsBinary ModeType = "CloudEventsBinary"
+
+	// ModeCloudEventsStructured will use CloudEvents structured HTTP mode.
+	ModeCloudEventsStructured ModeType = "CloudEventsStructured"
+
+	// ModePushCompatible will deliver the Pub/Sub message as Cloud Pub/Sub
+	// delivers a push message, in a JSON body without CloudEvents headers.
+	ModePushCompatible ModeType = "PushCompatible"
+)
+
+// BatchingSpec defines how the receive adapter batches events.
+type BatchingSpec struct {
+	// MaxEvents is the maximum number of events in a batch. A batch is
+	// delivered as soon as it's full. Cannot be larger than 1000.
+	MaxEvents int32 `json:"maxEvents"`
+
+	// MaxDelay is the maximum time a batch is held waiting for more events
+	// before it's delivered. Defaults to 1 second ('1s'). Cannot be longer
+	// than 1 minute.
+	// +optional
+	MaxDelay *string `json:"maxDelay,omitempty"`
+}
+
+// GetMaxDelay parses MaxDelay and returns the default if an error occurs.
+func (bs BatchingSpec) GetMaxDelay() time.Duration {
+	if bs.MaxDelay != nil {
+		if duration, err := time.ParseDuration(*bs.MaxDelay); err == nil {
+			return duration
+		}
+	}
+	return intevents.DefaultBatchMaxDelay
 }
 
 // GetAckDeadline parses AckDeadline and returns the default if an error occurs.
@@ -137,6 +250,10 @@ const (
 	// PullSubscriptionConditionTransformerProvided has status True when the
 	// PullSubscription has been configured with a transformer target.
 	PullSubscriptionConditionTransformerProvided apis.ConditionType = "TransformerProvided"
+
+	// PullSubscriptionConditionReplyProvided has status True when the
+	// PullSubscription has been configured with a reply target.
+	PullSubscriptionConditionReplyProvided apis.ConditionType = "ReplyProvided"
 )
 
 var pullSubscriptionCondSet = apis.NewLivingConditionSet(
@@ -154,6 +271,11 @@ type PullSubscriptionStatus struct {
 	// +optional
 	TransformerURI *apis.URL `json:"transformerUri,omitempty"`
 
+	// ReplyURI is the current active reply URI that has been configured for
+	// the PullSubscription.
+	// +optional
+	ReplyURI *apis.URL `json:"replyUri,omitempty"`
+
 	// SubscriptionID is the created subscription ID used by the PullSubscription.
 	// +optional
 	SubscriptionID string `json:"subscriptionId,omitempty"`
@@ -85,6 +85,26 @@ func TestGetRetentionDuration_default(t *testing.T) {
 	}
 }
 
+func TestGetBatchMaxDelay(t *testing.T) {
+	want := 100 * time.Millisecond
+	s := &BatchingSpec{MaxDelay: ptr.String("100ms")}
+	got := s.GetMaxDelay()
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGetBatchMaxDelay_default(t *testing.T) {
+	want := intevents.DefaultBatchMaxDelay
+	s := &BatchingSpec{}
+	got := s.GetMaxDelay()
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
 func TestPullSubscriptionIdentitySpec(t *testing.T) {
 	s := &PullSubscription{
 		Spec: PullSubscriptionSpec{
@@ -18,6 +18,8 @@ package v1
 
 import (
 	"context"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -80,6 +82,37 @@ func (current *PullSubscriptionSpec) Validate(ctx context.Context) *apis.FieldEr
 		}
 	}
 
+	// Mode [optional]
+	switch current.Mode {
+	case "", ModeCloudEventsBinary, ModeCloudEventsStructured:
+		// valid
+	case ModePushCompatible:
+		// Push messages are delivered as is, so they cannot be converted
+		// into events, transformed, batched or filtered.
+		if current.Transformer != nil && !equality.Semantic.DeepEqual(current.Transformer, &duckv1.Destination{}) {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "transformer"))
+		}
+		if current.Converter != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "converter"))
+		}
+		if current.Batching != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "batching"))
+		}
+		// Only sampling applies to push messages, which have no attributes
+		// to filter on.
+		if current.Filter != nil && len(current.Filter.Attributes) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "filter.attributes"))
+		}
+		if current.BuildFilter != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "buildFilter"))
+		}
+		if current.ImageFilter != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("mode", "imageFilter"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.Mode, "mode"))
+	}
+
 	if current.Secret != nil {
 		if !equality.Semantic.DeepEqual(current.Secret, &corev1.SecretKeySelector{}) {
 			err := validateSecret(current.Secret)
@@ -89,9 +122,120 @@ func (current *PullSubscriptionSpec) Validate(ctx context.Context) *apis.FieldEr
 		}
 	}
 
+	if current.Batching != nil {
+		errs = errs.Also(current.Batching.Validate(ctx).ViaField("batching"))
+		// A batch cannot be sent to the transformer, as its reply is expected
+		// to be a single event.
+		if current.Transformer != nil && !equality.Semantic.DeepEqual(current.Transformer, &duckv1.Destination{}) {
+			errs = errs.Also(apis.ErrMultipleOneOf("batching", "transformer"))
+		}
+		// Same for the sink reply to a batch.
+		if current.Reply != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("batching", "reply"))
+		}
+		// Batched messages are acked asynchronously, so the next messages
+		// with the same ordering key could be delivered before them.
+		if current.EnableMessageOrdering {
+			errs = errs.Also(apis.ErrMultipleOneOf("batching", "enableMessageOrdering"))
+		}
+	}
+
+	if current.Converter != nil {
+		errs = errs.Also(current.Converter.Validate(ctx).ViaField("converter"))
+	}
+
+	errs = errs.Also(current.PubSubSpec.Validate(ctx))
+
+	if current.BuildFilter != nil {
+		errs = errs.Also(current.BuildFilter.Validate(ctx).ViaField("buildFilter"))
+	}
+
+	if current.ImageFilter != nil {
+		errs = errs.Also(current.ImageFilter.Validate(ctx).ViaField("imageFilter"))
+	}
+
+	return errs
+}
+
+func (current *BatchingSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if current.MaxEvents < 1 || current.MaxEvents > intevents.MaxBatchMaxEvents {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(current.MaxEvents, 1, intevents.MaxBatchMaxEvents, "maxEvents"))
+	}
+	if current.MaxDelay != nil {
+		// If set, MaxDelay needs to parse to a valid positive duration.
+		md, err := time.ParseDuration(*current.MaxDelay)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*current.MaxDelay, "maxDelay"))
+		} else if md <= 0 || md > intevents.MaxBatchMaxDelay {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(*current.MaxDelay, "0s", intevents.MaxBatchMaxDelay.String(), "maxDelay"))
+		}
+	}
+	return errs
+}
+
+// mappableAttributes are the CloudEvent context attributes, other than
+// extensions, that Pub/Sub message attributes can be mapped to.
+var mappableAttributes = map[string]bool{
+	"id":         true,
+	"subject":    true,
+	"time":       true,
+	"dataschema": true,
+}
+
+// reservedAttributes are the CloudEvent context attributes that Pub/Sub
+// message attributes cannot be mapped to.
+var reservedAttributes = map[string]bool{
+	"specversion":     true,
+	"type":            true,
+	"source":          true,
+	"datacontenttype": true,
+	"data":            true,
+}
+
+func (current *ConverterSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if current.Type == "" {
+		errs = errs.Also(apis.ErrMissingField("type"))
+	} else {
+		errs = errs.Also(validateTemplate(current.Type, "type"))
+	}
+	if current.Source == "" {
+		errs = errs.Also(apis.ErrMissingField("source"))
+	} else {
+		errs = errs.Also(validateTemplate(current.Source, "source"))
+	}
+	errs = errs.Also(validateTemplate(current.Subject, "subject"))
+	for k, v := range current.Attributes {
+		if mappableAttributes[v] {
+			continue
+		}
+		if reservedAttributes[v] || !duck.IsValidExtensionName(v) {
+			errs = errs.Also(apis.ErrInvalidValue(v, apis.CurrentField).ViaKey(k).ViaField("attributes"))
+		}
+	}
+	if current.DataPath != "" {
+		for _, f := range strings.Split(current.DataPath, ".") {
+			if f == "" {
+				errs = errs.Also(apis.ErrInvalidValue(current.DataPath, "dataPath"))
+				break
+			}
+		}
+	}
 	return errs
 }
 
+func validateTemplate(text, field string) *apis.FieldError {
+	if _, err := template.New(field).Parse(text); err != nil {
+		return &apis.FieldError{
+			Message: "invalid template",
+			Paths:   []string{field},
+			Details: err.Error(),
+		}
+	}
+	return nil
+}
+
 // TODO move this to a common place.
 func validateSecret(secret *corev1.SecretKeySelector) *apis.FieldError {
 	var errs *apis.FieldError
@@ -110,11 +254,11 @@ func (current *PullSubscription) CheckImmutableFields(ctx context.Context, origi
 	}
 
 	var errs *apis.FieldError
-	// Modification of Topic, Secret, AckDeadline, RetainAckedMessages, RetentionDuration and Project are not allowed.
+	// Modification of Topic, Secret, AckDeadline, RetainAckedMessages, RetentionDuration, EnableMessageOrdering and Project are not allowed.
 	// Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(PullSubscriptionSpec{},
-			"Sink", "Transformer", "CloudEventOverrides")); diff != "" {
+			"Sink", "Transformer", "CloudEventOverrides", "Batching", "Delivery", "Reply", "FlowControl", "Filter", "Dedup", "BuildFilter", "ImageFilter", "Converter", "Mode")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -26,6 +26,7 @@ import (
 	v1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/ptr"
@@ -217,6 +218,386 @@ func TestPullSubscriptionCheckValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"ok batching": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 100, MaxDelay: ptr.String("500ms")}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad batching, with transformer": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Batching = &BatchingSpec{MaxEvents: 100}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad batching, maxEvents": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 0}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad batching, maxEvents range": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 1001}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad batching, maxDelay": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 100, MaxDelay: ptr.String("wrong")}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad batching, maxDelay range": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 100, MaxDelay: ptr.String("2m")}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok delivery": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				policy := eventingduckv1.BackoffPolicyExponential
+				obj.Delivery = &eventingduckv1.DeliverySpec{
+					Retry:         ptr.Int32(3),
+					BackoffPolicy: &policy,
+					BackoffDelay:  ptr.String("PT0.5S"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad delivery, backoffDelay": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1.DeliverySpec{
+					BackoffDelay: ptr.String("1s"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok reply": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Reply = &duckv1.Destination{
+					URI: apis.HTTP("reply"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad reply": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Reply = &duckv1.Destination{}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad batching, with reply": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 100}
+				obj.Reply = &duckv1.Destination{
+					URI: apis.HTTP("reply"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok message ordering": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.EnableMessageOrdering = true
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad batching, with message ordering": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Batching = &BatchingSpec{MaxEvents: 100}
+				obj.EnableMessageOrdering = true
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok flow control": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.FlowControl = &v1.FlowControlSpec{
+					MaxOutstandingMessages: 100,
+					MaxExtension:           ptr.String("10m"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad flow control, maxExtension": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.FlowControl = &v1.FlowControlSpec{
+					MaxExtension: ptr.String("10"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Filter = &v1.EventFilterSpec{
+					Attributes: map[string]string{"type": "type"},
+					SampleRate: ptr.String("0.5"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad filter, sampleRate": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Filter = &v1.EventFilterSpec{
+					SampleRate: ptr.String("2"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok push compatible mode, with sampling": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.Filter = &v1.EventFilterSpec{
+					SampleRate: ptr.String("0.5"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad push compatible mode, with attribute filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.Filter = &v1.EventFilterSpec{
+					Attributes: map[string]string{"type": "type"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad push compatible mode, with build filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.BuildFilter = &v1.BuildFilterSpec{
+					Statuses: []string{"SUCCESS"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok build filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.BuildFilter = &v1.BuildFilterSpec{
+					Statuses: []string{"SUCCESS"},
+					Images:   []string{"gcr.io/my-project/my-image"},
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad build filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.BuildFilter = &v1.BuildFilterSpec{
+					TriggerIDs: []string{""},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad push compatible mode, with image filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.ImageFilter = &v1.ImageFilterSpec{
+					Images: []string{"gcr.io/my-project/my-image"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok image filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.ImageFilter = &v1.ImageFilterSpec{
+					Repositories: []string{"gcr.io/my-project"},
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad image filter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.ImageFilter = &v1.ImageFilterSpec{
+					Images: []string{""},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok mode": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Mode = ModeCloudEventsStructured
+				return *obj
+			}(),
+			error: false,
+		},
+		"ok push compatible mode": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad mode": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Mode = "Pull"
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad push compatible mode, with transformer": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Mode = ModePushCompatible
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad push compatible mode, with batching": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.Batching = &BatchingSpec{MaxEvents: 100}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad push compatible mode, with converter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Transformer = nil
+				obj.Mode = ModePushCompatible
+				obj.Converter = &ConverterSpec{Type: "type", Source: "source"}
+				return *obj
+			}(),
+			error: true,
+		},
+		"ok converter": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{
+					Type:    "com.example.{{.Attributes.kind}}",
+					Source:  "//example.com/topics/{{.Topic}}",
+					Subject: "{{index .Attributes \"object-id\"}}",
+					Attributes: map[string]string{
+						"event-id": "id",
+						"trace-id": "traceid",
+					},
+					DataPath: "payload.record",
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad converter, missing type and source": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad converter, invalid template": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{
+					Type:   "com.example.{{.Attributes.kind",
+					Source: "source",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad converter, reserved attribute": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{
+					Type:       "type",
+					Source:     "source",
+					Attributes: map[string]string{"kind": "type"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad converter, invalid extension name": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{
+					Type:       "type",
+					Source:     "source",
+					Attributes: map[string]string{"trace-id": "trace-id"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad converter, invalid data path": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Converter = &ConverterSpec{
+					Type:     "type",
+					Source:   "source",
+					DataPath: "payload..record",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
 		"bad secret, missing key": {
 			spec: func() PullSubscriptionSpec {
 				obj := pullSubscriptionSpec.DeepCopy()
@@ -710,6 +1091,60 @@ func TestPullSubscriptionCheckImmutableFields(t *testing.T) {
 			},
 			allowed: false,
 		},
+		"Batching changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Batching = &BatchingSpec{MaxEvents: 10}
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"Delivery changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1.DeliverySpec{Retry: ptr.Int32(5)}
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"EnableMessageOrdering changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.EnableMessageOrdering = true
+				return *obj
+			}(),
+			allowed: false,
+		},
+		"FlowControl changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.FlowControl = &v1.FlowControlSpec{NumGoroutines: 2}
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"Mode changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Mode = ModeCloudEventsStructured
+				return *obj
+			}(),
+			allowed: true,
+		},
+		"Reply changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Reply = &duckv1.Destination{URI: apis.HTTP("reply")}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"ServiceAccountName added": {
 			orig: &pullSubscriptionSpec,
 			updated: PullSubscriptionSpec{
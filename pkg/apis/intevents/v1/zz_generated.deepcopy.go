@@ -21,12 +21,57 @@ limitations under the License.
 package v1
 
 import (
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	apis "knative.dev/pkg/apis"
-	duckv1 "knative.dev/pkg/apis/duck/v1"
+	pkgapisduckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchingSpec) DeepCopyInto(out *BatchingSpec) {
+	*out = *in
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchingSpec.
+func (in *BatchingSpec) DeepCopy() *BatchingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConverterSpec) DeepCopyInto(out *ConverterSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConverterSpec.
+func (in *ConverterSpec) DeepCopy() *ConverterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConverterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullSubscription) DeepCopyInto(out *PullSubscription) {
 	*out = *in
@@ -104,7 +149,27 @@ func (in *PullSubscriptionSpec) DeepCopyInto(out *PullSubscriptionSpec) {
 	}
 	if in.Transformer != nil {
 		in, out := &in.Transformer, &out.Transformer
-		*out = new(duckv1.Destination)
+		*out = new(pkgapisduckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Batching != nil {
+		in, out := &in.Batching, &out.Batching
+		*out = new(BatchingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Converter != nil {
+		in, out := &in.Converter, &out.Converter
+		*out = new(ConverterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BuildFilter != nil {
+		in, out := &in.BuildFilter, &out.BuildFilter
+		*out = new(duckv1.BuildFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageFilter != nil {
+		in, out := &in.ImageFilter, &out.ImageFilter
+		*out = new(duckv1.ImageFilterSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -129,6 +194,11 @@ func (in *PullSubscriptionStatus) DeepCopyInto(out *PullSubscriptionStatus) {
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReplyURI != nil {
+		in, out := &in.ReplyURI, &out.ReplyURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
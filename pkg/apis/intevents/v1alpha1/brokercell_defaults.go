@@ -48,6 +48,10 @@ const (
 	memoryLimitRetry      string = "1500Mi"
 	minReplicas           int32  = 1
 	maxReplicas           int32  = 10
+
+	targetBacklog          int64 = 100
+	backlogPollingInterval int32 = 15
+	backlogCooldownPeriod  int32 = 120
 )
 
 // SetDefaults sets the default field values for a BrokerCell.
@@ -93,4 +97,24 @@ func (componentParams *ComponentParameters) setAutoScalingDefaults() {
 	if componentParams.MaxReplicas == nil {
 		componentParams.MaxReplicas = ptr.Int32(maxReplicas)
 	}
+	if componentParams.BacklogScaling != nil {
+		componentParams.BacklogScaling.setDefaults()
+	}
+}
+
+func (bs *BacklogScalingSpec) setDefaults() {
+	if bs.Class == "" {
+		bs.Class = BacklogScalingExternalMetrics
+	}
+	if bs.TargetBacklog == nil {
+		bs.TargetBacklog = ptr.Int64(targetBacklog)
+	}
+	if bs.Class == BacklogScalingKeda {
+		if bs.PollingInterval == nil {
+			bs.PollingInterval = ptr.Int32(backlogPollingInterval)
+		}
+		if bs.CooldownPeriod == nil {
+			bs.CooldownPeriod = ptr.Int32(backlogCooldownPeriod)
+		}
+	}
 }
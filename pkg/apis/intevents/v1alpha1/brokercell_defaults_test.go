@@ -196,6 +196,48 @@ func TestBrokerCell_SetDefaults(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Backlog scaling defaults",
+		start: &BrokerCell{
+			Spec: BrokerCellSpec{
+				ComponentsParametersSpec{
+					Fanout: &ComponentParameters{
+						AvgCPUUtilization: ptr.Int32(95),
+						BacklogScaling:    &BacklogScalingSpec{},
+					},
+					Retry: &ComponentParameters{
+						AvgCPUUtilization: ptr.Int32(95),
+						BacklogScaling: &BacklogScalingSpec{
+							Class:         BacklogScalingKeda,
+							TargetBacklog: ptr.Int64(10),
+						},
+					},
+				},
+			},
+		},
+		want: &BrokerCell{
+			Spec: BrokerCellSpec{
+				ComponentsParametersSpec{
+					Fanout: (&ComponentParameters{
+						AvgCPUUtilization: ptr.Int32(95),
+						BacklogScaling: &BacklogScalingSpec{
+							Class:         BacklogScalingExternalMetrics,
+							TargetBacklog: ptr.Int64(targetBacklog),
+						},
+					}).WithDefaultReplicas(),
+					Ingress: makeComponent(cpuRequestIngress, cpuLimitIngress, memoryRequestIngress, memoryLimitIngress, avgCPUUtilizationIngress, avgMemoryUsageIngress).WithDefaultReplicas(),
+					Retry: (&ComponentParameters{
+						AvgCPUUtilization: ptr.Int32(95),
+						BacklogScaling: &BacklogScalingSpec{
+							Class:           BacklogScalingKeda,
+							TargetBacklog:   ptr.Int64(10),
+							PollingInterval: ptr.Int32(backlogPollingInterval),
+							CooldownPeriod:  ptr.Int32(backlogCooldownPeriod),
+						},
+					}).WithDefaultReplicas(),
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
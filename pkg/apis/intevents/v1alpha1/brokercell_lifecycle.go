@@ -50,6 +50,17 @@ const (
 	// BrokerCellConditionTargetsConfig reports the readiness of the
 	// BrokerCell's targets configmap.
 	BrokerCellConditionTargetsConfig apis.ConditionType = "TargetsConfigReady"
+
+	// BrokerCellConditionDataPlaneConfig reports whether the data plane pods
+	// are serving an up to date broker targets config. It doesn't affect the
+	// readiness of the BrokerCell as stale pods keep serving their last config.
+	BrokerCellConditionDataPlaneConfig apis.ConditionType = "DataPlaneConfigFresh"
+
+	// BrokerCellConditionBacklogScaling reports whether the backlog scaling of
+	// the fanout and retry components covers the subscriptions of all the
+	// brokers. It is only set when a component scales on its backlog, and
+	// doesn't affect the readiness of the BrokerCell.
+	BrokerCellConditionBacklogScaling apis.ConditionType = "BacklogScalingComplete"
 )
 
 // GetCondition returns the condition currently associated with the given type, or nil.
@@ -155,6 +166,32 @@ func (bs *BrokerCellStatus) MarkTargetsConfigFailed(reason, format string, args
 	brokerCellCondSet.Manage(bs).MarkFalse(BrokerCellConditionTargetsConfig, reason, format, args...)
 }
 
+func (bs *BrokerCellStatus) MarkDataPlaneConfigFresh() {
+	brokerCellCondSet.Manage(bs).MarkTrue(BrokerCellConditionDataPlaneConfig)
+}
+
+func (bs *BrokerCellStatus) MarkDataPlaneConfigStale(reason, format string, args ...interface{}) {
+	brokerCellCondSet.Manage(bs).MarkFalse(BrokerCellConditionDataPlaneConfig, reason, format, args...)
+}
+
+func (bs *BrokerCellStatus) MarkDataPlaneConfigUnknown(reason, format string, args ...interface{}) {
+	brokerCellCondSet.Manage(bs).MarkUnknown(BrokerCellConditionDataPlaneConfig, reason, format, args...)
+}
+
+func (bs *BrokerCellStatus) MarkBacklogScalingComplete() {
+	brokerCellCondSet.Manage(bs).MarkTrue(BrokerCellConditionBacklogScaling)
+}
+
+func (bs *BrokerCellStatus) MarkBacklogScalingIncomplete(reason, format string, args ...interface{}) {
+	brokerCellCondSet.Manage(bs).MarkFalse(BrokerCellConditionBacklogScaling, reason, format, args...)
+}
+
+// ClearBacklogScaling removes the backlog scaling condition, for BrokerCells
+// whose components don't scale on their backlog.
+func (bs *BrokerCellStatus) ClearBacklogScaling() {
+	brokerCellCondSet.Manage(bs).ClearCondition(BrokerCellConditionBacklogScaling)
+}
+
 func (bs *BrokerCellStatus) SetIngressTemplate(address string) {
 	bs.IngressTemplate = address
 }
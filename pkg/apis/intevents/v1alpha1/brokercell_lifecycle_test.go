@@ -328,3 +328,37 @@ func TestBrokerCellConditionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestBrokerCellDataPlaneConfigDoesNotAffectReadiness(t *testing.T) {
+	bs := TestHelper.ReadyBrokerCellStatus()
+	bs.MarkDataPlaneConfigStale("StaleReplicas", "induced failure")
+	if !bs.IsReady() {
+		t.Error("expected stale data plane config not to affect readiness")
+	}
+	if got := bs.GetCondition(BrokerCellConditionDataPlaneConfig).Status; got != corev1.ConditionFalse {
+		t.Errorf("unexpected data plane config condition: want %v, got %v", corev1.ConditionFalse, got)
+	}
+	bs.MarkDataPlaneConfigFresh()
+	if got := bs.GetCondition(BrokerCellConditionDataPlaneConfig).Status; got != corev1.ConditionTrue {
+		t.Errorf("unexpected data plane config condition: want %v, got %v", corev1.ConditionTrue, got)
+	}
+}
+
+func TestBrokerCellBacklogScalingDoesNotAffectReadiness(t *testing.T) {
+	bs := TestHelper.ReadyBrokerCellStatus()
+	bs.MarkBacklogScalingIncomplete("CrossProjectSubscriptions", "induced failure")
+	if !bs.IsReady() {
+		t.Error("expected incomplete backlog scaling not to affect readiness")
+	}
+	if got := bs.GetCondition(BrokerCellConditionBacklogScaling).Status; got != corev1.ConditionFalse {
+		t.Errorf("unexpected backlog scaling condition: want %v, got %v", corev1.ConditionFalse, got)
+	}
+	bs.MarkBacklogScalingComplete()
+	if got := bs.GetCondition(BrokerCellConditionBacklogScaling).Status; got != corev1.ConditionTrue {
+		t.Errorf("unexpected backlog scaling condition: want %v, got %v", corev1.ConditionTrue, got)
+	}
+	bs.ClearBacklogScaling()
+	if got := bs.GetCondition(BrokerCellConditionBacklogScaling); got != nil {
+		t.Errorf("unexpected backlog scaling condition: want nil, got %v", got)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -109,6 +110,102 @@ type ComponentParameters struct {
 
 	// MaxReplicas specifies the maximum replica count for the component.
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// BacklogScaling optionally makes the component scale on the number of
+	// undelivered messages in the Pub/Sub subscriptions it pulls from. Only
+	// the fanout (decouple subscriptions) and retry (retry subscriptions)
+	// components support it.
+	// +optional
+	BacklogScaling *BacklogScalingSpec `json:"backlogScaling,omitempty"`
+
+	// RolloutStrategy specifies how the component's deployment replaces its
+	// pods on updates. Defaults to a maxSurge of 1 and a maxUnavailable of 0.
+	// +optional
+	RolloutStrategy *RolloutStrategySpec `json:"rolloutStrategy,omitempty"`
+
+	// PodDisruptionBudget specifies how many of the component's pods may be
+	// voluntarily disrupted at once, e.g. by node drains. Defaults to a
+	// maxUnavailable of 1.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// RolloutStrategySpec specifies the rolling update parameters of a
+// component's deployment.
+type RolloutStrategySpec struct {
+	// MaxSurge is the maximum number or percentage of pods that can be
+	// created over the desired number of pods during an update.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number or percentage of pods that can be
+	// unavailable during an update.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// PodDisruptionBudgetSpec specifies the PodDisruptionBudget of a component.
+// At most one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods that must remain
+	// available after an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that can be
+	// unavailable after an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// BacklogScalingClass is the mechanism used to scale a component on its
+// Pub/Sub subscription backlog.
+type BacklogScalingClass string
+
+const (
+	// BacklogScalingExternalMetrics adds the num_undelivered_messages Cloud
+	// Monitoring metric of each subscription as an external metric to the
+	// component's Horizontal Pod Autoscaler. It requires an external metrics
+	// adapter for Cloud Monitoring to be installed in the cluster.
+	BacklogScalingExternalMetrics BacklogScalingClass = "external"
+
+	// BacklogScalingKeda replaces the component's Horizontal Pod Autoscaler
+	// with a KEDA ScaledObject with a gcp-pubsub trigger per subscription, for
+	// up to 20 subscriptions per component. The others are reported in the
+	// BacklogScalingComplete condition of the BrokerCell. The scaler looks the
+	// subscriptions up by ID in the project of the broker credentials. KEDA
+	// must be installed in the cluster. The CPU and memory targets of
+	// the component are not used with this class. The scaler only supports
+	// service account keys, so this class requires the key.json key of the
+	// google-broker-key secret and doesn't work with Workload Identity. The
+	// BacklogScalingComplete condition of the BrokerCell is false without it.
+	BacklogScalingKeda BacklogScalingClass = "keda"
+)
+
+// BacklogScalingSpec specifies how a component scales on the backlog of its
+// Pub/Sub subscriptions. Neither class sees the subscriptions of brokers in
+// other projects; they are reported by the BacklogScalingComplete condition
+// of the BrokerCell.
+type BacklogScalingSpec struct {
+	// Class is the scaling mechanism, either external or keda. Defaults to
+	// external.
+	// +optional
+	Class BacklogScalingClass `json:"class,omitempty"`
+
+	// TargetBacklog is the number of undelivered messages per subscription
+	// targeted for each replica of the component.
+	// +optional
+	TargetBacklog *int64 `json:"targetBacklog,omitempty"`
+
+	// PollingInterval is the interval in seconds KEDA uses to poll the
+	// subscription backlog. Only used by the keda class.
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds KEDA waits after the last
+	// trigger reported active before scaling in. Only used by the keda class.
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
 }
 
 // ComponentsParametersSpec specifies separate parameters for each component
@@ -138,6 +235,57 @@ type BrokerCellStatus struct {
 	// `namespace`.
 	// Example: "http://broker-ingress.cloud-run-events.svc.cluster.local/{namespace}/{name}"
 	IngressTemplate string `json:"ingressTemplate,omitempty"`
+
+	// Components reports the state of each data plane component of the
+	// BrokerCell.
+	// +optional
+	Components ComponentsStatus `json:"components,omitempty"`
+
+	// TargetsConfigGeneration is the generation of the broker targets config
+	// most recently written by the controller. It is incremented every time
+	// the brokers or triggers served by the BrokerCell change.
+	// +optional
+	TargetsConfigGeneration int64 `json:"targetsConfigGeneration,omitempty"`
+
+	// BrokerCount is the number of brokers served by the BrokerCell.
+	// +optional
+	BrokerCount int32 `json:"brokerCount,omitempty"`
+
+	// TriggerCount is the number of triggers served by the BrokerCell.
+	// +optional
+	TriggerCount int32 `json:"triggerCount,omitempty"`
+}
+
+// ComponentsStatus reports the state of each component of a BrokerCell.
+type ComponentsStatus struct {
+	Fanout  ComponentStatus `json:"fanout,omitempty"`
+	Ingress ComponentStatus `json:"ingress,omitempty"`
+	Retry   ComponentStatus `json:"retry,omitempty"`
+}
+
+// ComponentStatus reports the state of a single component of a BrokerCell.
+type ComponentStatus struct {
+	// Replicas is the number of replicas desired for the component's
+	// deployment.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of ready replicas of the component's
+	// deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// TargetsConfigGeneration is the lowest generation of the broker targets
+	// config loaded by the component's pods, i.e. the generation served by the
+	// whole deployment.
+	// +optional
+	TargetsConfigGeneration int64 `json:"targetsConfigGeneration,omitempty"`
+
+	// StaleReplicas is the number of the component's pods reporting that they
+	// failed to sync the broker targets config for longer than their max
+	// stale duration.
+	// +optional
+	StaleReplicas int32 `json:"staleReplicas,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -19,9 +19,13 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	resourceutil "github.com/google/knative-gcp/pkg/utils/resource"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 )
 
@@ -38,6 +42,11 @@ func (bcs *BrokerCellSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 	if bcs.Components.Ingress != nil {
 		fieldErrors = bcs.Components.Ingress.ValidateResourceRequirementSpecification(fieldErrors, "components.ingress")
+		if bcs.Components.Ingress.BacklogScaling != nil {
+			invalidValueError := apis.ErrDisallowedFields("backlogScaling").ViaField("components.ingress")
+			invalidValueError.Details = "The ingress component does not pull from Pub/Sub subscriptions"
+			fieldErrors = fieldErrors.Also(invalidValueError)
+		}
 	}
 	if bcs.Components.Retry != nil {
 		fieldErrors = bcs.Components.Retry.ValidateResourceRequirementSpecification(fieldErrors, "components.retry")
@@ -49,9 +58,67 @@ func (componentParams *ComponentParameters) ValidateResourceRequirementSpecifica
 	fieldErrors = componentParams.ValidateQuantityFormats(fieldErrors, componentPath)
 	fieldErrors = componentParams.ValidateResourceSpecification(fieldErrors, componentPath)
 	fieldErrors = componentParams.ValidateAutoscalingSpecification(fieldErrors, componentPath)
+	fieldErrors = componentParams.ValidateAvailabilitySpecification(fieldErrors, componentPath)
+	return fieldErrors
+}
+
+func (componentParams *ComponentParameters) ValidateAvailabilitySpecification(fieldErrors *apis.FieldError, componentPath string) *apis.FieldError {
+	if rs := componentParams.RolloutStrategy; rs != nil {
+		path := componentPath + ".rolloutStrategy"
+		fieldErrors = validateIntOrPercent(fieldErrors, rs.MaxSurge, "maxSurge", path)
+		fieldErrors = validateIntOrPercent(fieldErrors, rs.MaxUnavailable, "maxUnavailable", path)
+		// Kubernetes rejects rolling updates which can neither surge nor take
+		// down pods. An unset maxSurge defaults to 1.
+		if isZeroIntOrPercent(rs.MaxSurge) && isZeroIntOrPercent(rs.MaxUnavailable) && rs.MaxSurge != nil {
+			invalidValueError := apis.ErrInvalidValue(rs.MaxSurge.String(), "maxSurge").ViaField(path)
+			invalidValueError.Details = "maxSurge and maxUnavailable can not both be zero"
+			fieldErrors = fieldErrors.Also(invalidValueError)
+		}
+	}
+	if pdb := componentParams.PodDisruptionBudget; pdb != nil {
+		path := componentPath + ".podDisruptionBudget"
+		if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+			fieldErrors = fieldErrors.Also(apis.ErrMultipleOneOf("minAvailable", "maxUnavailable").ViaField(path))
+		}
+		fieldErrors = validateIntOrPercent(fieldErrors, pdb.MinAvailable, "minAvailable", path)
+		fieldErrors = validateIntOrPercent(fieldErrors, pdb.MaxUnavailable, "maxUnavailable", path)
+	}
 	return fieldErrors
 }
 
+// validateIntOrPercent checks that v, if set, is a non-negative integer or a
+// percentage between 0% and 100%.
+func validateIntOrPercent(fieldErrors *apis.FieldError, v *intstr.IntOrString, fieldName, path string) *apis.FieldError {
+	if v == nil {
+		return fieldErrors
+	}
+	switch v.Type {
+	case intstr.Int:
+		if v.IntVal < 0 {
+			fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(v.IntVal, 0, math.MaxInt32, fieldName).ViaField(path))
+		}
+	case intstr.String:
+		percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+		if !strings.HasSuffix(v.StrVal, "%") || err != nil || percent < 0 || percent > 100 {
+			invalidValueError := apis.ErrInvalidValue(v.StrVal, fieldName).ViaField(path)
+			invalidValueError.Details = "must be a non-negative integer or a percentage between 0% and 100%"
+			fieldErrors = fieldErrors.Also(invalidValueError)
+		}
+	}
+	return fieldErrors
+}
+
+// isZeroIntOrPercent returns true if v is unset, 0 or 0%.
+func isZeroIntOrPercent(v *intstr.IntOrString) bool {
+	if v == nil {
+		return true
+	}
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+	return v.StrVal == "0%"
+}
+
 func (componentParams *ComponentParameters) ValidateResourceSpecification(fieldErrors *apis.FieldError, componentPath string) *apis.FieldError {
 	// Make sure the CPU limit is not lower than what's requested (when both are set)
 	if componentParams.CPURequest != "" && componentParams.CPULimit != "" {
@@ -102,6 +169,29 @@ func (componentParams *ComponentParameters) ValidateAutoscalingSpecification(fie
 		invalidValueError.Details = "minReplicas value can not exceed the value of maxReplicas"
 		fieldErrors = fieldErrors.Also(invalidValueError)
 	}
+	if componentParams.BacklogScaling != nil {
+		fieldErrors = componentParams.BacklogScaling.validate(fieldErrors, componentPath+".backlogScaling")
+	}
+	return fieldErrors
+}
+
+func (bs *BacklogScalingSpec) validate(fieldErrors *apis.FieldError, path string) *apis.FieldError {
+	switch bs.Class {
+	case "", BacklogScalingExternalMetrics, BacklogScalingKeda:
+	default:
+		invalidValueError := apis.ErrInvalidValue(bs.Class, "class").ViaField(path)
+		invalidValueError.Details = fmt.Sprintf("class must be one of %q or %q", BacklogScalingExternalMetrics, BacklogScalingKeda)
+		fieldErrors = fieldErrors.Also(invalidValueError)
+	}
+	if bs.TargetBacklog != nil && *bs.TargetBacklog < 1 {
+		fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(*bs.TargetBacklog, 1, math.MaxInt64, "targetBacklog").ViaField(path))
+	}
+	if bs.PollingInterval != nil && *bs.PollingInterval < 1 {
+		fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(*bs.PollingInterval, 1, math.MaxInt32, "pollingInterval").ViaField(path))
+	}
+	if bs.CooldownPeriod != nil && *bs.CooldownPeriod < 0 {
+		fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(*bs.CooldownPeriod, 0, math.MaxInt32, "cooldownPeriod").ViaField(path))
+	}
 	return fieldErrors
 }
 
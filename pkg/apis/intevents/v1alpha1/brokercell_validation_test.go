@@ -18,9 +18,11 @@ package v1alpha1
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/ptr"
 )
@@ -168,6 +170,115 @@ func TestBrokerCell_Validate(t *testing.T) {
 
 			}(),
 		},
+		{
+			name: "Backlog scaling is not supported by ingress",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithBacklogScaling := MakeDefaultBrokerCellSpec()
+					brokerCellWithBacklogScaling.Components.Ingress.BacklogScaling = &BacklogScalingSpec{}
+					return brokerCellWithBacklogScaling
+				}()),
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrDisallowedFields("spec.components.ingress.backlogScaling")
+				fe.Details = "The ingress component does not pull from Pub/Sub subscriptions"
+				return fe
+			}(),
+		},
+		{
+			name: "Invalid backlog scaling",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithBacklogScaling := MakeDefaultBrokerCellSpec()
+					brokerCellWithBacklogScaling.Components.Fanout.BacklogScaling = &BacklogScalingSpec{
+						Class:         "unknown",
+						TargetBacklog: ptr.Int64(0),
+					}
+					return brokerCellWithBacklogScaling
+				}()),
+			},
+			want: func() *apis.FieldError {
+				var fieldErrors *apis.FieldError
+				fe := apis.ErrInvalidValue("unknown", "spec.components.fanout.backlogScaling.class")
+				fe.Details = `class must be one of "external" or "keda"`
+				fieldErrors = fieldErrors.Also(fe)
+				fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(0, 1, math.MaxInt64, "spec.components.fanout.backlogScaling.targetBacklog"))
+				return fieldErrors
+			}(),
+		},
+		{
+			name: "Valid backlog scaling",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithBacklogScaling := MakeDefaultBrokerCellSpec()
+					brokerCellWithBacklogScaling.Components.Retry.BacklogScaling = &BacklogScalingSpec{
+						Class:           BacklogScalingKeda,
+						TargetBacklog:   ptr.Int64(5),
+						PollingInterval: ptr.Int32(30),
+						CooldownPeriod:  ptr.Int32(0),
+					}
+					return brokerCellWithBacklogScaling
+				}()),
+			},
+			want: nil,
+		},
+		{
+			name: "Invalid rollout strategy",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithRolloutStrategy := MakeDefaultBrokerCellSpec()
+					brokerCellWithRolloutStrategy.Components.Ingress.RolloutStrategy = &RolloutStrategySpec{
+						MaxSurge:       intOrStringPtr(intstr.FromInt(0)),
+						MaxUnavailable: intOrStringPtr(intstr.FromString("0%")),
+					}
+					return brokerCellWithRolloutStrategy
+				}()),
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("0", "spec.components.ingress.rolloutStrategy.maxSurge")
+				fe.Details = "maxSurge and maxUnavailable can not both be zero"
+				return fe
+			}(),
+		},
+		{
+			name: "Invalid pod disruption budget",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithPDB := MakeDefaultBrokerCellSpec()
+					brokerCellWithPDB.Components.Fanout.PodDisruptionBudget = &PodDisruptionBudgetSpec{
+						MinAvailable:   intOrStringPtr(intstr.FromInt(-1)),
+						MaxUnavailable: intOrStringPtr(intstr.FromString("120%")),
+					}
+					return brokerCellWithPDB
+				}()),
+			},
+			want: func() *apis.FieldError {
+				var fieldErrors *apis.FieldError
+				fieldErrors = fieldErrors.Also(apis.ErrMultipleOneOf("spec.components.fanout.podDisruptionBudget.minAvailable", "spec.components.fanout.podDisruptionBudget.maxUnavailable"))
+				fieldErrors = fieldErrors.Also(apis.ErrOutOfBoundsValue(-1, 0, math.MaxInt32, "spec.components.fanout.podDisruptionBudget.minAvailable"))
+				fe := apis.ErrInvalidValue("120%", "spec.components.fanout.podDisruptionBudget.maxUnavailable")
+				fe.Details = "must be a non-negative integer or a percentage between 0% and 100%"
+				fieldErrors = fieldErrors.Also(fe)
+				return fieldErrors
+			}(),
+		},
+		{
+			name: "Valid rollout strategy and pod disruption budget",
+			brokerCell: BrokerCell{
+				Spec: (func() BrokerCellSpec {
+					brokerCellWithAvailability := MakeDefaultBrokerCellSpec()
+					brokerCellWithAvailability.Components.Retry.RolloutStrategy = &RolloutStrategySpec{
+						MaxSurge:       intOrStringPtr(intstr.FromString("25%")),
+						MaxUnavailable: intOrStringPtr(intstr.FromInt(0)),
+					}
+					brokerCellWithAvailability.Components.Retry.PodDisruptionBudget = &PodDisruptionBudgetSpec{
+						MinAvailable: intOrStringPtr(intstr.FromString("50%")),
+					}
+					return brokerCellWithAvailability
+				}()),
+			},
+			want: nil,
+		},
 		{
 			name: "Empty quantities are supported",
 			brokerCell: BrokerCell{
@@ -191,3 +302,7 @@ func TestBrokerCell_Validate(t *testing.T) {
 		})
 	}
 }
+
+func intOrStringPtr(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
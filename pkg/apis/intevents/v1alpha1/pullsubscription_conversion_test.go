@@ -174,10 +174,9 @@ func TestPullSubscriptionConversionBetweenV1(t *testing.T) {
 				cs := apis.NewLivingConditionSet()
 				cs.Manage(&got.Status).ClearCondition(convert.DeprecatedType)
 				ignoreUsername := cmp.AllowUnexported(url.Userinfo{})
-				// IdentityStatus.ServiceAccountName and PullSubscriptionSpec.Mode only exists in v1alpha1 and v1beta1, they don't exist in v1.
-				// So this won't be a round trip, they will be silently removed.
+				// IdentityStatus.ServiceAccountName only exists in v1alpha1 and v1beta1, it doesn't exist in v1.
+				// So this won't be a round trip, it will be silently removed.
 				in.Status.ServiceAccountName = ""
-				in.Spec.Mode = ""
 				if diff := cmp.Diff(in, got, ignoreUsername); diff != "" {
 					t.Errorf("roundtrip (-want, +got) = %v", diff)
 				}
@@ -23,10 +23,42 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	apis "knative.dev/pkg/apis"
 	v1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BacklogScalingSpec) DeepCopyInto(out *BacklogScalingSpec) {
+	*out = *in
+	if in.TargetBacklog != nil {
+		in, out := &in.TargetBacklog, &out.TargetBacklog
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BacklogScalingSpec.
+func (in *BacklogScalingSpec) DeepCopy() *BacklogScalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BacklogScalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCell) DeepCopyInto(out *BrokerCell) {
 	*out = *in
@@ -109,6 +141,7 @@ func (in *BrokerCellSpec) DeepCopy() *BrokerCellSpec {
 func (in *BrokerCellStatus) DeepCopyInto(out *BrokerCellStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	out.Components = in.Components
 	return
 }
 
@@ -145,6 +178,21 @@ func (in *ComponentParameters) DeepCopyInto(out *ComponentParameters) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.BacklogScaling != nil {
+		in, out := &in.BacklogScaling, &out.BacklogScaling
+		*out = new(BacklogScalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -158,6 +206,22 @@ func (in *ComponentParameters) DeepCopy() *ComponentParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentsParametersSpec) DeepCopyInto(out *ComponentsParametersSpec) {
 	*out = *in
@@ -189,6 +253,51 @@ func (in *ComponentsParametersSpec) DeepCopy() *ComponentsParametersSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentsStatus) DeepCopyInto(out *ComponentsStatus) {
+	*out = *in
+	out.Fanout = in.Fanout
+	out.Ingress = in.Ingress
+	out.Retry = in.Retry
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsStatus.
+func (in *ComponentsStatus) DeepCopy() *ComponentsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullSubscription) DeepCopyInto(out *PullSubscription) {
 	*out = *in
@@ -322,6 +431,32 @@ func (in *ResourceSpecification) DeepCopy() *ResourceSpecification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategySpec) DeepCopyInto(out *RolloutStrategySpec) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategySpec.
+func (in *RolloutStrategySpec) DeepCopy() *RolloutStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemResource) DeepCopyInto(out *SystemResource) {
 	*out = *in
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/knative-gcp/pkg/apis/convert"
 	v1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
@@ -31,7 +32,6 @@ import (
 func (source *PullSubscription) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	switch sink := to.(type) {
 	case *v1.PullSubscription:
-		// Since we remove Mode from PullSubscriptionSpec in v1, we silently remove it here.
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec.PubSubSpec = convert.ToV1PubSubSpec(source.Spec.PubSubSpec)
 		sink.Spec.Topic = source.Spec.Topic
@@ -39,6 +39,11 @@ func (source *PullSubscription) ConvertTo(ctx context.Context, to apis.Convertib
 		sink.Spec.RetainAckedMessages = source.Spec.RetainAckedMessages
 		sink.Spec.RetentionDuration = source.Spec.RetentionDuration
 		sink.Spec.Transformer = source.Spec.Transformer
+		if mode, err := convertToV1ModeType(source.Spec.Mode); err != nil {
+			return err
+		} else {
+			sink.Spec.Mode = mode
+		}
 		sink.Spec.AdapterType = source.Spec.AdapterType
 		sink.Status.PubSubStatus = convert.ToV1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.TransformerURI = source.Status.TransformerURI
@@ -62,8 +67,11 @@ func (sink *PullSubscription) ConvertFrom(ctx context.Context, from apis.Convert
 		sink.Spec.RetainAckedMessages = source.Spec.RetainAckedMessages
 		sink.Spec.RetentionDuration = source.Spec.RetentionDuration
 		sink.Spec.Transformer = source.Spec.Transformer
-		// Since we remove Mode from PullSubscriptionSpec in v1, we treat it as an empty string.
-		sink.Spec.Mode = ""
+		if mode, err := convertFromV1ModeType(source.Spec.Mode); err != nil {
+			return err
+		} else {
+			sink.Spec.Mode = mode
+		}
 		sink.Spec.AdapterType = source.Spec.AdapterType
 		sink.Status.PubSubStatus = convert.FromV1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.TransformerURI = source.Status.TransformerURI
@@ -73,3 +81,33 @@ func (sink *PullSubscription) ConvertFrom(ctx context.Context, from apis.Convert
 		return apis.ConvertFromViaProxy(ctx, source, &v1.PullSubscription{}, sink)
 	}
 }
+
+func convertToV1ModeType(from ModeType) (v1.ModeType, error) {
+	switch from {
+	case ModeCloudEventsBinary:
+		return v1.ModeCloudEventsBinary, nil
+	case ModeCloudEventsStructured:
+		return v1.ModeCloudEventsStructured, nil
+	case ModePushCompatible:
+		return v1.ModePushCompatible, nil
+	case "":
+		return "", nil
+	default:
+		return "unknown", fmt.Errorf("unknown ModeType %v", from)
+	}
+}
+
+func convertFromV1ModeType(from v1.ModeType) (ModeType, error) {
+	switch from {
+	case v1.ModeCloudEventsBinary:
+		return ModeCloudEventsBinary, nil
+	case v1.ModeCloudEventsStructured:
+		return ModeCloudEventsStructured, nil
+	case v1.ModePushCompatible:
+		return ModePushCompatible, nil
+	case "":
+		return "", nil
+	default:
+		return "unknown", fmt.Errorf("unknown ModeType %v", from)
+	}
+}
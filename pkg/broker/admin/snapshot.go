@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admin serves a debug snapshot of the broker targets config and
+// handlers loaded by a data plane pod.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+)
+
+// SnapshotPath is the path of the config snapshot endpoint. It is served next
+// to the health check endpoint of the data plane pods. It has three segments
+// so that it can't collide with the /<namespace>/<broker> ingress paths.
+const SnapshotPath = "/debug/config/snapshot"
+
+// Token is the bearer token authorizing requests to the admin endpoints. The
+// endpoints are disabled when the token is empty.
+type Token string
+
+// HandlerStatus is the status of a single handler of a sync pool.
+type HandlerStatus struct {
+	Alive bool `json:"alive"`
+}
+
+// Snapshot is the config state of a data plane pod.
+type Snapshot struct {
+	// Targets is the loaded targets config in JSON.
+	Targets json.RawMessage `json:"targets"`
+	// Generation is the generation of the loaded targets config. It's 0 if
+	// the generation isn't tracked.
+	Generation int64 `json:"generation,omitempty"`
+	// Handlers are the live handlers keyed by broker or trigger key. It's
+	// empty for pods that don't run handlers.
+	Handlers map[string]HandlerStatus `json:"handlers,omitempty"`
+	// LastSyncTime is the last time the targets config was loaded, or the
+	// last time the handlers were synced with it if its generation isn't
+	// tracked.
+	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
+}
+
+// TargetsJSON returns the JSON format of the given targets.
+func TargetsJSON(targets config.ReadonlyTargets) (json.RawMessage, error) {
+	b, err := targets.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var tc config.TargetsConfig
+	if err := proto.Unmarshal(b, &tc); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(&tc)
+}
+
+// ServeSnapshot writes the snapshot returned by snapshot as JSON if the
+// request carries the given bearer token.
+func ServeSnapshot(w http.ResponseWriter, req *http.Request, token Token, snapshot func() (*Snapshot, error)) {
+	if token == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !authorized(req, token) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s, err := snapshot()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+func authorized(req *http.Request, token Token) bool {
+	const prefix = "Bearer "
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, prefix)), []byte(token)) == 1
+}
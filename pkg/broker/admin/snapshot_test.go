@@ -0,0 +1,106 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServeSnapshot(t *testing.T) {
+	snapshot := func() (*Snapshot, error) {
+		return &Snapshot{
+			Targets:  []byte(`{}`),
+			Handlers: map[string]HandlerStatus{"ns/broker": {Alive: true}},
+		}, nil
+	}
+	tests := []struct {
+		name     string
+		token    Token
+		method   string
+		auth     string
+		snapshot func() (*Snapshot, error)
+		wantCode int
+		wantBody string
+	}{{
+		name:     "disabled",
+		method:   http.MethodGet,
+		auth:     "Bearer ",
+		snapshot: snapshot,
+		wantCode: http.StatusNotFound,
+	}, {
+		name:     "wrong method",
+		token:    "secret",
+		method:   http.MethodPost,
+		auth:     "Bearer secret",
+		snapshot: snapshot,
+		wantCode: http.StatusMethodNotAllowed,
+	}, {
+		name:     "no token",
+		token:    "secret",
+		method:   http.MethodGet,
+		snapshot: snapshot,
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:     "wrong token",
+		token:    "secret",
+		method:   http.MethodGet,
+		auth:     "Bearer other",
+		snapshot: snapshot,
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:   "snapshot error",
+		token:  "secret",
+		method: http.MethodGet,
+		auth:   "Bearer secret",
+		snapshot: func() (*Snapshot, error) {
+			return nil, errors.New("inspect failed")
+		},
+		wantCode: http.StatusInternalServerError,
+		wantBody: "inspect failed\n",
+	}, {
+		name:     "success",
+		token:    "secret",
+		method:   http.MethodGet,
+		auth:     "Bearer secret",
+		snapshot: snapshot,
+		wantCode: http.StatusOK,
+		wantBody: `{"targets":{},"handlers":{"ns/broker":{"alive":true}}}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, SnapshotPath, nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			w := httptest.NewRecorder()
+			ServeSnapshot(w, req, tc.token, tc.snapshot)
+			if w.Code != tc.wantCode {
+				t.Errorf("Status code got=%v, want=%v", w.Code, tc.wantCode)
+			}
+			if tc.wantBody != "" {
+				if diff := cmp.Diff(tc.wantBody, w.Body.String()); diff != "" {
+					t.Errorf("Body (-want,+got): %v", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FullTopicName returns the fully qualified name of a topic,
+// e.g. projects/<project>/topics/<id>.
+func FullTopicName(project, id string) string {
+	return fmt.Sprintf("projects/%s/topics/%s", project, id)
+}
+
+// FullSubscriptionName returns the fully qualified name of a subscription,
+// e.g. projects/<project>/subscriptions/<id>.
+func FullSubscriptionName(project, id string) string {
+	return fmt.Sprintf("projects/%s/subscriptions/%s", project, id)
+}
+
+// ParseQueueName splits a queue topic or subscription name into its project
+// and ID. A plain ID (without the projects/<project>/ prefix) is returned with
+// an empty project, meaning the data plane's own project should be used.
+func ParseQueueName(name string) (project, id string) {
+	parts := strings.Split(name, "/")
+	if len(parts) == 4 && parts[0] == "projects" && (parts[2] == "topics" || parts[2] == "subscriptions") {
+		return parts[1], parts[3]
+	}
+	return "", name
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestParseQueueName(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantProject string
+		wantID      string
+	}{{
+		name:   "plain-id",
+		wantID: "plain-id",
+	}, {
+		name:        FullTopicName("tenant-project", "topic-id"),
+		wantProject: "tenant-project",
+		wantID:      "topic-id",
+	}, {
+		name:        FullSubscriptionName("tenant-project", "sub-id"),
+		wantProject: "tenant-project",
+		wantID:      "sub-id",
+	}, {
+		name:   "projects/p/snapshots/id",
+		wantID: "projects/p/snapshots/id",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, id := ParseQueueName(tt.name)
+			if project != tt.wantProject || id != tt.wantID {
+				t.Errorf("ParseQueueName(%q) = (%q, %q), want (%q, %q)", tt.name, project, id, tt.wantProject, tt.wantID)
+			}
+		})
+	}
+}
@@ -94,7 +94,12 @@ type Queue struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Topic        string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The topic ID, or the fully qualified topic name
+	// (projects/<project>/topics/<id>) if the topic lives in a project
+	// other than the data plane's.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The subscription ID, or the fully qualified subscription name
+	// (projects/<project>/subscriptions/<id>).
 	Subscription string `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
 	State        State  `protobuf:"varint,3,opt,name=state,proto3,enum=config.State" json:"state,omitempty"`
 }
@@ -33,3 +33,11 @@ func WithNotifyChan(ch chan<- struct{}) Option {
 		t.notifyChan = ch
 	}
 }
+
+// WithGeneration is the option to record the generation of the loaded
+// targets config and the time it was loaded in the given Generation.
+func WithGeneration(gen *Generation) Option {
+	return func(t *Targets) {
+		t.generation = gen
+	}
+}
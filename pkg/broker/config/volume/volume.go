@@ -20,7 +20,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/google/knative-gcp/pkg/broker/config"
@@ -28,7 +33,13 @@ import (
 )
 
 const (
-	defaultPath = "/var/run/cloud-run-events/broker/targets"
+	// DefaultPath is the default path of the targets config file.
+	DefaultPath = "/var/run/cloud-run-events/broker/targets"
+
+	// GenerationFileName is the name of the file holding the generation of
+	// the targets config. It lives in the same directory as the targets
+	// config file, as both are keys of the same configmap.
+	GenerationFileName = "generation"
 )
 
 // Targets implements config.ReadonlyTargets with data
@@ -39,6 +50,36 @@ type Targets struct {
 	config.CachedTargets
 	path       string
 	notifyChan chan<- struct{}
+	generation *Generation
+}
+
+// Generation holds the generation of the targets config loaded by Targets.
+// It is recorded when the targets are loaded, so that it always matches the
+// loaded targets rather than the current content of the generation file.
+type Generation struct {
+	value int64
+	// syncTime is the time the targets were last loaded in Unix nanoseconds.
+	syncTime int64
+}
+
+// Load returns the generation of the loaded targets config.
+func (g *Generation) Load() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+// SyncTime returns the last time the targets config was loaded. It's the zero
+// time if the targets config was never loaded.
+func (g *Generation) SyncTime() time.Time {
+	nanos := atomic.LoadInt64(&g.syncTime)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+func (g *Generation) store(gen int64) {
+	atomic.StoreInt64(&g.value, gen)
+	atomic.StoreInt64(&g.syncTime, time.Now().UnixNano())
 }
 
 var _ config.ReadonlyTargets = (*Targets)(nil)
@@ -47,7 +88,7 @@ var _ config.ReadonlyTargets = (*Targets)(nil)
 func NewTargetsFromFile(opts ...Option) (config.ReadonlyTargets, error) {
 	t := &Targets{
 		CachedTargets: config.CachedTargets{},
-		path:          defaultPath,
+		path:          DefaultPath,
 	}
 
 	for _, opt := range opts {
@@ -115,7 +156,14 @@ func (t *Targets) watchWith(watcher *fsnotify.Watcher) error {
 }
 
 func (t *Targets) sync() error {
-	b, err := t.readFile()
+	// Read the targets and the generation through the resolved path, so that
+	// both come from the same version of the configmap volume even if it is
+	// swapped in between.
+	path, err := filepath.EvalSymlinks(t.path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config file: %w", err)
+	}
+	b, err := ioutil.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
@@ -125,10 +173,36 @@ func (t *Targets) sync() error {
 		return fmt.Errorf("failed to unmarshal config file: %w", err)
 	}
 
+	var gen int64
+	if t.generation != nil {
+		if gen, err = ReadGeneration(path); err != nil {
+			return err
+		}
+	}
+
 	t.Store(&val)
+	// Record the generation after the targets are stored so that it is never
+	// ahead of them.
+	if t.generation != nil {
+		t.generation.store(gen)
+	}
 	return nil
 }
 
-func (t *Targets) readFile() ([]byte, error) {
-	return ioutil.ReadFile(t.path)
+// ReadGeneration reads the generation of the targets config at the given
+// path. It returns 0 if the generation file doesn't exist, which is the case
+// for configmaps written by controllers that don't track the generation.
+func ReadGeneration(targetsPath string) (int64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(filepath.Dir(targetsPath), GenerationFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read generation file: %w", err)
+	}
+	gen, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse generation: %w", err)
+	}
+	return gen, nil
 }
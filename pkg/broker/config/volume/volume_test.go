@@ -19,6 +19,7 @@ package volume
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -196,3 +197,92 @@ func atomicWriteFile(t *testing.T, file string, bytes []byte) {
 		t.Fatalf("unexpected error from renaming temp file: %v", err)
 	}
 }
+
+func TestReadGeneration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configtest-*")
+	if err != nil {
+		t.Fatalf("unexpected error from creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	targetsPath := filepath.Join(dir, "targets")
+
+	gen, err := ReadGeneration(targetsPath)
+	if err != nil {
+		t.Fatalf("unexpected error from ReadGeneration without generation file: %v", err)
+	}
+	if gen != 0 {
+		t.Errorf("ReadGeneration without generation file got=%d, want=0", gen)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, GenerationFileName), []byte("42"), 0644); err != nil {
+		t.Fatalf("unexpected error from writing generation file: %v", err)
+	}
+	gen, err = ReadGeneration(targetsPath)
+	if err != nil {
+		t.Fatalf("unexpected error from ReadGeneration: %v", err)
+	}
+	if gen != 42 {
+		t.Errorf("ReadGeneration got=%d, want=42", gen)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, GenerationFileName), []byte("not a number"), 0644); err != nil {
+		t.Fatalf("unexpected error from writing generation file: %v", err)
+	}
+	if _, err := ReadGeneration(targetsPath); err == nil {
+		t.Error("ReadGeneration with invalid generation got nil error")
+	}
+}
+
+func TestSyncGeneration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configtest-*")
+	if err != nil {
+		t.Fatalf("unexpected error from creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	targetsPath := filepath.Join(dir, "targets")
+	b, _ := proto.Marshal(&config.TargetsConfig{})
+	if err := ioutil.WriteFile(targetsPath, b, 0644); err != nil {
+		t.Fatalf("unexpected error from writing config file: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, GenerationFileName), []byte("3"), 0644); err != nil {
+		t.Fatalf("unexpected error from writing generation file: %v", err)
+	}
+
+	ch := make(chan struct{}, 1)
+	gen := &Generation{}
+	if !gen.SyncTime().IsZero() {
+		t.Errorf("sync time before the targets are loaded got=%v, want zero", gen.SyncTime())
+	}
+	before := time.Now()
+	if _, err := NewTargetsFromFile(WithPath(targetsPath), WithNotifyChan(ch), WithGeneration(gen)); err != nil {
+		t.Fatalf("unexpected error from NewTargetsFromFile: %v", err)
+	}
+	if got := gen.Load(); got != 3 {
+		t.Errorf("initial generation got=%d, want=3", got)
+	}
+	initialSyncTime := gen.SyncTime()
+	if initialSyncTime.Before(before) {
+		t.Errorf("initial sync time got=%v, want not before %v", initialSyncTime, before)
+	}
+
+	// The generation file alone changing doesn't change the loaded generation.
+	if err := ioutil.WriteFile(filepath.Join(dir, GenerationFileName), []byte("4"), 0644); err != nil {
+		t.Fatalf("unexpected error from writing generation file: %v", err)
+	}
+	if got := gen.Load(); got != 3 {
+		t.Errorf("generation before the targets are reloaded got=%d, want=3", got)
+	}
+
+	atomicWriteFile(t, targetsPath, b)
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for the notification")
+	}
+	if got := gen.Load(); got != 4 {
+		t.Errorf("updated generation got=%d, want=4", got)
+	}
+	if got := gen.SyncTime(); got.Before(initialSyncTime) {
+		t.Errorf("updated sync time got=%v, want not before %v", got, initialSyncTime)
+	}
+}
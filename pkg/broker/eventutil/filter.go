@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventutil
+
+import (
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// FilterMismatch describes the first filter attribute an event doesn't match.
+type FilterMismatch struct {
+	// Attribute is the name of the filter attribute.
+	Attribute string
+	// Filter is the value of the filter attribute.
+	Filter string
+	// Missing is true if the attribute is not set on the event.
+	Missing bool
+	// Received is the value of the attribute on the event.
+	Received interface{}
+}
+
+// MatchFilterAttributes matches the event against the filter attributes of a
+// Trigger: every attribute must be set on the event, and be equal to the
+// filter value unless the latter is empty. It returns nil if the event
+// matches, and the first mismatch otherwise.
+func MatchFilterAttributes(attrs map[string]string, event *event.Event) *FilterMismatch {
+	if len(attrs) == 0 {
+		return nil
+	}
+	// Set standard context attributes. The attributes available may not be
+	// exactly the same as the attributes defined in the current version of the
+	// CloudEvents spec.
+	ce := map[string]interface{}{
+		"specversion":     event.SpecVersion(),
+		"type":            event.Type(),
+		"source":          event.Source(),
+		"subject":         event.Subject(),
+		"id":              event.ID(),
+		"time":            event.Time().String(),
+		"schemaurl":       event.DataSchema(),
+		"datacontenttype": event.DataContentType(),
+		"datamediatype":   event.DataMediaType(),
+		// TODO: use data_base64 when SDK supports it.
+		"datacontentencoding": event.DeprecatedDataContentEncoding(),
+	}
+	for k, v := range event.Extensions() {
+		ce[k] = v
+	}
+
+	for k, v := range attrs {
+		value, ok := ce[k]
+		// If the attribute does not exist in the event, it doesn't match.
+		if !ok {
+			return &FilterMismatch{Attribute: k, Filter: v, Missing: true}
+		}
+		// If the attribute is not set to any and is different than the one from the event, it doesn't match.
+		if v != "" && v != value {
+			return &FilterMismatch{Attribute: k, Filter: v, Received: value}
+		}
+	}
+	return nil
+}
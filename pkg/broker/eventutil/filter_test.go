@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventutil
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMatchFilterAttributes(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("id")
+	e.SetType("example.type")
+	e.SetSource("example/uri")
+	e.SetExtension("custom", "foo")
+
+	tests := []struct {
+		name  string
+		attrs map[string]string
+		want  *FilterMismatch
+	}{{
+		name: "no filter",
+	}, {
+		name:  "matching attributes",
+		attrs: map[string]string{"type": "example.type", "custom": "foo"},
+	}, {
+		name:  "any value",
+		attrs: map[string]string{"custom": ""},
+	}, {
+		name:  "unset context attribute",
+		attrs: map[string]string{"datacontentencoding": ""},
+	}, {
+		name:  "non-matching attribute",
+		attrs: map[string]string{"custom": "bar"},
+		want:  &FilterMismatch{Attribute: "custom", Filter: "bar", Received: "foo"},
+	}, {
+		name:  "missing attribute",
+		attrs: map[string]string{"other": ""},
+		want:  &FilterMismatch{Attribute: "other", Missing: true},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := MatchFilterAttributes(test.attrs, &e)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("MatchFilterAttributes (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/knative-gcp/pkg/logging"
 	"go.uber.org/zap"
 
+	"github.com/google/knative-gcp/pkg/broker/admin"
 	"github.com/google/knative-gcp/pkg/broker/config"
 	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	"github.com/google/knative-gcp/pkg/broker/handler/processors"
@@ -59,7 +60,8 @@ type FanoutPool struct {
 
 type fanoutHandlerCache struct {
 	Handler
-	b *config.Broker
+	b       *config.Broker
+	deliver *deliver.Processor
 }
 
 // If somehow the existing handler's setting has deviated from the current broker config,
@@ -132,10 +134,19 @@ func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 		return true
 	})
 
+	// The retry topics in use are only computed once per sync, and only if a
+	// handler is kept.
+	var retryTopicsInUse map[string]bool
 	p.targets.RangeBrokers(func(b *config.Broker) bool {
 		if value, ok := p.pool.Load(b.Key()); ok {
-			// Skip if we don't need to renew the handler.
-			if !value.(*fanoutHandlerCache).shouldRenew(b) {
+			// Skip if we don't need to renew the handler. The handler
+			// keeps running across trigger changes, so release the retry
+			// topics of the triggers that were removed.
+			if hc := value.(*fanoutHandlerCache); !hc.shouldRenew(b) {
+				if retryTopicsInUse == nil {
+					retryTopicsInUse = deliver.RetryTopicsInUse(p.targets)
+				}
+				hc.deliver.PruneRetryTopics(retryTopicsInUse)
 				return true
 			}
 			// Stop and clean up the old handler before we start a new one.
@@ -149,32 +160,37 @@ func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 			return true
 		}
 
-		sub := p.pubsubClient.Subscription(b.DecoupleQueue.Subscription)
+		sub := queueSubscription(p.pubsubClient, b.DecoupleQueue.Subscription)
 		sub.ReceiveSettings = p.options.PubsubReceiveSettings
 
+		deliverProcessor := &deliver.Processor{
+			DeliverClient:      p.deliverClient,
+			Targets:            p.targets,
+			RetryOnFailure:     true,
+			DeliverRetryClient: p.deliverRetryClient,
+			RetryPubsubClient:  p.pubsubClient,
+			DeliverTimeout:     p.options.DeliveryTimeout,
+			StatsReporter:      p.statsReporter,
+		}
 		h := NewHandler(
 			sub,
 			processors.ChainProcessors(
 				&fanout.Processor{MaxConcurrency: p.options.MaxConcurrencyPerEvent, Targets: p.targets},
 				&filter.Processor{Targets: p.targets},
-				&deliver.Processor{
-					DeliverClient:      p.deliverClient,
-					Targets:            p.targets,
-					RetryOnFailure:     true,
-					DeliverRetryClient: p.deliverRetryClient,
-					DeliverTimeout:     p.options.DeliveryTimeout,
-					StatsReporter:      p.statsReporter,
-				},
+				deliverProcessor,
 			),
 			p.options.TimeoutPerEvent,
 		)
 		hc := &fanoutHandlerCache{
 			Handler: *h,
 			b:       b,
+			deliver: deliverProcessor,
 		}
 
 		// Start the handler with broker key in context.
 		hc.Start(handlerctx.WithBrokerKey(ctx, b.Key()), func(err error) {
+			// The handler no longer processes events once it has stopped.
+			deliverProcessor.Close()
 			if err != nil {
 				logging.FromContext(ctx).Error("handler for broker has stopped with error", zap.String("broker", b.Key()), zap.Error(err))
 			} else {
@@ -188,3 +204,19 @@ func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 
 	return nil
 }
+
+// Targets returns the targets config the pool syncs with.
+func (p *FanoutPool) Targets() config.ReadonlyTargets {
+	return p.targets
+}
+
+// HandlerStatuses returns the status of the handlers in the pool keyed by
+// broker key.
+func (p *FanoutPool) HandlerStatuses() map[string]admin.HandlerStatus {
+	statuses := make(map[string]admin.HandlerStatus)
+	p.pool.Range(func(key, value interface{}) bool {
+		statuses[key.(string)] = admin.HandlerStatus{Alive: value.(*fanoutHandlerCache).IsAlive()}
+		return true
+	})
+	return statuses
+}
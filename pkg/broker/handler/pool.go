@@ -18,11 +18,17 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
+	"cloud.google.com/go/pubsub"
+	"github.com/google/knative-gcp/pkg/broker/admin"
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
+	"github.com/google/knative-gcp/pkg/broker/podstatus"
 	"github.com/google/knative-gcp/pkg/logging"
 	"go.uber.org/zap"
 )
@@ -36,11 +42,33 @@ type SyncPool interface {
 	SyncOnce(ctx context.Context) error
 }
 
+// inspectablePool is a SyncPool that exposes the targets config and the
+// handlers it has loaded for debugging.
+type inspectablePool interface {
+	Targets() config.ReadonlyTargets
+	HandlerStatuses() map[string]admin.HandlerStatus
+}
+
+// ConfigStatusReporter reports the state of the targets config loaded by a
+// data plane pod.
+type ConfigStatusReporter interface {
+	// ReportConfigStatus is called after every successful sync and
+	// periodically. stale is true when the pool hasn't been synced within the
+	// max stale duration.
+	ReportConfigStatus(ctx context.Context, stale bool)
+}
+
 type healthChecker struct {
 	mux              sync.RWMutex
 	lastReportTime   time.Time
 	maxStaleDuration time.Duration
 	port             int
+	reporters        []ConfigStatusReporter
+	pool             SyncPool
+	adminToken       admin.Token
+	// generation is the generation of the targets config loaded by the pool.
+	// It's nil if the generation isn't tracked.
+	generation *volume.Generation
 }
 
 func (c *healthChecker) reportHealth() {
@@ -55,8 +83,41 @@ func (c *healthChecker) lastTime() time.Time {
 	return c.lastReportTime
 }
 
+// isStale returns true if the pool hasn't been synced within maxStaleDuration.
+func (c *healthChecker) isStale() bool {
+	// Zero maxStaleDuration means infinite.
+	if c.maxStaleDuration == 0 {
+		return false
+	}
+	return time.Now().Sub(c.lastTime()) > c.maxStaleDuration
+}
+
+// reportConfigStatus reports the config status to all reporters.
+func (c *healthChecker) reportConfigStatus(ctx context.Context) {
+	stale := c.isStale()
+	for _, r := range c.reporters {
+		r.ReportConfigStatus(ctx, stale)
+	}
+}
+
 func (c *healthChecker) start(ctx context.Context) {
 	c.reportHealth()
+	if len(c.reporters) > 0 {
+		go func() {
+			// Report periodically in addition to after every sync, so that
+			// staleness is also reported.
+			ticker := time.NewTicker(podstatus.ConfigStatusReportPeriod)
+			defer ticker.Stop()
+			for {
+				c.reportConfigStatus(ctx)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
 	srv := &http.Server{
 		Addr:    ":" + strconv.Itoa(c.port),
 		Handler: c,
@@ -75,30 +136,65 @@ func (c *healthChecker) start(ctx context.Context) {
 	}
 }
 
+// snapshot returns the config snapshot of the pool. The generation and the
+// last sync time come from the loaded targets config the same as for the
+// ingress, or the last sync time is the last time the pool was synced if the
+// generation isn't tracked.
+func (c *healthChecker) snapshot() (*admin.Snapshot, error) {
+	p, ok := c.pool.(inspectablePool)
+	if !ok {
+		return nil, fmt.Errorf("sync pool %T can not be inspected", c.pool)
+	}
+	targets, err := admin.TargetsJSON(p.Targets())
+	if err != nil {
+		return nil, err
+	}
+	s := &admin.Snapshot{
+		Targets:  targets,
+		Handlers: p.HandlerStatuses(),
+	}
+	if c.generation != nil {
+		s.Generation = c.generation.Load()
+		if syncTime := c.generation.SyncTime(); !syncTime.IsZero() {
+			s.LastSyncTime = &syncTime
+		}
+	}
+	if s.LastSyncTime == nil {
+		lastSyncTime := c.lastTime()
+		s.LastSyncTime = &lastSyncTime
+	}
+	return s, nil
+}
+
 func (c *healthChecker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if req.URL.Path != "/healthz" {
-		w.WriteHeader(http.StatusNotFound)
+	if req.URL.Path == admin.SnapshotPath {
+		admin.ServeSnapshot(w, req, c.adminToken, c.snapshot)
 		return
 	}
-	// Zero maxStaleDuration means infinite.
-	if c.maxStaleDuration == 0 {
-		w.WriteHeader(http.StatusOK)
+	if req.URL.Path != "/healthz" {
+		w.WriteHeader(http.StatusNotFound)
 		return
 	}
-	if time.Now().Sub(c.lastTime()) > c.maxStaleDuration {
+	if c.isStale() {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
-// StartSyncPool starts the sync pool.
+// StartSyncPool starts the sync pool. The given reporters are notified of the
+// config status after every successful sync and periodically. Requests with
+// the admin token can get a snapshot of the pool from the health check port,
+// including the given generation of the targets config if it's not nil.
 func StartSyncPool(
 	ctx context.Context,
 	syncPool SyncPool,
 	syncSignal <-chan struct{},
 	maxStaleDuration time.Duration,
 	healthCheckPort int,
+	adminToken admin.Token,
+	generation *volume.Generation,
+	reporters ...ConfigStatusReporter,
 ) (SyncPool, error) {
 
 	if err := syncPool.SyncOnce(ctx); err != nil {
@@ -107,6 +203,10 @@ func StartSyncPool(
 	c := &healthChecker{
 		maxStaleDuration: maxStaleDuration,
 		port:             healthCheckPort,
+		reporters:        reporters,
+		pool:             syncPool,
+		adminToken:       adminToken,
+		generation:       generation,
 	}
 	go c.start(ctx)
 	if syncSignal != nil {
@@ -127,7 +227,18 @@ func watch(ctx context.Context, syncPool SyncPool, syncSignal <-chan struct{}, c
 			} else {
 				logging.FromContext(ctx).Debug("successfully synced handlers pool on watch signal")
 				c.reportHealth()
+				c.reportConfigStatus(ctx)
 			}
 		}
 	}
 }
+
+// queueSubscription returns the subscription of a queue. The subscription name
+// is either an ID in the client's project or a fully qualified name in another
+// project.
+func queueSubscription(client *pubsub.Client, name string) *pubsub.Subscription {
+	if project, id := config.ParseQueueName(name); project != "" {
+		return client.SubscriptionInProject(id, project)
+	}
+	return client.Subscription(name)
+}
@@ -18,13 +18,23 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/google/knative-gcp/pkg/broker/admin"
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
 )
 
 func TestSyncPool(t *testing.T) {
@@ -42,7 +52,7 @@ func TestSyncPool(t *testing.T) {
 			t.Fatalf("failed to get random free port: %v", err)
 		}
 
-		_, gotErr := StartSyncPool(ctx, syncPool, make(chan struct{}), 30*time.Second, p)
+		_, gotErr := StartSyncPool(ctx, syncPool, make(chan struct{}), 30*time.Second, p, "", nil)
 		if gotErr == nil {
 			t.Error("StartSyncPool got unexpected result")
 		}
@@ -65,7 +75,7 @@ func TestSyncPool(t *testing.T) {
 		}
 
 		ch := make(chan struct{})
-		if _, err := StartSyncPool(ctx, syncPool, ch, time.Second, p); err != nil {
+		if _, err := StartSyncPool(ctx, syncPool, ch, time.Second, p, "", nil); err != nil {
 			t.Errorf("StartSyncPool got unexpected error: %v", err)
 		}
 		syncPool.verifySyncOnceCalled(t)
@@ -80,6 +90,170 @@ func TestSyncPool(t *testing.T) {
 		time.Sleep(time.Second)
 		assertHealthCheckResult(t, p, false)
 	})
+
+	t.Run("Config status reported with StartSyncPool", func(t *testing.T) {
+		syncPool := &fakeSyncPool{
+			returnErr:  false,
+			syncCalled: make(chan struct{}, 1),
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		p, err := GetFreePort()
+		if err != nil {
+			t.Fatalf("failed to get random free port: %v", err)
+		}
+
+		reporter := &fakeConfigStatusReporter{reported: make(chan bool, 10)}
+		ch := make(chan struct{})
+		if _, err := StartSyncPool(ctx, syncPool, ch, time.Second, p, "", nil, reporter); err != nil {
+			t.Errorf("StartSyncPool got unexpected error: %v", err)
+		}
+		syncPool.verifySyncOnceCalled(t)
+		// Reported once on start.
+		reporter.verifyReported(t, false)
+
+		ch <- struct{}{}
+		syncPool.verifySyncOnceCalled(t)
+		reporter.verifyReported(t, false)
+	})
+}
+
+func TestSyncPoolConfigSnapshot(t *testing.T) {
+	syncPool := &fakeInspectableSyncPool{
+		fakeSyncPool: fakeSyncPool{syncCalled: make(chan struct{}, 1)},
+		targets:      memory.NewEmptyTargets(),
+		handlers:     map[string]admin.HandlerStatus{"ns/broker": {Alive: true}},
+	}
+	syncPool.targets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+		bm.SetState(config.State_READY)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := GetFreePort()
+	if err != nil {
+		t.Fatalf("failed to get random free port: %v", err)
+	}
+	generation := loadGeneration(t, 7)
+	if _, err := StartSyncPool(ctx, syncPool, nil, time.Minute, p, "secret", generation); err != nil {
+		t.Errorf("StartSyncPool got unexpected error: %v", err)
+	}
+	syncPool.verifySyncOnceCalled(t)
+	// Make sure the health checker is up.
+	time.Sleep(500 * time.Millisecond)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", p, admin.SnapshotPath)
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Failed to get config snapshot: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Unauthenticated config snapshot status code got=%v, want=%v", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("Failed to create config snapshot request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to get config snapshot: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Config snapshot status code got=%v, want=%v", resp.StatusCode, http.StatusOK)
+	}
+	var got admin.Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode config snapshot: %v", err)
+	}
+	if diff := cmp.Diff(syncPool.handlers, got.Handlers); diff != "" {
+		t.Errorf("Config snapshot handlers (-want,+got): %v", diff)
+	}
+	if got.Generation != 7 {
+		t.Errorf("Config snapshot generation got=%d, want=7", got.Generation)
+	}
+	if got.LastSyncTime == nil || !got.LastSyncTime.Equal(generation.SyncTime()) {
+		t.Errorf("Config snapshot last sync time got=%v, want=%v", got.LastSyncTime, generation.SyncTime())
+	}
+	wantTargets, err := admin.TargetsJSON(syncPool.targets)
+	if err != nil {
+		t.Fatalf("Failed to marshal targets: %v", err)
+	}
+	var want, gotTargets interface{}
+	json.Unmarshal(wantTargets, &want)
+	json.Unmarshal(got.Targets, &gotTargets)
+	if diff := cmp.Diff(want, gotTargets); diff != "" {
+		t.Errorf("Config snapshot targets (-want,+got): %v", diff)
+	}
+}
+
+func TestQueueSubscription(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, close := testPubsubClient(ctx, t, "test-project")
+	defer close()
+
+	tests := []struct {
+		name string
+		want string
+	}{{
+		name: "sub",
+		want: "projects/test-project/subscriptions/sub",
+	}, {
+		name: config.FullSubscriptionName("tenant-project", "sub"),
+		want: "projects/tenant-project/subscriptions/sub",
+	}}
+	for _, tt := range tests {
+		if got := queueSubscription(c, tt.name).String(); got != tt.want {
+			t.Errorf("queueSubscription(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// loadGeneration loads an empty targets config with the given generation and
+// returns the generation it was loaded with.
+func loadGeneration(t *testing.T, gen int64) *volume.Generation {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "pooltest-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	targetsPath := filepath.Join(dir, "targets")
+	if err := ioutil.WriteFile(targetsPath, nil, 0644); err != nil {
+		t.Fatalf("Failed to write targets config: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, volume.GenerationFileName), []byte(strconv.FormatInt(gen, 10)), 0644); err != nil {
+		t.Fatalf("Failed to write generation: %v", err)
+	}
+	generation := &volume.Generation{}
+	if _, err := volume.NewTargetsFromFile(volume.WithPath(targetsPath), volume.WithGeneration(generation)); err != nil {
+		t.Fatalf("Failed to load targets config: %v", err)
+	}
+	return generation
+}
+
+type fakeConfigStatusReporter struct {
+	reported chan bool
+}
+
+func (r *fakeConfigStatusReporter) ReportConfigStatus(_ context.Context, stale bool) {
+	r.reported <- stale
+}
+
+func (r *fakeConfigStatusReporter) verifyReported(t *testing.T, wantStale bool) {
+	t.Helper()
+	select {
+	case <-time.After(500 * time.Millisecond):
+		t.Errorf("ReportConfigStatus was not called before timeout")
+	case stale := <-r.reported:
+		if stale != wantStale {
+			t.Errorf("ReportConfigStatus stale got=%v, want=%v", stale, wantStale)
+		}
+	}
 }
 
 func assertHealthCheckResult(t *testing.T, port int, ok bool) {
@@ -124,6 +298,20 @@ func (p *fakeSyncPool) SyncOnce(ctx context.Context) error {
 	return nil
 }
 
+type fakeInspectableSyncPool struct {
+	fakeSyncPool
+	targets  config.Targets
+	handlers map[string]admin.HandlerStatus
+}
+
+func (p *fakeInspectableSyncPool) Targets() config.ReadonlyTargets {
+	return p.targets
+}
+
+func (p *fakeInspectableSyncPool) HandlerStatuses() map[string]admin.HandlerStatus {
+	return p.handlers
+}
+
 // GetFreePort asks a free open port.
 func GetFreePort() (int, error) {
 	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
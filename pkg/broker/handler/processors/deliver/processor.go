@@ -22,13 +22,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
+	"cloud.google.com/go/pubsub"
+	cepubsub "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/transformer"
 	ceclient "github.com/cloudevents/sdk-go/v2/client"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/google/knative-gcp/pkg/logging"
 	"go.opencensus.io/trace"
@@ -61,12 +65,23 @@ type Processor struct {
 	// to the retry topic.
 	DeliverRetryClient ceclient.Client
 
+	// RetryPubsubClient is used to publish events to retry topics that
+	// live in a project other than the data plane's, i.e. whose names are
+	// fully qualified.
+	RetryPubsubClient *pubsub.Client
+
 	// DeliverTimeout is the timeout applied to cancel delivery.
 	// If zero, not additional timeout is applied.
 	DeliverTimeout time.Duration
 
 	// StatsReporter is used to report delivery metrics.
 	StatsReporter *metrics.DeliveryReporter
+
+	// retryTopics caches the handles of the retry topics in other projects,
+	// keyed by fully qualified topic name, so that their publish bundlers are
+	// reused across events.
+	retryTopicsMux sync.Mutex
+	retryTopics    map[string]*retryTopic
 }
 
 var _ processors.Interface = (*Processor)(nil)
@@ -249,9 +264,118 @@ func (p *Processor) sendMsg(ctx context.Context, address string, msg binding.Mes
 }
 
 func (p *Processor) sendToRetryTopic(ctx context.Context, target *config.Target, event *event.Event) error {
+	if project, id := config.ParseQueueName(target.RetryQueue.Topic); project != "" {
+		return p.publishToRetryTopicInProject(ctx, project, id, event)
+	}
 	pctx := cecontext.WithTopic(ctx, target.RetryQueue.Topic)
 	if err := p.DeliverRetryClient.Send(pctx, *event); err != nil {
 		return fmt.Errorf("failed to send event to retry topic: %w", err)
 	}
 	return nil
 }
+
+// publishToRetryTopicInProject publishes the event to a retry topic in another
+// project. The cloudevents pubsub protocol only addresses topics in its
+// client's project, so the event is published with the pubsub client directly.
+func (p *Processor) publishToRetryTopicInProject(ctx context.Context, project, topicID string, event *event.Event) error {
+	if p.RetryPubsubClient == nil {
+		return fmt.Errorf("failed to send event to retry topic: no pubsub client for project %q", project)
+	}
+	dt := extensions.FromSpanContext(trace.FromContext(ctx).SpanContext())
+	msg := new(pubsub.Message)
+	if err := cepubsub.WritePubSubMessage(ctx, binding.ToMessage(event), msg, dt.WriteTransformer()); err != nil {
+		return fmt.Errorf("failed to send event to retry topic: %w", err)
+	}
+	topic := p.acquireRetryTopic(project, topicID)
+	defer p.releaseRetryTopic(topic)
+	if _, err := topic.Publish(ctx, msg).Get(ctx); err != nil {
+		return fmt.Errorf("failed to send event to retry topic: %w", err)
+	}
+	return nil
+}
+
+// retryTopic is a cached handle of a retry topic in another project. refs is
+// the number of publishes in flight with the handle, so that a removed handle
+// is only stopped once they are done.
+type retryTopic struct {
+	*pubsub.Topic
+	refs    int
+	removed bool
+}
+
+// acquireRetryTopic returns the cached handle of the retry topic in another
+// project, creating it if needed. The handle must be released with
+// releaseRetryTopic once the publish is done.
+func (p *Processor) acquireRetryTopic(project, topicID string) *retryTopic {
+	p.retryTopicsMux.Lock()
+	defer p.retryTopicsMux.Unlock()
+	name := config.FullTopicName(project, topicID)
+	topic, ok := p.retryTopics[name]
+	if !ok {
+		if p.retryTopics == nil {
+			p.retryTopics = make(map[string]*retryTopic)
+		}
+		topic = &retryTopic{Topic: p.RetryPubsubClient.TopicInProject(topicID, project)}
+		p.retryTopics[name] = topic
+	}
+	topic.refs++
+	return topic
+}
+
+// releaseRetryTopic releases a handle acquired with acquireRetryTopic. It stops
+// the handle if it was removed from the cache and this was its last publish.
+func (p *Processor) releaseRetryTopic(topic *retryTopic) {
+	p.retryTopicsMux.Lock()
+	topic.refs--
+	stop := topic.removed && topic.refs == 0
+	p.retryTopicsMux.Unlock()
+	if stop {
+		topic.Stop()
+	}
+}
+
+// RetryTopicsInUse returns the fully qualified names of the retry topics in
+// other projects used by the given targets. Pools compute it once per sync to
+// prune the retry topics cached by their processors.
+func RetryTopicsInUse(targets config.ReadonlyTargets) map[string]bool {
+	inUse := make(map[string]bool)
+	targets.RangeAllTargets(func(t *config.Target) bool {
+		if t.RetryQueue == nil {
+			return true
+		}
+		if project, id := config.ParseQueueName(t.RetryQueue.Topic); project != "" {
+			inUse[config.FullTopicName(project, id)] = true
+		}
+		return true
+	})
+	return inUse
+}
+
+// PruneRetryTopics removes the cached retry topic handles that are not in the
+// given set, e.g. because their triggers were deleted. The removed handles are
+// stopped once the publishes in flight with them are done.
+func (p *Processor) PruneRetryTopics(inUse map[string]bool) {
+	p.retryTopicsMux.Lock()
+	var idle []*retryTopic
+	for name, topic := range p.retryTopics {
+		if inUse[name] {
+			continue
+		}
+		delete(p.retryTopics, name)
+		topic.removed = true
+		if topic.refs == 0 {
+			idle = append(idle, topic)
+		}
+	}
+	p.retryTopicsMux.Unlock()
+	// Stop flushes the pending events, so it's not called with the lock held.
+	for _, topic := range idle {
+		topic.Stop()
+	}
+}
+
+// Close stops the cached retry topic handles, flushing the events still being
+// published. It must be called once the processor no longer processes events.
+func (p *Processor) Close() {
+	p.PruneRetryTopics(nil)
+}
@@ -41,6 +41,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/api/option"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
 	"google.golang.org/grpc"
 	"knative.dev/pkg/logging"
 	logtest "knative.dev/pkg/logging/testing"
@@ -230,6 +231,7 @@ func TestDeliverFailure(t *testing.T) {
 		withRetry     bool
 		targetHandler *targetWithFailureHandler
 		failRetry     bool
+		retryProject  string
 		wantErr       bool
 	}{{
 		name:          "delivery error no retry",
@@ -245,6 +247,18 @@ func TestDeliverFailure(t *testing.T) {
 		withRetry:     true,
 		failRetry:     true,
 		wantErr:       true,
+	}, {
+		name:          "delivery error retry in another project success",
+		targetHandler: &targetWithFailureHandler{respCode: http.StatusInternalServerError},
+		withRetry:     true,
+		retryProject:  "tenant-project",
+	}, {
+		name:          "delivery error retry in another project failure",
+		targetHandler: &targetWithFailureHandler{respCode: http.StatusInternalServerError},
+		withRetry:     true,
+		failRetry:     true,
+		retryProject:  "tenant-project",
+		wantErr:       true,
 	}, {
 		name:          "delivery timeout no retry",
 		targetHandler: &targetWithFailureHandler{delay: time.Second, respCode: http.StatusOK},
@@ -274,14 +288,23 @@ func TestDeliverFailure(t *testing.T) {
 			targetSvr := httptest.NewServer(tc.targetHandler)
 			defer targetSvr.Close()
 
-			_, c, close := testPubsubClient(ctx, t, "test-project")
+			srv, c, close := testPubsubClient(ctx, t, "test-project")
 			defer close()
 
+			retryTopic := "test-retry-topic"
+			if tc.retryProject != "" {
+				retryTopic = config.FullTopicName(tc.retryProject, "test-retry-topic")
+			}
 			// Don't create the retry topic to make it fail.
 			if !tc.failRetry {
 				if _, err := c.CreateTopic(ctx, "test-retry-topic"); err != nil {
 					t.Fatalf("failed to create test pubsub topc: %v", err)
 				}
+				if tc.retryProject != "" {
+					if _, err := srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: retryTopic}); err != nil {
+						t.Fatalf("failed to create test pubsub topc: %v", err)
+					}
+				}
 			}
 
 			ps, err := cepubsub.New(ctx, cepubsub.WithClient(c), cepubsub.WithProjectID("test-project"))
@@ -300,7 +323,7 @@ func TestDeliverFailure(t *testing.T) {
 				Broker:    "broker",
 				Address:   targetSvr.URL,
 				RetryQueue: &config.Queue{
-					Topic: "test-retry-topic",
+					Topic: retryTopic,
 				},
 			}
 			testTargets := memory.NewEmptyTargets()
@@ -319,6 +342,7 @@ func TestDeliverFailure(t *testing.T) {
 				Targets:            testTargets,
 				RetryOnFailure:     tc.withRetry,
 				DeliverRetryClient: deliverRetryClient,
+				RetryPubsubClient:  c,
 				DeliverTimeout:     500 * time.Millisecond,
 				StatsReporter:      r,
 			}
@@ -328,6 +352,38 @@ func TestDeliverFailure(t *testing.T) {
 			if (err != nil) != tc.wantErr {
 				t.Errorf("processing got error=%v, want=%v", err, tc.wantErr)
 			}
+
+			if tc.withRetry && tc.retryProject != "" {
+				// The retry topic handle is reused by the following events.
+				if err := p.Process(ctx, newSampleEvent()); (err != nil) != tc.wantErr {
+					t.Errorf("processing the second event got error=%v, want=%v", err, tc.wantErr)
+				}
+				if got := len(p.retryTopics); got != 1 {
+					t.Errorf("cached retry topics got=%d, want=1", got)
+				}
+				// The retry topic is still in use until the trigger is deleted.
+				p.PruneRetryTopics(RetryTopicsInUse(testTargets))
+				if got := len(p.retryTopics); got != 1 {
+					t.Errorf("cached retry topics after pruning got=%d, want=1", got)
+				}
+				testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+					bm.DeleteTargets(target)
+				})
+				// A publish in flight when the trigger is deleted still succeeds.
+				topic := p.acquireRetryTopic(tc.retryProject, "test-retry-topic")
+				p.PruneRetryTopics(RetryTopicsInUse(testTargets))
+				if _, ok := p.retryTopics[retryTopic]; ok {
+					t.Errorf("cached retry topics after deleting the trigger got=%v, want no %q", p.retryTopics, retryTopic)
+				}
+				if _, err := topic.Publish(ctx, &pubsub.Message{Data: []byte("in flight")}).Get(ctx); (err != nil) != tc.failRetry {
+					t.Errorf("publishing in flight after pruning got error=%v, want error=%v", err, tc.failRetry)
+				}
+				p.releaseRetryTopic(topic)
+				p.Close()
+				if got := len(p.retryTopics); got != 0 {
+					t.Errorf("cached retry topics after close got=%d, want=0", got)
+				}
+			}
 		})
 	}
 }
@@ -28,6 +28,7 @@ import (
 	kntracing "knative.dev/eventing/pkg/tracing"
 
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/eventutil"
 	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	"github.com/google/knative-gcp/pkg/broker/handler/processors"
 	"github.com/google/knative-gcp/pkg/tracing"
@@ -94,42 +95,16 @@ func startSpan(ctx context.Context, trigger types.NamespacedName, event *event.E
 }
 
 func (p *Processor) passFilter(ctx context.Context, attrs map[string]string, event *event.Event) bool {
-	// Set standard context attributes. The attributes available may not be
-	// exactly the same as the attributes defined in the current version of the
-	// CloudEvents spec.
-	ce := map[string]interface{}{
-		"specversion":     event.SpecVersion(),
-		"type":            event.Type(),
-		"source":          event.Source(),
-		"subject":         event.Subject(),
-		"id":              event.ID(),
-		"time":            event.Time().String(),
-		"schemaurl":       event.DataSchema(),
-		"datacontenttype": event.DataContentType(),
-		"datamediatype":   event.DataMediaType(),
-		// TODO: use data_base64 when SDK supports it.
-		"datacontentencoding": event.DeprecatedDataContentEncoding(),
+	m := eventutil.MatchFilterAttributes(attrs, event)
+	if m == nil {
+		return true
 	}
-	ext := event.Extensions()
-	for k, v := range ext {
-		ce[k] = v
-	}
-
-	for k, v := range attrs {
-		var value interface{}
-		value, ok := ce[k]
-		// If the attribute does not exist in the event, return false.
-		if !ok {
-			logging.FromContext(ctx).Debug("Attribute not found", zap.String("attribute", k))
-			trace.FromContext(ctx).Annotatef(nil, "event missing filter attribute %q", k)
-			return false
-		}
-		// If the attribute is not set to any and is different than the one from the event, return false.
-		if v != "" && v != value {
-			logging.FromContext(ctx).Debug("Attribute had non-matching value", zap.String("attribute", k), zap.String("filter", v), zap.Any("received", value))
-			trace.FromContext(ctx).Annotatef(nil, "event attribute %q does not match filter value %q", k, v)
-			return false
-		}
+	if m.Missing {
+		logging.FromContext(ctx).Debug("Attribute not found", zap.String("attribute", m.Attribute))
+		trace.FromContext(ctx).Annotatef(nil, "event missing filter attribute %q", m.Attribute)
+	} else {
+		logging.FromContext(ctx).Debug("Attribute had non-matching value", zap.String("attribute", m.Attribute), zap.String("filter", m.Filter), zap.Any("received", m.Received))
+		trace.FromContext(ctx).Annotatef(nil, "event attribute %q does not match filter value %q", m.Attribute, m.Filter)
 	}
-	return true
+	return false
 }
@@ -26,6 +26,7 @@ import (
 
 	"cloud.google.com/go/pubsub"
 
+	"github.com/google/knative-gcp/pkg/broker/admin"
 	"github.com/google/knative-gcp/pkg/broker/config"
 	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	"github.com/google/knative-gcp/pkg/broker/handler/processors"
@@ -128,7 +129,7 @@ func (p *RetryPool) SyncOnce(ctx context.Context) error {
 			return true
 		}
 
-		sub := p.pubsubClient.Subscription(t.RetryQueue.Subscription)
+		sub := queueSubscription(p.pubsubClient, t.RetryQueue.Subscription)
 		sub.ReceiveSettings = p.options.PubsubReceiveSettings
 
 		h := NewHandler(
@@ -172,3 +173,19 @@ func (p *RetryPool) SyncOnce(ctx context.Context) error {
 
 	return nil
 }
+
+// Targets returns the targets config the pool syncs with.
+func (p *RetryPool) Targets() config.ReadonlyTargets {
+	return p.targets
+}
+
+// HandlerStatuses returns the status of the handlers in the pool keyed by
+// trigger key.
+func (p *RetryPool) HandlerStatuses() map[string]admin.HandlerStatus {
+	statuses := make(map[string]admin.HandlerStatus)
+	p.pool.Range(func(key, value interface{}) bool {
+		statuses[key.(string)] = admin.HandlerStatus{Alive: value.(*retryHandlerCache).IsAlive()}
+		return true
+	})
+	return statuses
+}
@@ -61,7 +61,7 @@ func TestRetryWatchAndSync(t *testing.T) {
 	}
 
 	t.Run("start sync pool creates no handler", func(t *testing.T) {
-		_, err = StartSyncPool(ctx, syncPool, signal, time.Minute, p)
+		_, err = StartSyncPool(ctx, syncPool, signal, time.Minute, p, "", nil)
 		if err != nil {
 			t.Errorf("unexpected error from starting sync pool: %v", err)
 		}
@@ -157,7 +157,7 @@ func TestRetrySyncPoolE2E(t *testing.T) {
 		t.Fatalf("failed to get random free port: %v", err)
 	}
 
-	if _, err := StartSyncPool(ctx, syncPool, signal, time.Minute, p); err != nil {
+	if _, err := StartSyncPool(ctx, syncPool, signal, time.Minute, p, "", nil); err != nil {
 		t.Errorf("unexpected error from starting sync pool: %v", err)
 	}
 
@@ -28,6 +28,9 @@ import (
 	ceclient "github.com/cloudevents/sdk-go/v2/client"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/knative-gcp/pkg/broker/admin"
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
 	"github.com/google/knative-gcp/pkg/logging"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"github.com/google/knative-gcp/pkg/tracing"
@@ -95,15 +98,25 @@ type Handler struct {
 	httpReceiver HttpMessageReceiver
 	// decouple is the client to send events to a decouple sink.
 	decouple DecoupleSink
-	logger   *zap.Logger
-	reporter *metrics.IngressReporter
+	// targets is the loaded targets config, only used for debugging.
+	targets config.ReadonlyTargets
+	// generation is the generation of the loaded targets config, only used
+	// for debugging. It's nil if the generation isn't tracked.
+	generation *volume.Generation
+	// adminToken authorizes requests to the admin endpoints.
+	adminToken admin.Token
+	logger     *zap.Logger
+	reporter   *metrics.IngressReporter
 }
 
 // NewHandler creates a new ingress handler.
-func NewHandler(ctx context.Context, httpReceiver HttpMessageReceiver, decouple DecoupleSink, reporter *metrics.IngressReporter) *Handler {
+func NewHandler(ctx context.Context, httpReceiver HttpMessageReceiver, decouple DecoupleSink, targets config.ReadonlyTargets, generation *volume.Generation, adminToken admin.Token, reporter *metrics.IngressReporter) *Handler {
 	return &Handler{
 		httpReceiver: httpReceiver,
 		decouple:     decouple,
+		targets:      targets,
+		generation:   generation,
+		adminToken:   adminToken,
 		reporter:     reporter,
 		logger:       logging.FromContext(ctx),
 	}
@@ -124,6 +137,10 @@ func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Re
 		response.WriteHeader(nethttp.StatusOK)
 		return
 	}
+	if request.URL.Path == admin.SnapshotPath {
+		admin.ServeSnapshot(response, request, h.adminToken, h.snapshot)
+		return
+	}
 	ctx := request.Context()
 	ctx = logging.WithLogger(ctx, h.logger)
 	ctx = tracing.WithLogging(ctx, trace.FromContext(ctx))
@@ -210,6 +227,24 @@ func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Re
 	response.WriteHeader(statusCode)
 }
 
+// snapshot returns the config snapshot of the ingress. The ingress doesn't run
+// handlers, so only the targets config, its generation and the last time it
+// was loaded are included.
+func (h *Handler) snapshot() (*admin.Snapshot, error) {
+	targets, err := admin.TargetsJSON(h.targets)
+	if err != nil {
+		return nil, err
+	}
+	s := &admin.Snapshot{Targets: targets}
+	if h.generation != nil {
+		s.Generation = h.generation.Load()
+		if syncTime := h.generation.SyncTime(); !syncTime.IsZero() {
+			s.LastSyncTime = &syncTime
+		}
+	}
+	return s, nil
+}
+
 // toEvent converts an http request to an event.
 func (h *Handler) toEvent(ctx context.Context, request *nethttp.Request) (*cev2.Event, error) {
 	message := http.NewMessageFromHttpRequest(request)
@@ -21,8 +21,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	nethttp "net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -38,8 +41,10 @@ import (
 	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/knative-gcp/pkg/broker/admin"
 	"github.com/google/knative-gcp/pkg/broker/config"
 	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
 	"github.com/google/knative-gcp/pkg/metrics"
 	reportertest "github.com/google/knative-gcp/pkg/metrics/testing"
 	kgcptesting "github.com/google/knative-gcp/pkg/testing"
@@ -48,6 +53,7 @@ import (
 	"google.golang.org/api/option"
 	"google.golang.org/api/support/bundler"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/logging"
@@ -97,6 +103,12 @@ var brokerConfig = &config.TargetsConfig{
 			Namespace:     "ns4",
 			DecoupleQueue: &config.Queue{Topic: "topic4", State: config.State_UNKNOWN},
 		},
+		"debug/config": {
+			Id:            "b-uid-5",
+			Name:          "config",
+			Namespace:     "debug",
+			DecoupleQueue: &config.Queue{Topic: topicID, State: config.State_READY},
+		},
 	},
 }
 
@@ -169,6 +181,20 @@ func TestHandler(t *testing.T) {
 			},
 			eventAssertions: []eventAssertion{assertExtensionsExist(EventArrivalTime), assertTraceID(traceID)},
 		},
+		{
+			name:           "broker whose path is close to the admin endpoints",
+			path:           "/debug/config",
+			event:          createTestEvent("test-event"),
+			wantCode:       nethttp.StatusAccepted,
+			wantEventCount: 1,
+			wantMetricTags: map[string]string{
+				metricskey.LabelEventType:         eventType,
+				metricskey.LabelResponseCode:      "202",
+				metricskey.LabelResponseCodeClass: "2xx",
+				metricskey.PodName:                pod,
+				metricskey.ContainerName:          container,
+			},
+		},
 		{
 			name:     "valid event but unsupported http method",
 			method:   "PUT",
@@ -400,7 +426,7 @@ func runIngressHandlerBenchmark(b *testing.B, eventSize int) {
 	if err != nil {
 		b.Fatal(err)
 	}
-	h := NewHandler(ctx, nil, decouple, statsReporter)
+	h := NewHandler(ctx, nil, decouple, memory.NewTargets(brokerConfig), nil, "", statsReporter)
 
 	if _, err := psClient.CreateTopic(ctx, topicID); err != nil {
 		b.Fatal(err)
@@ -471,7 +497,7 @@ func createAndStartIngress(ctx context.Context, t testing.TB, psSrv *pstest.Serv
 	if err != nil {
 		t.Fatal(err)
 	}
-	h := NewHandler(ctx, receiver, decouple, statsReporter)
+	h := NewHandler(ctx, receiver, decouple, memory.NewTargets(brokerConfig), nil, "", statsReporter)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -578,3 +604,49 @@ func (recv *testHttpMessageReceiver) StartListen(ctx context.Context, handler ne
 	}
 	return nil
 }
+
+func TestHandlerSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ingresstest-*")
+	if err != nil {
+		t.Fatalf("unexpected error from creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	targetsPath := filepath.Join(dir, "targets")
+	b, err := proto.Marshal(brokerConfig)
+	if err != nil {
+		t.Fatalf("unexpected error from marshalling targets: %v", err)
+	}
+	if err := ioutil.WriteFile(targetsPath, b, 0644); err != nil {
+		t.Fatalf("unexpected error from writing config file: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, volume.GenerationFileName), []byte("7"), 0644); err != nil {
+		t.Fatalf("unexpected error from writing generation file: %v", err)
+	}
+	generation := &volume.Generation{}
+	targets, err := volume.NewTargetsFromFile(volume.WithPath(targetsPath), volume.WithGeneration(generation))
+	if err != nil {
+		t.Fatalf("unexpected error from NewTargetsFromFile: %v", err)
+	}
+
+	h := NewHandler(logtest.TestContextWithLogger(t), nil, nil, targets, generation, "secret", nil)
+	req := httptest.NewRequest(nethttp.MethodGet, admin.SnapshotPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != nethttp.StatusOK {
+		t.Fatalf("snapshot status code got=%d, want=%d", w.Code, nethttp.StatusOK)
+	}
+	var got admin.Snapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error from decoding snapshot: %v", err)
+	}
+	if got.Generation != 7 {
+		t.Errorf("snapshot generation got=%d, want=7", got.Generation)
+	}
+	if got.LastSyncTime == nil || !got.LastSyncTime.Equal(generation.SyncTime()) {
+		t.Errorf("snapshot last sync time got=%v, want=%v", got.LastSyncTime, generation.SyncTime())
+	}
+	if len(got.Targets) == 0 {
+		t.Error("snapshot targets got empty, want the loaded targets")
+	}
+}
@@ -99,7 +99,7 @@ func (m *multiTopicDecoupleSink) getTopicForBroker(ctx context.Context, broker t
 
 	if topic, ok := m.getExistingTopic(broker); ok {
 		// Check that the broker's topic ID hasn't changed.
-		if topic.ID() == topicID {
+		if topicMatches(topic, topicID) {
 			return topic, nil
 		}
 	}
@@ -118,14 +118,19 @@ func (m *multiTopicDecoupleSink) updateTopicForBroker(ctx context.Context, broke
 	}
 
 	if topic, ok := m.topics[broker]; ok {
-		if topic.ID() == topicID {
+		if topicMatches(topic, topicID) {
 			// Topic already updated.
 			return topic, nil
 		}
 		// Stop old topic.
 		m.topics[broker].Stop()
 	}
-	topic := m.pubsub.Topic(topicID)
+	var topic *pubsub.Topic
+	if project, id := config.ParseQueueName(topicID); project != "" {
+		topic = m.pubsub.TopicInProject(id, project)
+	} else {
+		topic = m.pubsub.Topic(topicID)
+	}
 	m.topics[broker] = topic
 	return topic, nil
 }
@@ -150,6 +155,15 @@ func (m *multiTopicDecoupleSink) getTopicIDForBroker(ctx context.Context, broker
 	return brokerConfig.DecoupleQueue.Topic, nil
 }
 
+// topicMatches checks whether the topic handle refers to the given topic ID or
+// fully qualified topic name.
+func topicMatches(topic *pubsub.Topic, name string) bool {
+	if project, _ := config.ParseQueueName(name); project != "" {
+		return topic.String() == name
+	}
+	return topic.ID() == name
+}
+
 func (m *multiTopicDecoupleSink) getExistingTopic(broker types.NamespacedName) (*pubsub.Topic, bool) {
 	m.topicsMut.RLock()
 	defer m.topicsMut.RUnlock()
@@ -24,6 +24,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/pubsub/pstest"
 	"github.com/google/uuid"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	cepubsub "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
@@ -92,6 +93,23 @@ func TestMultiTopicDecoupleSink(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path broker topic in another project",
+			brokerConfig: &config.TargetsConfig{
+				Brokers: map[string]*config.Broker{
+					"test_ns_1/test_broker_1": {DecoupleQueue: &config.Queue{Topic: config.FullTopicName("tenant-project", "test_topic_1"), State: config.State_READY}},
+				},
+			},
+			cases: []brokerTestCase{
+				{
+					broker: types.NamespacedName{
+						Namespace: "test_ns_1",
+						Name:      "test_broker_1",
+					},
+					topic: config.FullTopicName("tenant-project", "test_topic_1"),
+				},
+			},
+		},
 		{
 			name:         "broker doesn't exist in config",
 			brokerConfig: &config.TargetsConfig{},
@@ -169,12 +187,20 @@ func TestMultiTopicDecoupleSink(t *testing.T) {
 			psClient := createPubsubClient(ctx, t, psSrv)
 			brokerConfig := memory.NewTargets(tt.brokerConfig)
 			for i, testCase := range tt.cases {
-				topic := psClient.Topic(testCase.topic)
-				if exists, err := topic.Exists(ctx); err != nil {
-					t.Fatal(err)
-				} else if !exists {
-					if topic, err = psClient.CreateTopic(ctx, testCase.topic); err != nil {
+				var topic *pubsub.Topic
+				if project, id := config.ParseQueueName(testCase.topic); project != "" {
+					topic = psClient.TopicInProject(id, project)
+					if _, err := psSrv.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: testCase.topic}); err != nil {
+						t.Fatal(err)
+					}
+				} else {
+					topic = psClient.Topic(testCase.topic)
+					if exists, err := topic.Exists(ctx); err != nil {
 						t.Fatal(err)
+					} else if !exists {
+						if topic, err = psClient.CreateTopic(ctx, testCase.topic); err != nil {
+							t.Fatal(err)
+						}
 					}
 				}
 				subscription, err := psClient.CreateSubscription(
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podstatus reports the broker targets config state of a data plane
+// pod through annotations on the pod itself, so that the BrokerCell controller
+// can aggregate it into the BrokerCell status.
+package podstatus
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/google/knative-gcp/pkg/logging"
+)
+
+const (
+	// ConfigGenerationAnnotationKey is the pod annotation holding the
+	// generation of the broker targets config loaded by the pod.
+	ConfigGenerationAnnotationKey = "events.cloud.google.com/targetsConfigGeneration"
+
+	// ConfigStaleAnnotationKey is the pod annotation that is "true" when the
+	// pod failed to sync the broker targets config for longer than its max
+	// stale duration.
+	ConfigStaleAnnotationKey = "events.cloud.google.com/targetsConfigStale"
+
+	// ConfigStatusReportPeriod is how often the data plane components report
+	// their config status.
+	ConfigStatusReportPeriod = 15 * time.Second
+)
+
+// Reporter reports the config status of the pod it runs in.
+type Reporter struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	podName    string
+	generation func() (int64, error)
+
+	mux  sync.Mutex
+	last map[string]string
+}
+
+// NewReporter creates a Reporter for the given pod. generation returns the
+// generation of the currently loaded targets config.
+func NewReporter(kubeClient kubernetes.Interface, namespace, podName string, generation func() (int64, error)) *Reporter {
+	return &Reporter{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		podName:    podName,
+		generation: generation,
+	}
+}
+
+// ReportConfigStatus annotates the pod with the loaded config generation and
+// whether it is stale. The pod is only patched when the status changed since
+// the last successful report.
+func (r *Reporter) ReportConfigStatus(ctx context.Context, stale bool) {
+	gen, err := r.generation()
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get targets config generation", zap.Error(err))
+		return
+	}
+	annotations := map[string]string{
+		ConfigGenerationAnnotationKey: strconv.FormatInt(gen, 10),
+		ConfigStaleAnnotationKey:      strconv.FormatBool(stale),
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if equality.Semantic.DeepEqual(annotations, r.last) {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to marshal pod annotations patch", zap.Error(err))
+		return
+	}
+	if _, err := r.kubeClient.CoreV1().Pods(r.namespace).Patch(ctx, r.podName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		// Not fatal, the status will be reported again on the next attempt.
+		logging.FromContext(ctx).Warn("Failed to report config status", zap.Error(err))
+		return
+	}
+	r.last = annotations
+}
+
+// Run reports the config status as never stale every period until ctx is
+// done. It is meant for components which don't track staleness themselves.
+func (r *Reporter) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		r.ReportConfigStatus(ctx, false)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+func TestReportConfigStatus(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pod"}}
+	client := fake.NewSimpleClientset(pod)
+	gen := int64(1)
+	r := NewReporter(client, "ns", "pod", func() (int64, error) { return gen, nil })
+	ctx := context.Background()
+
+	assertAnnotations := func(want map[string]string) {
+		t.Helper()
+		got, err := client.CoreV1().Pods("ns").Get(ctx, "pod", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get pod: %v", err)
+		}
+		if diff := cmp.Diff(want, got.Annotations); diff != "" {
+			t.Errorf("Unexpected pod annotations (-want, +got): %s", diff)
+		}
+	}
+	countPatches := func() int {
+		n := 0
+		for _, a := range client.Actions() {
+			if _, ok := a.(clientgotesting.PatchAction); ok {
+				n++
+			}
+		}
+		return n
+	}
+
+	r.ReportConfigStatus(ctx, false)
+	assertAnnotations(map[string]string{
+		ConfigGenerationAnnotationKey: "1",
+		ConfigStaleAnnotationKey:      "false",
+	})
+
+	// Unchanged status is not patched again.
+	r.ReportConfigStatus(ctx, false)
+	if got := countPatches(); got != 1 {
+		t.Errorf("Unexpected number of patches, got %d, want 1", got)
+	}
+
+	gen = 2
+	r.ReportConfigStatus(ctx, true)
+	assertAnnotations(map[string]string{
+		ConfigGenerationAnnotationKey: "2",
+		ConfigStaleAnnotationKey:      "true",
+	})
+
+	// Failing to get the generation doesn't change the annotations.
+	r.generation = func() (int64, error) { return 0, errors.New("generation error") }
+	r.ReportConfigStatus(ctx, false)
+	if got := countPatches(); got != 2 {
+		t.Errorf("Unexpected number of patches, got %d, want 2", got)
+	}
+}
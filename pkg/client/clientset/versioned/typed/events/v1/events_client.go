@@ -26,8 +26,11 @@ import (
 
 type EventsV1Interface interface {
 	RESTClient() rest.Interface
+	CloudArtifactRegistrySourcesGetter
 	CloudAuditLogsSourcesGetter
+	CloudBillingBudgetSourcesGetter
 	CloudBuildSourcesGetter
+	CloudMonitoringAlertSourcesGetter
 	CloudPubSubSourcesGetter
 	CloudSchedulerSourcesGetter
 	CloudStorageSourcesGetter
@@ -38,14 +41,26 @@ type EventsV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *EventsV1Client) CloudArtifactRegistrySources(namespace string) CloudArtifactRegistrySourceInterface {
+	return newCloudArtifactRegistrySources(c, namespace)
+}
+
 func (c *EventsV1Client) CloudAuditLogsSources(namespace string) CloudAuditLogsSourceInterface {
 	return newCloudAuditLogsSources(c, namespace)
 }
 
+func (c *EventsV1Client) CloudBillingBudgetSources(namespace string) CloudBillingBudgetSourceInterface {
+	return newCloudBillingBudgetSources(c, namespace)
+}
+
 func (c *EventsV1Client) CloudBuildSources(namespace string) CloudBuildSourceInterface {
 	return newCloudBuildSources(c, namespace)
 }
 
+func (c *EventsV1Client) CloudMonitoringAlertSources(namespace string) CloudMonitoringAlertSourceInterface {
+	return newCloudMonitoringAlertSources(c, namespace)
+}
+
 func (c *EventsV1Client) CloudPubSubSources(namespace string) CloudPubSubSourceInterface {
 	return newCloudPubSubSources(c, namespace)
 }
@@ -18,10 +18,16 @@ limitations under the License.
 
 package v1
 
+type CloudArtifactRegistrySourceExpansion interface{}
+
 type CloudAuditLogsSourceExpansion interface{}
 
+type CloudBillingBudgetSourceExpansion interface{}
+
 type CloudBuildSourceExpansion interface{}
 
+type CloudMonitoringAlertSourceExpansion interface{}
+
 type CloudPubSubSourceExpansion interface{}
 
 type CloudSchedulerSourceExpansion interface{}
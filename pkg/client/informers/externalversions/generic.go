@@ -67,10 +67,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1beta1().Triggers().Informer()}, nil
 
 		// Group=events.cloud.google.com, Version=v1
+	case v1.SchemeGroupVersion.WithResource("cloudartifactregistrysources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudArtifactRegistrySources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudauditlogssources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudAuditLogsSources().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cloudbillingbudgetsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudBillingBudgetSources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudbuildsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudBuildSources().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cloudmonitoringalertsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudMonitoringAlertSources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudpubsubsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1().CloudPubSubSources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudschedulersources"):
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filtered
+
+import (
+	context "context"
+
+	client "github.com/google/knative-gcp/pkg/client/injection/kube/client"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	informers "k8s.io/client-go/informers"
+	kubernetes "k8s.io/client-go/kubernetes"
+	controller "knative.dev/pkg/controller"
+	injection "knative.dev/pkg/injection"
+	logging "knative.dev/pkg/logging"
+)
+
+// LabelSelector selects the objects of the filtered informer factory: those
+// created for the BrokerCells, which have the app=cloud-run-events and
+// brokerCell labels. The BrokerCell reconciler only lists its data plane pods,
+// so caching the pods of the whole cluster isn't needed.
+const LabelSelector = "app=cloud-run-events,brokerCell"
+
+func init() {
+	injection.Default.RegisterInformerFactory(withInformerFactory)
+}
+
+// Key is used as the key for associating information with a context.Context.
+type Key struct{}
+
+func withInformerFactory(ctx context.Context) context.Context {
+	c := client.Get(ctx)
+	return context.WithValue(ctx, Key{}, NewInformerFactory(ctx, c))
+}
+
+// NewInformerFactory creates an informer factory only listing and watching the
+// objects matching LabelSelector.
+func NewInformerFactory(ctx context.Context, c kubernetes.Interface) informers.SharedInformerFactory {
+	opts := []informers.SharedInformerOption{
+		informers.WithTweakListOptions(func(l *v1.ListOptions) {
+			l.LabelSelector = LabelSelector
+		}),
+	}
+	if injection.HasNamespaceScope(ctx) {
+		opts = append(opts, informers.WithNamespace(injection.GetNamespaceScope(ctx)))
+	}
+	return informers.NewSharedInformerFactoryWithOptions(c, controller.GetResyncPeriod(ctx), opts...)
+}
+
+// Get extracts the filtered InformerFactory from the context.
+func Get(ctx context.Context) informers.SharedInformerFactory {
+	untyped := ctx.Value(Key{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panic(
+			"Unable to fetch filtered k8s.io/client-go/informers.SharedInformerFactory from context.")
+	}
+	return untyped.(informers.SharedInformerFactory)
+}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1
 
+// CloudArtifactRegistrySourceListerExpansion allows custom methods to be added to
+// CloudArtifactRegistrySourceLister.
+type CloudArtifactRegistrySourceListerExpansion interface{}
+
+// CloudArtifactRegistrySourceNamespaceListerExpansion allows custom methods to be added to
+// CloudArtifactRegistrySourceNamespaceLister.
+type CloudArtifactRegistrySourceNamespaceListerExpansion interface{}
+
 // CloudAuditLogsSourceListerExpansion allows custom methods to be added to
 // CloudAuditLogsSourceLister.
 type CloudAuditLogsSourceListerExpansion interface{}
@@ -26,6 +34,14 @@ type CloudAuditLogsSourceListerExpansion interface{}
 // CloudAuditLogsSourceNamespaceLister.
 type CloudAuditLogsSourceNamespaceListerExpansion interface{}
 
+// CloudBillingBudgetSourceListerExpansion allows custom methods to be added to
+// CloudBillingBudgetSourceLister.
+type CloudBillingBudgetSourceListerExpansion interface{}
+
+// CloudBillingBudgetSourceNamespaceListerExpansion allows custom methods to be added to
+// CloudBillingBudgetSourceNamespaceLister.
+type CloudBillingBudgetSourceNamespaceListerExpansion interface{}
+
 // CloudBuildSourceListerExpansion allows custom methods to be added to
 // CloudBuildSourceLister.
 type CloudBuildSourceListerExpansion interface{}
@@ -34,6 +50,14 @@ type CloudBuildSourceListerExpansion interface{}
 // CloudBuildSourceNamespaceLister.
 type CloudBuildSourceNamespaceListerExpansion interface{}
 
+// CloudMonitoringAlertSourceListerExpansion allows custom methods to be added to
+// CloudMonitoringAlertSourceLister.
+type CloudMonitoringAlertSourceListerExpansion interface{}
+
+// CloudMonitoringAlertSourceNamespaceListerExpansion allows custom methods to be added to
+// CloudMonitoringAlertSourceNamespaceLister.
+type CloudMonitoringAlertSourceNamespaceListerExpansion interface{}
+
 // CloudPubSubSourceListerExpansion allows custom methods to be added to
 // CloudPubSubSourceLister.
 type CloudPubSubSourceListerExpansion interface{}
@@ -29,3 +29,20 @@ type PubSubable interface {
 	// PubSubStatus returns the PubSubStatus portion of the Status.
 	PubSubStatus() *duckv1.PubSubStatus
 }
+
+// BuildFilterable is implemented by the PubSubables whose receive adapter
+// filters the Cloud Build notifications it delivers.
+type BuildFilterable interface {
+	// BuildFilterSpec returns the filter of the Cloud Build notifications,
+	// or nil if all of them are delivered.
+	BuildFilterSpec() *duckv1.BuildFilterSpec
+}
+
+// ImageFilterable is implemented by the PubSubables whose receive adapter
+// filters the Artifact Registry and Container Registry image notifications it
+// delivers.
+type ImageFilterable interface {
+	// ImageFilterSpec returns the filter of the image notifications, or nil
+	// if all of them are delivered.
+	ImageFilterSpec() *duckv1.ImageFilterSpec
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"context"
+
+	budgets "cloud.google.com/go/billing/budgets/apiv1beta1"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	budgetspb "google.golang.org/genproto/googleapis/cloud/billing/budgets/v1beta1"
+)
+
+// CreateFn is a factory function to create a Budgets client.
+type CreateFn func(ctx context.Context, opts ...option.ClientOption) (Client, error)
+
+// NewClient creates a new wrapped Budgets client.
+func NewClient(ctx context.Context, opts ...option.ClientOption) (Client, error) {
+	client, err := budgets.NewBudgetClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &budgetsClient{
+		client: client,
+	}, nil
+}
+
+// budgetsClient wraps budgets.BudgetClient. Is the client that will be used everywhere except unit tests.
+type budgetsClient struct {
+	client *budgets.BudgetClient
+}
+
+// Verify that it satisfies the budgets.Client interface.
+var _ Client = &budgetsClient{}
+
+// Close implements budgets.BudgetClient.Close
+func (c *budgetsClient) Close() error {
+	return c.client.Close()
+}
+
+// GetBudget implements budgets.BudgetClient.GetBudget
+func (c *budgetsClient) GetBudget(ctx context.Context, req *budgetspb.GetBudgetRequest, opts ...gax.CallOption) (*budgetspb.Budget, error) {
+	return c.client.GetBudget(ctx, req, opts...)
+}
+
+// UpdateBudget implements budgets.BudgetClient.UpdateBudget
+func (c *budgetsClient) UpdateBudget(ctx context.Context, req *budgetspb.UpdateBudgetRequest, opts ...gax.CallOption) (*budgetspb.Budget, error) {
+	return c.client.UpdateBudget(ctx, req, opts...)
+}
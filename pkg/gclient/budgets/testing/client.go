@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+
+	"google.golang.org/api/option"
+
+	"github.com/google/knative-gcp/pkg/gclient/budgets"
+	"github.com/googleapis/gax-go/v2"
+	budgetspb "google.golang.org/genproto/googleapis/cloud/billing/budgets/v1beta1"
+)
+
+// TestClientCreator returns a budgets.CreateFn used to construct the test Budgets client.
+func TestClientCreator(value interface{}) budgets.CreateFn {
+	var data TestClientData
+	var ok bool
+	if data, ok = value.(TestClientData); !ok {
+		data = TestClientData{}
+	}
+	if data.CreateClientErr != nil {
+		return func(_ context.Context, _ ...option.ClientOption) (budgets.Client, error) {
+			return nil, data.CreateClientErr
+		}
+	}
+
+	return func(_ context.Context, _ ...option.ClientOption) (budgets.Client, error) {
+		return &testClient{
+			data: data,
+		}, nil
+	}
+}
+
+// TestClientData is the data used to configure the test Budgets client.
+type TestClientData struct {
+	CreateClientErr error
+	GetBudgetErr    error
+	UpdateBudgetErr error
+	CloseErr        error
+	// Budget is returned by GetBudget. If nil, a budget with only the
+	// requested name is returned.
+	Budget *budgetspb.Budget
+}
+
+// testClient is the test Budgets client.
+type testClient struct {
+	data TestClientData
+}
+
+// Verify that it satisfies the budgets.Client interface.
+var _ budgets.Client = &testClient{}
+
+// Close implements client.Close
+func (c *testClient) Close() error {
+	return c.data.CloseErr
+}
+
+// GetBudget implements client.GetBudget
+func (c *testClient) GetBudget(ctx context.Context, req *budgetspb.GetBudgetRequest, opts ...gax.CallOption) (*budgetspb.Budget, error) {
+	if c.data.GetBudgetErr != nil {
+		return nil, c.data.GetBudgetErr
+	}
+	if c.data.Budget != nil {
+		return c.data.Budget, nil
+	}
+	return &budgetspb.Budget{
+		Name: req.Name,
+	}, nil
+}
+
+// UpdateBudget implements client.UpdateBudget
+func (c *testClient) UpdateBudget(ctx context.Context, req *budgetspb.UpdateBudgetRequest, opts ...gax.CallOption) (*budgetspb.Budget, error) {
+	if c.data.UpdateBudgetErr != nil {
+		return nil, c.data.UpdateBudgetErr
+	}
+	return req.Budget, nil
+}
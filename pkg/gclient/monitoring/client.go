@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+// CreateFn is a factory function to create a Monitoring client.
+type CreateFn func(ctx context.Context, opts ...option.ClientOption) (Client, error)
+
+// NewClient creates a new wrapped Monitoring client.
+func NewClient(ctx context.Context, opts ...option.ClientOption) (Client, error) {
+	channels, err := monitoring.NewNotificationChannelClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := monitoring.NewAlertPolicyClient(ctx, opts...)
+	if err != nil {
+		channels.Close()
+		return nil, err
+	}
+	return &monitoringClient{
+		channels: channels,
+		policies: policies,
+	}, nil
+}
+
+// monitoringClient wraps monitoring.NotificationChannelClient and monitoring.AlertPolicyClient. Is the client that
+// will be used everywhere except unit tests.
+type monitoringClient struct {
+	channels *monitoring.NotificationChannelClient
+	policies *monitoring.AlertPolicyClient
+}
+
+// Verify that it satisfies the monitoring.Client interface.
+var _ Client = &monitoringClient{}
+
+// Close closes both the notification channel and the alert policy clients.
+func (c *monitoringClient) Close() error {
+	err := c.channels.Close()
+	if perr := c.policies.Close(); err == nil {
+		err = perr
+	}
+	return err
+}
+
+// CreateNotificationChannel implements monitoring.NotificationChannelClient.CreateNotificationChannel
+func (c *monitoringClient) CreateNotificationChannel(ctx context.Context, req *monitoringpb.CreateNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	return c.channels.CreateNotificationChannel(ctx, req, opts...)
+}
+
+// UpdateNotificationChannel implements monitoring.NotificationChannelClient.UpdateNotificationChannel
+func (c *monitoringClient) UpdateNotificationChannel(ctx context.Context, req *monitoringpb.UpdateNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	return c.channels.UpdateNotificationChannel(ctx, req, opts...)
+}
+
+// DeleteNotificationChannel implements monitoring.NotificationChannelClient.DeleteNotificationChannel
+func (c *monitoringClient) DeleteNotificationChannel(ctx context.Context, req *monitoringpb.DeleteNotificationChannelRequest, opts ...gax.CallOption) error {
+	return c.channels.DeleteNotificationChannel(ctx, req, opts...)
+}
+
+// GetNotificationChannel implements monitoring.NotificationChannelClient.GetNotificationChannel
+func (c *monitoringClient) GetNotificationChannel(ctx context.Context, req *monitoringpb.GetNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	return c.channels.GetNotificationChannel(ctx, req, opts...)
+}
+
+// ListNotificationChannels implements monitoring.NotificationChannelClient.ListNotificationChannels
+func (c *monitoringClient) ListNotificationChannels(ctx context.Context, req *monitoringpb.ListNotificationChannelsRequest, opts ...gax.CallOption) ([]*monitoringpb.NotificationChannel, error) {
+	var channels []*monitoringpb.NotificationChannel
+	it := c.channels.ListNotificationChannels(ctx, req, opts...)
+	for {
+		channel, err := it.Next()
+		if err == iterator.Done {
+			return channels, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+}
+
+// GetAlertPolicy implements monitoring.AlertPolicyClient.GetAlertPolicy
+func (c *monitoringClient) GetAlertPolicy(ctx context.Context, req *monitoringpb.GetAlertPolicyRequest, opts ...gax.CallOption) (*monitoringpb.AlertPolicy, error) {
+	return c.policies.GetAlertPolicy(ctx, req, opts...)
+}
+
+// UpdateAlertPolicy implements monitoring.AlertPolicyClient.UpdateAlertPolicy
+func (c *monitoringClient) UpdateAlertPolicy(ctx context.Context, req *monitoringpb.UpdateAlertPolicyRequest, opts ...gax.CallOption) (*monitoringpb.AlertPolicy, error) {
+	return c.policies.UpdateAlertPolicy(ctx, req, opts...)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+
+	"google.golang.org/api/option"
+
+	"github.com/google/knative-gcp/pkg/gclient/monitoring"
+	"github.com/googleapis/gax-go/v2"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+// TestClientCreator returns a monitoring.CreateFn used to construct the test Monitoring client.
+func TestClientCreator(value interface{}) monitoring.CreateFn {
+	var data TestClientData
+	var ok bool
+	if data, ok = value.(TestClientData); !ok {
+		data = TestClientData{}
+	}
+	if data.CreateClientErr != nil {
+		return func(_ context.Context, _ ...option.ClientOption) (monitoring.Client, error) {
+			return nil, data.CreateClientErr
+		}
+	}
+
+	return func(_ context.Context, _ ...option.ClientOption) (monitoring.Client, error) {
+		return &testClient{
+			data: data,
+		}, nil
+	}
+}
+
+// TestClientData is the data used to configure the test Monitoring client.
+type TestClientData struct {
+	CreateClientErr              error
+	CreateNotificationChannelErr error
+	UpdateNotificationChannelErr error
+	DeleteNotificationChannelErr error
+	GetNotificationChannelErr    error
+	ListNotificationChannelsErr  error
+	GetAlertPolicyErr            error
+	UpdateAlertPolicyErr         error
+	CloseErr                     error
+	// NotificationChannelID is the ID of the channels returned by
+	// CreateNotificationChannel.
+	NotificationChannelID string
+	// NotificationChannel is returned by GetNotificationChannel. If nil, a
+	// channel with only the requested name is returned.
+	NotificationChannel *monitoringpb.NotificationChannel
+	// NotificationChannels are returned by ListNotificationChannels,
+	// regardless of the filter.
+	NotificationChannels []*monitoringpb.NotificationChannel
+	// AlertPolicies are returned by GetAlertPolicy, keyed by name. Policies
+	// not in the map are returned with only the requested name.
+	AlertPolicies map[string]*monitoringpb.AlertPolicy
+}
+
+// testClient is the test Monitoring client.
+type testClient struct {
+	data TestClientData
+}
+
+// Verify that it satisfies the monitoring.Client interface.
+var _ monitoring.Client = &testClient{}
+
+// Close implements client.Close
+func (c *testClient) Close() error {
+	return c.data.CloseErr
+}
+
+// CreateNotificationChannel implements client.CreateNotificationChannel
+func (c *testClient) CreateNotificationChannel(ctx context.Context, req *monitoringpb.CreateNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	if c.data.CreateNotificationChannelErr != nil {
+		return nil, c.data.CreateNotificationChannelErr
+	}
+	return &monitoringpb.NotificationChannel{
+		Name:        req.Name + "/notificationChannels/" + c.data.NotificationChannelID,
+		Type:        req.NotificationChannel.Type,
+		DisplayName: req.NotificationChannel.DisplayName,
+		Labels:      req.NotificationChannel.Labels,
+	}, nil
+}
+
+// UpdateNotificationChannel implements client.UpdateNotificationChannel
+func (c *testClient) UpdateNotificationChannel(ctx context.Context, req *monitoringpb.UpdateNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	if c.data.UpdateNotificationChannelErr != nil {
+		return nil, c.data.UpdateNotificationChannelErr
+	}
+	return req.NotificationChannel, nil
+}
+
+// DeleteNotificationChannel implements client.DeleteNotificationChannel
+func (c *testClient) DeleteNotificationChannel(ctx context.Context, req *monitoringpb.DeleteNotificationChannelRequest, opts ...gax.CallOption) error {
+	return c.data.DeleteNotificationChannelErr
+}
+
+// GetNotificationChannel implements client.GetNotificationChannel
+func (c *testClient) GetNotificationChannel(ctx context.Context, req *monitoringpb.GetNotificationChannelRequest, opts ...gax.CallOption) (*monitoringpb.NotificationChannel, error) {
+	if c.data.GetNotificationChannelErr != nil {
+		return nil, c.data.GetNotificationChannelErr
+	}
+	if c.data.NotificationChannel != nil {
+		return c.data.NotificationChannel, nil
+	}
+	return &monitoringpb.NotificationChannel{
+		Name: req.Name,
+	}, nil
+}
+
+// ListNotificationChannels implements client.ListNotificationChannels
+func (c *testClient) ListNotificationChannels(ctx context.Context, req *monitoringpb.ListNotificationChannelsRequest, opts ...gax.CallOption) ([]*monitoringpb.NotificationChannel, error) {
+	if c.data.ListNotificationChannelsErr != nil {
+		return nil, c.data.ListNotificationChannelsErr
+	}
+	return c.data.NotificationChannels, nil
+}
+
+// GetAlertPolicy implements client.GetAlertPolicy
+func (c *testClient) GetAlertPolicy(ctx context.Context, req *monitoringpb.GetAlertPolicyRequest, opts ...gax.CallOption) (*monitoringpb.AlertPolicy, error) {
+	if c.data.GetAlertPolicyErr != nil {
+		return nil, c.data.GetAlertPolicyErr
+	}
+	if p, ok := c.data.AlertPolicies[req.Name]; ok {
+		return p, nil
+	}
+	return &monitoringpb.AlertPolicy{
+		Name: req.Name,
+	}, nil
+}
+
+// UpdateAlertPolicy implements client.UpdateAlertPolicy
+func (c *testClient) UpdateAlertPolicy(ctx context.Context, req *monitoringpb.UpdateAlertPolicyRequest, opts ...gax.CallOption) (*monitoringpb.AlertPolicy, error) {
+	if c.data.UpdateAlertPolicyErr != nil {
+		return nil, c.data.UpdateAlertPolicyErr
+	}
+	return req.AlertPolicy, nil
+}
@@ -36,4 +36,8 @@ type Client interface {
 	DeleteJob(ctx context.Context, req *schedulerpb.DeleteJobRequest, opts ...gax.CallOption) error
 	// GetJob see https://godoc.org/cloud.google.com/go/scheduler/apiv1#CloudSchedulerClient.GetJob
 	GetJob(ctx context.Context, req *schedulerpb.GetJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error)
+	// PauseJob see https://godoc.org/cloud.google.com/go/scheduler/apiv1#CloudSchedulerClient.PauseJob
+	PauseJob(ctx context.Context, req *schedulerpb.PauseJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error)
+	// ResumeJob see https://godoc.org/cloud.google.com/go/scheduler/apiv1#CloudSchedulerClient.ResumeJob
+	ResumeJob(ctx context.Context, req *schedulerpb.ResumeJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error)
 }
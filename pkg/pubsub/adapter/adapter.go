@@ -18,7 +18,10 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	nethttp "net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -27,6 +30,8 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/extensions"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	intereventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
 	"github.com/google/knative-gcp/pkg/apis/messaging"
 	"github.com/google/knative-gcp/pkg/logging"
 	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
@@ -35,9 +40,14 @@ import (
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"go.opencensus.io/trace"
 	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	kntracing "knative.dev/eventing/pkg/tracing"
 )
 
+// ErrorExtension is the attribute holding the conversion or delivery error of
+// messages sent to the dead letter topic.
+const ErrorExtension = "knativeerror"
+
 // AdapterArgs has a bundle of arguments needed to create an Adapter.
 type AdapterArgs struct {
 	// TopicID is the id of the Pub/Sub topic.
@@ -55,6 +65,68 @@ type AdapterArgs struct {
 
 	// ConverterType use to select which converter to use.
 	ConverterType converters.ConverterType
+
+	// CustomConverter declares the conversion of the Custom converter type.
+	CustomConverter *intereventsv1.ConverterSpec
+
+	// SendMode is the HTTP mode events are sent in. Events are sent in binary
+	// mode if empty. In push mode, messages are not converted to events but
+	// delivered as Pub/Sub push messages.
+	SendMode converters.ModeType
+
+	// BatchMaxEvents is the maximum number of events delivered to the sink in
+	// a single CloudEvents batch. Batching is disabled if it is zero.
+	BatchMaxEvents int
+
+	// BatchMaxDelay is the maximum time a batch waits for more events before
+	// it is delivered.
+	BatchMaxDelay time.Duration
+
+	// Retry is the number of times a failed delivery is retried before the
+	// message is nacked. Only transient failures are retried.
+	Retry int
+
+	// BackoffPolicy is the backoff policy (linear or exponential) between retries.
+	BackoffPolicy eventingduckv1.BackoffPolicyType
+
+	// BackoffDelay is the base delay between retries.
+	BackoffDelay time.Duration
+
+	// ReplyURI is the URI where the events replied by the sink are sent to.
+	// Replies are dropped if empty.
+	ReplyURI string
+
+	// DeadLetterTopicID is the id of the Pub/Sub topic where messages that
+	// cannot be converted to events are sent to. If empty, they are dropped.
+	DeadLetterTopicID string
+
+	// DedupCacheSize is the maximum number of delivered message IDs kept to
+	// skip the redeliveries of these messages. Deduplication is disabled if
+	// it is zero.
+	DedupCacheSize int
+
+	// DedupTTL is how long delivered message IDs are kept.
+	DedupTTL time.Duration
+
+	// ReceiveSettings are the flow control settings of the streaming pull
+	// of the subscription.
+	ReceiveSettings pubsub.ReceiveSettings
+
+	// FilterAttributes are the attributes the events must match to be
+	// delivered. All events are delivered if empty.
+	FilterAttributes map[string]string
+
+	// SampleRate is the fraction of the events delivered among the ones
+	// matching FilterAttributes. All of them are delivered if it is zero.
+	SampleRate float64
+
+	// BuildFilter selects the Cloud Build notifications delivered. All of
+	// them are delivered if nil.
+	BuildFilter *gcpduckv1.BuildFilterSpec
+
+	// ImageFilter selects the Artifact Registry and Container Registry image
+	// notifications delivered. All of them are delivered if nil.
+	ImageFilter *gcpduckv1.ImageFilterSpec
 }
 
 // Adapter implements the Pub/Sub adapter to deliver Pub/Sub messages from a
@@ -63,6 +135,11 @@ type Adapter struct {
 	// subscription is the pubsub subscription used to receive messages from pubsub.
 	subscription *pubsub.Subscription
 
+	// deadLetterTopic is the pubsub topic where messages that fail conversion
+	// or fail delivery permanently are published to. Nil if no dead letter
+	// topic is configured.
+	deadLetterTopic *pubsub.Topic
+
 	// outbound is the client used to send events to.
 	outbound *nethttp.Client
 
@@ -72,6 +149,10 @@ type Adapter struct {
 	// converter used to convert pubsub messages to CE.
 	converter converters.Converter
 
+	// dedup records the delivered messages, so that their redeliveries are
+	// skipped. Nil if deduplication is disabled.
+	dedup DedupStore
+
 	// projectID is the id of the GCP project.
 	projectID string
 
@@ -84,6 +165,12 @@ type Adapter struct {
 	// args holds a set of arguments used to configure the Adapter.
 	args *AdapterArgs
 
+	// batcher accumulates events when batching is enabled.
+	batcher *batcher
+
+	// random returns a pseudo-random number in [0.0,1.0) to sample events.
+	random func() float64
+
 	// cancel is function to stop pulling messages.
 	cancel context.CancelFunc
 
@@ -98,20 +185,25 @@ func NewAdapter(
 	name Name,
 	resourceGroup ResourceGroup,
 	subscription *pubsub.Subscription,
+	deadLetterTopic *pubsub.Topic,
 	outbound *nethttp.Client,
 	converter converters.Converter,
+	dedup DedupStore,
 	reporter StatsReporter,
 	args *AdapterArgs) *Adapter {
 	return &Adapter{
-		subscription:   subscription,
-		projectID:      string(projectID),
-		namespacedName: types.NamespacedName{Namespace: string(namespace), Name: string(name)},
-		resourceGroup:  string(resourceGroup),
-		outbound:       outbound,
-		converter:      converter,
-		reporter:       reporter,
-		args:           args,
-		logger:         logging.FromContext(ctx),
+		subscription:    subscription,
+		deadLetterTopic: deadLetterTopic,
+		projectID:       string(projectID),
+		namespacedName:  types.NamespacedName{Namespace: string(namespace), Name: string(name)},
+		resourceGroup:   string(resourceGroup),
+		outbound:        outbound,
+		converter:       converter,
+		dedup:           dedup,
+		reporter:        reporter,
+		args:            args,
+		random:          rand.Float64,
+		logger:          logging.FromContext(ctx),
 	}
 }
 
@@ -123,22 +215,37 @@ func (a *Adapter) Start(ctx context.Context) error {
 	ctx = WithTopicKey(ctx, a.args.TopicID)
 	ctx = WithSubscriptionKey(ctx, a.subscription.ID())
 
+	if a.args.SendMode == converters.Push {
+		return a.subscription.Receive(ctx, a.receivePush)
+	}
+	if a.args.BatchMaxEvents > 0 {
+		a.batcher = newBatcher(a.args.BatchMaxEvents, a.args.BatchMaxDelay, a.sendBatch)
+		return a.subscription.Receive(ctx, a.receiveBatched)
+	}
 	return a.subscription.Receive(ctx, a.receive)
 }
 
 // Stop stops the adapter.
 func (a *Adapter) Stop() {
 	a.cancel()
+	if a.deadLetterTopic != nil {
+		a.deadLetterTopic.Stop()
+	}
 }
 
 // TODO refactor this method. As our RA code is used both for Sources and our Channel, it also supports replies
 //  (in the case of Channels) and the logic is more convoluted.
 func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
+	if a.isDuplicate(ctx, msg) {
+		return
+	}
+
 	event, err := a.converter.Convert(ctx, msg, a.args.ConverterType)
 	if err != nil {
-		a.logger.Debug("Failed to convert received message to an event, check the msg format: %v", zap.Error(err))
-		// Ack the message so it won't be retried, we consider all errors to be non-retryable.
-		msg.Ack()
+		a.handleConversionFailure(ctx, msg, err)
+		return
+	}
+	if a.dropEvent(ctx, msg, event) {
 		return
 	}
 
@@ -158,7 +265,9 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 	// in case both subscriber and reply are set. The transformer would act as the subscriber and the sink will be where
 	// we will send the reply.
 	if a.args.TransformerURI != "" {
-		resp, err := a.sendMsg(ctx, a.args.TransformerURI, (*binding.EventMessage)(event))
+		resp, err := a.sendWithRetries(ctx, func() (*nethttp.Response, error) {
+			return a.sendMsg(ctx, a.args.TransformerURI, (*binding.EventMessage)(event))
+		})
 		if err != nil {
 			a.logger.Error("Failed to send message to transformer", zap.String("address", a.args.TransformerURI), zap.Error(err))
 			msg.Nack()
@@ -175,14 +284,14 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 
 		if resp.StatusCode/100 != 2 {
 			a.logger.Error("Event delivery failed", zap.Int("StatusCode", resp.StatusCode))
-			msg.Nack()
+			a.handleDeliveryFailure(ctx, msg, args, resp)
 			return
 		}
 
 		respMsg := cehttp.NewMessageFromHttpResponse(resp)
 		if respMsg.ReadEncoding() == binding.EncodingUnknown {
 			// No reply
-			msg.Ack()
+			a.ackDelivered(ctx, msg)
 			return
 		}
 
@@ -212,7 +321,9 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 		}
 	}
 
-	response, err := a.sendMsg(ctx, a.args.SinkURI, (*binding.EventMessage)(event))
+	response, err := a.sendWithRetries(ctx, func() (*nethttp.Response, error) {
+		return a.sendMsg(ctx, a.args.SinkURI, (*binding.EventMessage)(event))
+	})
 	if err != nil {
 		a.logger.Error("Failed to send message to sink", zap.String("address", a.args.SinkURI), zap.Error(err))
 		msg.Nack()
@@ -229,10 +340,114 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 
 	if response.StatusCode/100 != 2 {
 		a.logger.Error("Event delivery failed", zap.Int("StatusCode", response.StatusCode))
+		a.handleDeliveryFailure(ctx, msg, args, response)
+		return
+	}
+
+	// If a reply destination has been configured, forward the sink reply, if
+	// any. The message is redelivered if the reply cannot be forwarded.
+	if a.args.ReplyURI != "" {
+		if err := a.forwardReply(ctx, response); err != nil {
+			a.logger.Error("Failed to forward reply", zap.String("address", a.args.ReplyURI), zap.Error(err))
+			msg.Nack()
+			return
+		}
+	}
+
+	a.ackDelivered(ctx, msg)
+}
+
+// forwardReply sends the event replied by the sink in resp, if any, to the
+// reply destination.
+func (a *Adapter) forwardReply(ctx context.Context, resp *nethttp.Response) error {
+	respMsg := cehttp.NewMessageFromHttpResponse(resp)
+	if respMsg.ReadEncoding() == binding.EncodingUnknown {
+		// No reply.
+		return nil
+	}
+	reply, err := binding.ToEvent(ctx, respMsg)
+	if err != nil {
+		return fmt.Errorf("failed to convert response message to event: %w", err)
+	}
+
+	response, err := a.sendWithRetries(ctx, func() (*nethttp.Response, error) {
+		return a.sendMsg(ctx, a.args.ReplyURI, (*binding.EventMessage)(reply))
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			a.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+
+	a.reporter.ReportEventCount(&ReportArgs{
+		EventType:   reply.Type(),
+		EventSource: reply.Source(),
+	}, response.StatusCode)
+
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("reply delivery failed with status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+// handleConversionFailure publishes a message that could not be converted to
+// an event to the dead letter topic, if any, with the conversion error in the
+// ErrorExtension attribute. The message is acked unless publishing fails, as
+// conversion errors are non-retryable.
+func (a *Adapter) handleConversionFailure(ctx context.Context, msg *pubsub.Message, convErr error) {
+	if a.deadLetterTopic == nil {
+		a.logger.Warn("Failed to convert received message to an event, dropping it", zap.String("messageId", msg.ID), zap.Error(convErr))
+		msg.Ack()
+		return
+	}
+
+	a.logger.Warn("Failed to convert received message to an event, sending it to the dead letter topic",
+		zap.String("messageId", msg.ID), zap.String("topic", a.deadLetterTopic.ID()), zap.Error(convErr))
+	a.sendToDeadLetterTopic(ctx, msg, convErr.Error())
+}
+
+// handleDeliveryFailure handles a message whose delivery failed with the given
+// non-2xx response. The message is nacked if the failure is retryable, so that
+// Pub/Sub redelivers it. Otherwise redelivering it would fail again, so it is
+// published to the dead letter topic if any, or acked and reported as dropped.
+func (a *Adapter) handleDeliveryFailure(ctx context.Context, msg *pubsub.Message, args *ReportArgs, resp *nethttp.Response) {
+	if isRetryable(resp, nil) {
 		msg.Nack()
 		return
 	}
+	if a.deadLetterTopic == nil {
+		a.logger.Warn("Event delivery failed permanently, dropping it", zap.String("messageId", msg.ID), zap.Int("StatusCode", resp.StatusCode))
+		a.reporter.ReportDroppedEventCount(args)
+		msg.Ack()
+		return
+	}
 
+	a.logger.Warn("Event delivery failed permanently, sending it to the dead letter topic",
+		zap.String("messageId", msg.ID), zap.String("topic", a.deadLetterTopic.ID()), zap.Int("StatusCode", resp.StatusCode))
+	a.sendToDeadLetterTopic(ctx, msg, fmt.Sprintf("event delivery failed with status code %d", resp.StatusCode))
+}
+
+// sendToDeadLetterTopic publishes the given message to the dead letter topic
+// with the given error in the ErrorExtension attribute. The message is acked
+// unless publishing fails.
+func (a *Adapter) sendToDeadLetterTopic(ctx context.Context, msg *pubsub.Message, errMsg string) {
+	attrs := make(map[string]string, len(msg.Attributes)+1)
+	for k, v := range msg.Attributes {
+		attrs[k] = v
+	}
+	attrs[ErrorExtension] = errMsg
+	res := a.deadLetterTopic.Publish(ctx, &pubsub.Message{
+		Data:       msg.Data,
+		Attributes: attrs,
+	})
+	if _, err := res.Get(ctx); err != nil {
+		a.logger.Error("Failed to publish message to the dead letter topic", zap.String("messageId", msg.ID), zap.Error(err))
+		msg.Nack()
+		return
+	}
 	msg.Ack()
 }
 
@@ -241,6 +456,12 @@ func (a *Adapter) sendMsg(ctx context.Context, address string, msg binding.Messa
 	if err != nil {
 		return nil, err
 	}
+	switch a.args.SendMode {
+	case converters.Structured:
+		ctx = binding.WithForceStructured(ctx)
+	case converters.Binary:
+		ctx = binding.WithForceBinary(ctx)
+	}
 	if err := cehttp.WriteRequest(ctx, msg, req); err != nil {
 		return nil, err
 	}
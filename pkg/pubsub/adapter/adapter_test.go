@@ -18,12 +18,17 @@ package adapter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -34,6 +39,7 @@ import (
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"golang.org/x/sync/errgroup"
 	logtest "knative.dev/pkg/logging/testing"
@@ -80,7 +86,8 @@ type metricLabels struct {
 }
 
 type statsReporterRecorder struct {
-	labels []metricLabels
+	labels  []metricLabels
+	dropped []metricLabels
 }
 
 func (r *statsReporterRecorder) ReportEventCount(args *ReportArgs, responseCode int) error {
@@ -88,6 +95,11 @@ func (r *statsReporterRecorder) ReportEventCount(args *ReportArgs, responseCode
 	return nil
 }
 
+func (r *statsReporterRecorder) ReportDroppedEventCount(args *ReportArgs) error {
+	r.dropped = append(r.dropped, metricLabels{CeType: args.EventType, CeSource: args.EventSource})
+	return nil
+}
+
 type mockConverter struct {
 	converted *cev2.Event
 }
@@ -202,8 +214,10 @@ func TestAdapter(t *testing.T) {
 				Name(testName),
 				ResourceGroup(testResourceGroup),
 				sub,
+				nil,
 				outbound,
 				&mockConverter{converted: tc.converted},
+				nil,
 				&statsReporterRecorder{},
 				args)
 
@@ -292,3 +306,838 @@ func newSampleEvent() *event.Event {
 	sampleEvent.SetTime(time.Now())
 	return &sampleEvent
 }
+
+func TestAdapterBatching(t *testing.T) {
+	convertedEvent := newSampleEvent()
+	convertedEvent.SetID("converted")
+
+	cases := []struct {
+		name       string
+		maxEvents  int
+		maxDelay   time.Duration
+		published  int
+		sinkStatus int
+		wantSize   int
+	}{{
+		name:       "batch is full",
+		maxEvents:  3,
+		maxDelay:   time.Minute,
+		published:  3,
+		sinkStatus: http.StatusOK,
+		wantSize:   3,
+	}, {
+		name:       "batch delay elapses",
+		maxEvents:  10,
+		maxDelay:   100 * time.Millisecond,
+		published:  2,
+		sinkStatus: http.StatusOK,
+		wantSize:   2,
+	}, {
+		name:       "sink fails",
+		maxEvents:  2,
+		maxDelay:   time.Minute,
+		published:  2,
+		sinkStatus: http.StatusInternalServerError,
+		wantSize:   2,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logtest.TestContextWithLogger(t)
+
+			batches := make(chan []cev2.Event, 10)
+			sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Type"); got != cev2.ApplicationCloudEventsBatchJSON {
+					t.Errorf("unexpected content type, want %q got %q", cev2.ApplicationCloudEventsBatchJSON, got)
+				}
+				var events []cev2.Event
+				if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+					t.Errorf("sink received invalid batch: %v", err)
+				}
+				w.WriteHeader(tc.sinkStatus)
+				batches <- events
+			}))
+			defer sinkSvr.Close()
+
+			c, close := testPubsubClient(ctx, t, testProjectID)
+			defer close()
+
+			topic, err := c.CreateTopic(ctx, testTopic)
+			if err != nil {
+				t.Fatalf("failed to create topic: %v", err)
+			}
+			sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+				Topic: topic,
+			})
+			if err != nil {
+				t.Fatalf("failed to create subscription: %v", err)
+			}
+
+			adapter := NewAdapter(ctx,
+				clients.ProjectID(testProjectID),
+				Namespace(testNamespace),
+				Name(testName),
+				ResourceGroup(testResourceGroup),
+				sub,
+				nil,
+				http.DefaultClient,
+				&mockConverter{converted: convertedEvent},
+				nil,
+				&statsReporterRecorder{},
+				&AdapterArgs{
+					TopicID:        testTopic,
+					SinkURI:        sinkSvr.URL,
+					Extensions:     map[string]string{"foo": "bar"},
+					ConverterType:  converters.ConverterType(testConverterType),
+					BatchMaxEvents: tc.maxEvents,
+					BatchMaxDelay:  tc.maxDelay,
+				})
+
+			rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			go adapter.Start(rctx)
+			defer adapter.Stop()
+
+			for i := 0; i < tc.published; i++ {
+				if _, err := topic.Publish(ctx, &pubsub.Message{Data: []byte("data")}).Get(ctx); err != nil {
+					t.Fatalf("failed to publish message: %v", err)
+				}
+			}
+
+			var got []cev2.Event
+			select {
+			case got = <-batches:
+			case <-rctx.Done():
+				t.Fatal("timed out waiting for the batch")
+			}
+			if len(got) != tc.wantSize {
+				t.Errorf("unexpected batch size, want %d got %d", tc.wantSize, len(got))
+			}
+			for _, e := range got {
+				if e.ID() != convertedEvent.ID() || e.Extensions()["foo"] != "bar" {
+					t.Errorf("unexpected event in batch: %v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestAdapterDeadLetter(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+
+	c, close := testPubsubClient(ctx, t, testProjectID)
+	defer close()
+
+	topic, err := c.CreateTopic(ctx, testTopic)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+		Topic: topic,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	dlt, err := c.CreateTopic(ctx, "dead-letter-topic")
+	if err != nil {
+		t.Fatalf("failed to create dead letter topic: %v", err)
+	}
+	dlSub, err := c.CreateSubscription(ctx, "dead-letter-sub", pubsub.SubscriptionConfig{
+		Topic: dlt,
+	})
+	if err != nil {
+		t.Fatalf("failed to create dead letter subscription: %v", err)
+	}
+
+	adapter := NewAdapter(ctx,
+		clients.ProjectID(testProjectID),
+		Namespace(testNamespace),
+		Name(testName),
+		ResourceGroup(testResourceGroup),
+		sub,
+		dlt,
+		http.DefaultClient,
+		// The converter fails.
+		&mockConverter{},
+		nil,
+		&statsReporterRecorder{},
+		&AdapterArgs{
+			TopicID:           testTopic,
+			SinkURI:           "http://sink",
+			ConverterType:     converters.ConverterType(testConverterType),
+			DeadLetterTopicID: dlt.ID(),
+		})
+
+	rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go adapter.Start(rctx)
+	defer adapter.Stop()
+
+	if _, err := topic.Publish(ctx, &pubsub.Message{
+		Data:       []byte("data"),
+		Attributes: map[string]string{"foo": "bar"},
+	}).Get(ctx); err != nil {
+		t.Fatalf("failed to publish message: %v", err)
+	}
+
+	var got *pubsub.Message
+	dlctx, dlcancel := context.WithCancel(rctx)
+	if err := dlSub.Receive(dlctx, func(ctx context.Context, msg *pubsub.Message) {
+		msg.Ack()
+		got = msg
+		dlcancel()
+	}); err != nil {
+		t.Fatalf("failed to receive from dead letter subscription: %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("timed out waiting for the dead lettered message")
+	}
+	if string(got.Data) != "data" {
+		t.Errorf("unexpected dead lettered data, want %q got %q", "data", string(got.Data))
+	}
+	wantAttrs := map[string]string{
+		"foo":          "bar",
+		ErrorExtension: "induced error",
+	}
+	if diff := cmp.Diff(wantAttrs, got.Attributes); diff != "" {
+		t.Errorf("unexpected dead lettered attributes (-want,+got): %v", diff)
+	}
+}
+
+func TestAdapterPermanentDeliveryFailure(t *testing.T) {
+	cases := []struct {
+		name       string
+		deadLetter bool
+	}{{
+		name: "dropped without dead letter topic",
+	}, {
+		name:       "sent to dead letter topic",
+		deadLetter: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logtest.TestContextWithLogger(t)
+
+			var requests int32
+			sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+			defer sinkSvr.Close()
+
+			c, close := testPubsubClient(ctx, t, testProjectID)
+			defer close()
+
+			topic, err := c.CreateTopic(ctx, testTopic)
+			if err != nil {
+				t.Fatalf("failed to create topic: %v", err)
+			}
+			sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+				Topic: topic,
+			})
+			if err != nil {
+				t.Fatalf("failed to create subscription: %v", err)
+			}
+			var dlt *pubsub.Topic
+			var dlSub *pubsub.Subscription
+			if tc.deadLetter {
+				if dlt, err = c.CreateTopic(ctx, "dead-letter-topic"); err != nil {
+					t.Fatalf("failed to create dead letter topic: %v", err)
+				}
+				if dlSub, err = c.CreateSubscription(ctx, "dead-letter-sub", pubsub.SubscriptionConfig{
+					Topic: dlt,
+				}); err != nil {
+					t.Fatalf("failed to create dead letter subscription: %v", err)
+				}
+			}
+
+			reporter := &statsReporterRecorder{}
+			adapter := NewAdapter(ctx,
+				clients.ProjectID(testProjectID),
+				Namespace(testNamespace),
+				Name(testName),
+				ResourceGroup(testResourceGroup),
+				sub,
+				dlt,
+				http.DefaultClient,
+				&mockConverter{converted: newSampleEvent()},
+				nil,
+				reporter,
+				&AdapterArgs{
+					TopicID:       testTopic,
+					SinkURI:       sinkSvr.URL,
+					ConverterType: converters.ConverterType(testConverterType),
+				})
+
+			rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			done := make(chan error, 1)
+			go func() { done <- adapter.Start(rctx) }()
+			defer adapter.Stop()
+
+			if _, err := topic.Publish(ctx, &pubsub.Message{
+				Data:       []byte("data"),
+				Attributes: map[string]string{"foo": "bar"},
+			}).Get(ctx); err != nil {
+				t.Fatalf("failed to publish message: %v", err)
+			}
+
+			if tc.deadLetter {
+				var got *pubsub.Message
+				dlctx, dlcancel := context.WithCancel(rctx)
+				if err := dlSub.Receive(dlctx, func(ctx context.Context, msg *pubsub.Message) {
+					msg.Ack()
+					got = msg
+					dlcancel()
+				}); err != nil {
+					t.Fatalf("failed to receive from dead letter subscription: %v", err)
+				}
+				if got == nil {
+					t.Fatal("timed out waiting for the dead lettered message")
+				}
+				wantAttrs := map[string]string{
+					"foo":          "bar",
+					ErrorExtension: "event delivery failed with status code 400",
+				}
+				if diff := cmp.Diff(wantAttrs, got.Attributes); diff != "" {
+					t.Errorf("unexpected dead lettered attributes (-want,+got): %v", diff)
+				}
+			}
+
+			// A nacked message would be redelivered to the sink right away.
+			time.Sleep(time.Second)
+			cancel()
+			<-done
+			if got := atomic.LoadInt32(&requests); got != 1 {
+				t.Errorf("unexpected number of sink requests, want 1 got %d", got)
+			}
+			wantDropped := 1
+			if tc.deadLetter {
+				wantDropped = 0
+			}
+			if len(reporter.dropped) != wantDropped {
+				t.Errorf("unexpected number of dropped events, want %d got %d", wantDropped, len(reporter.dropped))
+			}
+		})
+	}
+}
+
+func TestAdapterReply(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+
+	convertedEvent := newSampleEvent()
+	convertedEvent.SetID("converted")
+	replyEvent := convertedEvent.Clone()
+	replyEvent.SetType("reply-type")
+
+	sinkClient, err := cehttp.New()
+	if err != nil {
+		t.Fatalf("failed to create sink cloudevents client: %v", err)
+	}
+	sinkSvr := httptest.NewServer(sinkClient)
+	defer sinkSvr.Close()
+
+	replyClient, err := cehttp.New()
+	if err != nil {
+		t.Fatalf("failed to create reply cloudevents client: %v", err)
+	}
+	replySvr := httptest.NewServer(replyClient)
+	defer replySvr.Close()
+
+	c, close := testPubsubClient(ctx, t, testProjectID)
+	defer close()
+
+	topic, err := c.CreateTopic(ctx, testTopic)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+		Topic: topic,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	adapter := NewAdapter(ctx,
+		clients.ProjectID(testProjectID),
+		Namespace(testNamespace),
+		Name(testName),
+		ResourceGroup(testResourceGroup),
+		sub,
+		nil,
+		http.DefaultClient,
+		&mockConverter{converted: convertedEvent},
+		nil,
+		&statsReporterRecorder{},
+		&AdapterArgs{
+			TopicID:       testTopic,
+			SinkURI:       sinkSvr.URL,
+			ReplyURI:      replySvr.URL,
+			Extensions:    map[string]string{},
+			ConverterType: converters.ConverterType(testConverterType),
+		})
+
+	rctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	group, gctx := errgroup.WithContext(rctx)
+	group.Go(func() error { return adapter.Start(rctx) })
+	defer adapter.Stop()
+
+	group.Go(func() error {
+		msg, resp, err := sinkClient.Respond(gctx)
+		if err != nil {
+			return fmt.Errorf("unexpected error from sink receiving event: %v", err)
+		}
+		defer msg.Finish(nil)
+		if err := resp(gctx, binding.ToMessage(&replyEvent), protocol.ResultACK); err != nil {
+			return fmt.Errorf("unexpected error from sink responding event: %v", err)
+		}
+		return nil
+	})
+
+	group.Go(func() error {
+		msg, err := replyClient.Receive(gctx)
+		if err != nil {
+			return fmt.Errorf("unexpected error from reply receiving event: %v", err)
+		}
+		defer msg.Finish(nil)
+		gotEvent, err := binding.ToEvent(gctx, msg)
+		if err != nil {
+			return fmt.Errorf("reply received message that cannot be converted to an event: %v", err)
+		}
+		if diff := cmp.Diff(&replyEvent, gotEvent); diff != "" {
+			t.Errorf("reply received event (-want,+got): %v", diff)
+		}
+		return nil
+	})
+
+	if _, err := topic.Publish(ctx, &pubsub.Message{Data: []byte("data")}).Get(ctx); err != nil {
+		t.Fatalf("failed to publish message: %v", err)
+	}
+
+	if err := group.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantMetricLabels := []metricLabels{{
+		CeType:     convertedEvent.Type(),
+		CeSource:   convertedEvent.Source(),
+		StatusCode: http.StatusOK,
+	}, {
+		CeType:     replyEvent.Type(),
+		CeSource:   replyEvent.Source(),
+		StatusCode: http.StatusOK,
+	}}
+	if diff := cmp.Diff(wantMetricLabels, adapter.reporter.(*statsReporterRecorder).labels); diff != "" {
+		t.Errorf("metrics reported (-want,+got): %v", diff)
+	}
+}
+
+func TestAdapterMessageOrdering(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+	const count = 5
+
+	var (
+		mu       sync.Mutex
+		inflight int
+		received int
+	)
+	done := make(chan struct{})
+	sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inflight++
+		if inflight > 1 {
+			t.Error("messages with the same ordering key were delivered concurrently")
+		}
+		mu.Unlock()
+
+		// Give the next message a chance to be delivered concurrently.
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inflight--
+		received++
+		if received == count {
+			close(done)
+		}
+		mu.Unlock()
+	}))
+	defer sinkSvr.Close()
+
+	c, closeClient := testPubsubClient(ctx, t, testProjectID)
+	defer closeClient()
+
+	topic, err := c.CreateTopic(ctx, testTopic)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	topic.EnableMessageOrdering = true
+	sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+		Topic:                 topic,
+		EnableMessageOrdering: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	adapter := NewAdapter(ctx,
+		clients.ProjectID(testProjectID),
+		Namespace(testNamespace),
+		Name(testName),
+		ResourceGroup(testResourceGroup),
+		sub,
+		nil,
+		http.DefaultClient,
+		converters.NewPubSubConverter(),
+		nil,
+		&statsReporterRecorder{},
+		&AdapterArgs{
+			TopicID:       testTopic,
+			SinkURI:       sinkSvr.URL,
+			Extensions:    map[string]string{},
+			ConverterType: converters.PubSubPull,
+		})
+
+	rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go adapter.Start(rctx)
+	defer adapter.Stop()
+
+	// The order of delivery is up to Pub/Sub, the fake server doesn't
+	// preserve it, so only check that messages are not processed
+	// concurrently.
+	var results []*pubsub.PublishResult
+	for i := 0; i < count; i++ {
+		results = append(results, topic.Publish(ctx, &pubsub.Message{
+			Data:        []byte(fmt.Sprintf("message-%d", i)),
+			OrderingKey: "key",
+		}))
+	}
+	for _, r := range results {
+		if _, err := r.Get(ctx); err != nil {
+			t.Fatalf("failed to publish message: %v", err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-rctx.Done():
+		t.Fatal("timed out waiting for the messages")
+	}
+}
+
+func TestAdapterSendMode(t *testing.T) {
+	publishTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+
+	tests := []struct {
+		name    string
+		mode    converters.ModeType
+		checkFn func(t *testing.T, req request)
+	}{{
+		name: "binary",
+		mode: converters.Binary,
+		checkFn: func(t *testing.T, req request) {
+			if got := req.header.Get("ce-id"); got != "id" {
+				t.Errorf("ce-id header got %q want %q", got, "id")
+			}
+		},
+	}, {
+		name: "structured",
+		mode: converters.Structured,
+		checkFn: func(t *testing.T, req request) {
+			if got := req.header.Get("Content-Type"); got != cev2.ApplicationCloudEventsJSON {
+				t.Errorf("Content-Type header got %q want %q", got, cev2.ApplicationCloudEventsJSON)
+			}
+			if got := req.header.Get("ce-id"); got != "" {
+				t.Errorf("ce-id header got %q want none", got)
+			}
+			var got event.Event
+			if err := json.Unmarshal(req.body, &got); err != nil {
+				t.Fatalf("failed to unmarshal structured event: %v", err)
+			}
+			if got.ID() != "id" {
+				t.Errorf("event id got %q want %q", got.ID(), "id")
+			}
+		},
+	}, {
+		name: "push",
+		mode: converters.Push,
+		checkFn: func(t *testing.T, req request) {
+			if got := req.header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type header got %q want %q", got, "application/json")
+			}
+			for k := range req.header {
+				if strings.HasPrefix(strings.ToLower(k), "ce-") {
+					t.Errorf("unexpected CloudEvents header %q", k)
+				}
+			}
+			var got struct {
+				Subscription string `json:"subscription"`
+				Message      struct {
+					ID          string            `json:"messageId"`
+					Data        []byte            `json:"data"`
+					Attributes  map[string]string `json:"attributes"`
+					PublishTime time.Time         `json:"publishTime"`
+				} `json:"message"`
+			}
+			if err := json.Unmarshal(req.body, &got); err != nil {
+				t.Fatalf("failed to unmarshal push message: %v", err)
+			}
+			if want := fmt.Sprintf("projects/%s/subscriptions/%s", testProjectID, testSub); got.Subscription != want {
+				t.Errorf("subscription got %q want %q", got.Subscription, want)
+			}
+			if got.Message.ID == "" {
+				t.Error("message id is empty")
+			}
+			if string(got.Message.Data) != "data" {
+				t.Errorf("message data got %q want %q", got.Message.Data, "data")
+			}
+			if diff := cmp.Diff(map[string]string{"key": "value"}, got.Message.Attributes); diff != "" {
+				t.Errorf("message attributes (-want,+got): %v", diff)
+			}
+			if got.Message.PublishTime.IsZero() {
+				t.Error("message publish time is zero")
+			}
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logtest.TestContextWithLogger(t)
+
+			convertedEvent := newSampleEvent()
+			convertedEvent.SetTime(publishTime)
+
+			requests := make(chan request, 1)
+			sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("failed to read request body: %v", err)
+				}
+				select {
+				case requests <- request{header: r.Header, body: body}:
+				default:
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer sinkSvr.Close()
+
+			c, close := testPubsubClient(ctx, t, testProjectID)
+			defer close()
+
+			topic, err := c.CreateTopic(ctx, testTopic)
+			if err != nil {
+				t.Fatalf("failed to create topic: %v", err)
+			}
+			sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+				Topic: topic,
+			})
+			if err != nil {
+				t.Fatalf("failed to create subscription: %v", err)
+			}
+
+			adapter := NewAdapter(ctx,
+				clients.ProjectID(testProjectID),
+				Namespace(testNamespace),
+				Name(testName),
+				ResourceGroup(testResourceGroup),
+				sub,
+				nil,
+				http.DefaultClient,
+				&mockConverter{converted: convertedEvent},
+				nil,
+				&statsReporterRecorder{},
+				&AdapterArgs{
+					TopicID:       testTopic,
+					SinkURI:       sinkSvr.URL,
+					Extensions:    map[string]string{},
+					ConverterType: converters.ConverterType(testConverterType),
+					SendMode:      test.mode,
+				})
+
+			go adapter.Start(ctx)
+			defer adapter.Stop()
+
+			if _, err := topic.Publish(ctx, &pubsub.Message{
+				Data:       []byte("data"),
+				Attributes: map[string]string{"key": "value"},
+			}).Get(ctx); err != nil {
+				t.Fatalf("failed to publish message: %v", err)
+			}
+
+			select {
+			case req := <-requests:
+				test.checkFn(t, req)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the sink to receive a request")
+			}
+		})
+	}
+}
+
+func TestAdapterDedup(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+
+	received := make(chan string, 2)
+	sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		received <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer sinkSvr.Close()
+
+	c, close := testPubsubClient(ctx, t, testProjectID)
+	defer close()
+
+	topic, err := c.CreateTopic(ctx, testTopic)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+		Topic: topic,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	// The first message was already delivered, e.g. before its ack was lost.
+	dedup := NewMemoryDedupStore(10, time.Minute)
+	delivered, err := topic.Publish(ctx, &pubsub.Message{Data: []byte("delivered")}).Get(ctx)
+	if err != nil {
+		t.Fatalf("failed to publish message: %v", err)
+	}
+	dedup.MarkDelivered(ctx, delivered)
+	id, err := topic.Publish(ctx, &pubsub.Message{Data: []byte("new")}).Get(ctx)
+	if err != nil {
+		t.Fatalf("failed to publish message: %v", err)
+	}
+
+	adapter := NewAdapter(ctx,
+		clients.ProjectID(testProjectID),
+		Namespace(testNamespace),
+		Name(testName),
+		ResourceGroup(testResourceGroup),
+		sub,
+		nil,
+		http.DefaultClient,
+		converters.NewPubSubConverter(),
+		dedup,
+		&statsReporterRecorder{},
+		&AdapterArgs{
+			TopicID:       testTopic,
+			SinkURI:       sinkSvr.URL,
+			Extensions:    map[string]string{},
+			ConverterType: converters.PubSubPull,
+		})
+
+	go adapter.Start(ctx)
+	defer adapter.Stop()
+
+	select {
+	case got := <-received:
+		if got != "new" {
+			t.Errorf("sink received %q want %q", got, "new")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the sink to receive the new message")
+	}
+	select {
+	case got := <-received:
+		t.Errorf("sink received unexpected message %q", got)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if ok, _ := dedup.Delivered(ctx, id); !ok {
+		t.Errorf("message %q was not recorded as delivered", id)
+	}
+}
+
+func TestAdapterFilter(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+
+	received := make(chan string, 2)
+	sinkSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("ce-kind")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer sinkSvr.Close()
+
+	c, close := testPubsubClient(ctx, t, testProjectID)
+	defer close()
+
+	topic, err := c.CreateTopic(ctx, testTopic)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, testSub, pubsub.SubscriptionConfig{
+		Topic: topic,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	reporter := &statsReporterRecorder{}
+	adapter := NewAdapter(ctx,
+		clients.ProjectID(testProjectID),
+		Namespace(testNamespace),
+		Name(testName),
+		ResourceGroup(testResourceGroup),
+		sub,
+		nil,
+		http.DefaultClient,
+		converters.NewPubSubConverter(),
+		nil,
+		reporter,
+		&AdapterArgs{
+			TopicID:          testTopic,
+			SinkURI:          sinkSvr.URL,
+			Extensions:       map[string]string{},
+			ConverterType:    converters.PubSubPull,
+			FilterAttributes: map[string]string{"kind": "wanted"},
+		})
+
+	for _, kind := range []string{"unwanted", "wanted"} {
+		if _, err := topic.Publish(ctx, &pubsub.Message{
+			Data:       []byte("data"),
+			Attributes: map[string]string{"kind": kind},
+		}).Get(ctx); err != nil {
+			t.Fatalf("failed to publish message: %v", err)
+		}
+	}
+
+	go adapter.Start(ctx)
+	defer adapter.Stop()
+
+	select {
+	case got := <-received:
+		if got != "wanted" {
+			t.Errorf("sink received kind %q want %q", got, "wanted")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the sink to receive the wanted message")
+	}
+	select {
+	case got := <-received:
+		t.Errorf("sink received unexpected message of kind %q", got)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	wantDropped := []metricLabels{{
+		CeType:   schemasv1.CloudPubSubMessagePublishedEventType,
+		CeSource: schemasv1.CloudPubSubEventSource(testProjectID, testTopic),
+	}}
+	if diff := cmp.Diff(wantDropped, reporter.dropped); diff != "" {
+		t.Errorf("dropped metrics reported (-want,+got): %v", diff)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+)
+
+// batchEntry is a received Pub/Sub message together with the event it was converted to.
+type batchEntry struct {
+	msg   *pubsub.Message
+	event *cev2.Event
+}
+
+// batch is a set of entries waiting to be delivered together.
+type batch struct {
+	entries []batchEntry
+	timer   *time.Timer
+}
+
+// batcher accumulates events until either maxEvents events were added or
+// maxDelay elapsed since the first event of the batch was added, and then
+// hands the batch over to send.
+type batcher struct {
+	maxEvents int
+	maxDelay  time.Duration
+	send      func(ctx context.Context, entries []batchEntry)
+
+	mu      sync.Mutex
+	current *batch
+}
+
+func newBatcher(maxEvents int, maxDelay time.Duration, send func(context.Context, []batchEntry)) *batcher {
+	return &batcher{
+		maxEvents: maxEvents,
+		maxDelay:  maxDelay,
+		send:      send,
+	}
+}
+
+// add adds an entry to the current batch. If the batch becomes full, it is
+// sent synchronously on the caller's goroutine.
+func (b *batcher) add(ctx context.Context, entry batchEntry) {
+	b.mu.Lock()
+	if b.current == nil {
+		bt := &batch{}
+		bt.timer = time.AfterFunc(b.maxDelay, func() { b.flush(ctx, bt) })
+		b.current = bt
+	}
+	bt := b.current
+	bt.entries = append(bt.entries, entry)
+	if len(bt.entries) < b.maxEvents {
+		b.mu.Unlock()
+		return
+	}
+	b.current = nil
+	b.mu.Unlock()
+
+	bt.timer.Stop()
+	b.send(ctx, bt.entries)
+}
+
+// flush sends bt if it is still the current batch, i.e. it was not already
+// sent because it became full.
+func (b *batcher) flush(ctx context.Context, bt *batch) {
+	b.mu.Lock()
+	if b.current != bt {
+		b.mu.Unlock()
+		return
+	}
+	b.current = nil
+	b.mu.Unlock()
+
+	b.send(ctx, bt.entries)
+}
+
+// receiveBatched converts msg and adds it to the current batch. The message
+// is acked or nacked once its batch is delivered.
+func (a *Adapter) receiveBatched(ctx context.Context, msg *pubsub.Message) {
+	if a.isDuplicate(ctx, msg) {
+		return
+	}
+
+	event, err := a.converter.Convert(ctx, msg, a.args.ConverterType)
+	if err != nil {
+		a.handleConversionFailure(ctx, msg, err)
+		return
+	}
+	if a.dropEvent(ctx, msg, event) {
+		return
+	}
+
+	// Apply CloudEvent override extensions to the outbound event.
+	for k, v := range a.args.Extensions {
+		event.SetExtension(k, v)
+	}
+
+	a.batcher.add(ctx, batchEntry{msg: msg, event: event})
+}
+
+// sendBatch delivers entries to the sink as a single CloudEvents batch, and
+// acks or nacks all their messages based on the sink response.
+func (a *Adapter) sendBatch(ctx context.Context, entries []batchEntry) {
+	// Parent the span on the first event of the batch.
+	ctx, span := a.startSpan(ctx, entries[0].event)
+	defer span.End()
+
+	events := make([]*cev2.Event, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, e.event)
+	}
+
+	response, err := a.sendWithRetries(ctx, func() (*nethttp.Response, error) {
+		return a.sendBatchRequest(ctx, events)
+	})
+	if err != nil {
+		a.logger.Error("Failed to send batch to sink", zap.String("address", a.args.SinkURI), zap.Int("size", len(entries)), zap.Error(err))
+		nackAll(entries)
+		return
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			a.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+
+	for _, e := range entries {
+		a.reporter.ReportEventCount(&ReportArgs{
+			EventType:   e.event.Type(),
+			EventSource: e.event.Source(),
+		}, response.StatusCode)
+	}
+
+	if response.StatusCode/100 != 2 {
+		a.logger.Error("Batch delivery failed", zap.Int("StatusCode", response.StatusCode), zap.Int("size", len(entries)))
+		nackAll(entries)
+		return
+	}
+
+	for _, e := range entries {
+		a.ackDelivered(ctx, e.msg)
+	}
+}
+
+func (a *Adapter) sendBatchRequest(ctx context.Context, events []*cev2.Event) (*nethttp.Response, error) {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, a.args.SinkURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", cev2.ApplicationCloudEventsBatchJSON)
+	return a.outbound.Do(req)
+}
+
+func nackAll(entries []batchEntry) {
+	for _, e := range entries {
+		e.msg.Nack()
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+const (
+	imageInsertAction = "INSERT"
+	imageDeleteAction = "DELETE"
+)
+
+// imageNotification is the payload of the Pub/Sub notifications of Artifact
+// Registry and Container Registry. Either the digest or the tag may be missing,
+// e.g. when an untagged image is pushed or when only a tag is deleted.
+type imageNotification struct {
+	Action string `json:"action"`
+	Digest string `json:"digest"`
+	Tag    string `json:"tag"`
+}
+
+func convertCloudArtifactRegistry(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	var n imageNotification
+	if err := json.Unmarshal(msg.Data, &n); err != nil {
+		return nil, fmt.Errorf("failed to decode image notification: %w", err)
+	}
+	if n.Digest == "" && n.Tag == "" {
+		return nil, errors.New("received event did not have digest nor tag")
+	}
+
+	project, err := GetProjectKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID(msg.ID)
+	event.SetTime(msg.PublishTime)
+	event.SetSource(schemasv1.CloudArtifactRegistryEventSource(project))
+	switch n.Action {
+	case imageInsertAction:
+		event.SetType(schemasv1.CloudArtifactRegistryImageInsertedEventType)
+	case imageDeleteAction:
+		event.SetType(schemasv1.CloudArtifactRegistryImageDeletedEventType)
+	default:
+		return nil, fmt.Errorf("received event had unknown action %q", n.Action)
+	}
+
+	// The digest identifies the image content, the tag only identifies it
+	// until it is moved.
+	if n.Digest != "" {
+		event.SetSubject(n.Digest)
+		event.SetExtension(schemasv1.CloudArtifactRegistryDigestExtension, n.Digest)
+	} else {
+		event.SetSubject(n.Tag)
+	}
+	if n.Tag != "" {
+		event.SetExtension(schemasv1.CloudArtifactRegistryTagExtension, n.Tag)
+	}
+
+	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+const (
+	imageDigest = "gcr.io/my-project/hello-world@sha256:6ec128e26cd5d7ce2ae32fed7ac3bc3f6a8d42ed47b3e5c1a9b6f8d1b8a6f3e1"
+	imageTag    = "gcr.io/my-project/hello-world:1.1"
+
+	imageInsertData       = `{"action":"INSERT","digest":"` + imageDigest + `","tag":"` + imageTag + `"}`
+	imageInsertDigestData = `{"action":"INSERT","digest":"` + imageDigest + `"}`
+	imageDeleteTagData    = `{"action":"DELETE","tag":"` + imageTag + `"}`
+)
+
+func TestConvertCloudArtifactRegistry(t *testing.T) {
+	publishTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		data        string
+		wantEventFn func() *cev2.Event
+		wantErr     bool
+	}{{
+		name: "image inserted",
+		data: imageInsertData,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudArtifactRegistryImageInsertedEventType)
+			e.SetSource("//artifactregistry.googleapis.com/projects/testproject")
+			e.SetSubject(imageDigest)
+			e.SetExtension(schemasv1.CloudArtifactRegistryDigestExtension, imageDigest)
+			e.SetExtension(schemasv1.CloudArtifactRegistryTagExtension, imageTag)
+			e.SetData(cev2.ApplicationJSON, []byte(imageInsertData))
+			return &e
+		},
+	}, {
+		name: "untagged image inserted",
+		data: imageInsertDigestData,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudArtifactRegistryImageInsertedEventType)
+			e.SetSource("//artifactregistry.googleapis.com/projects/testproject")
+			e.SetSubject(imageDigest)
+			e.SetExtension(schemasv1.CloudArtifactRegistryDigestExtension, imageDigest)
+			e.SetData(cev2.ApplicationJSON, []byte(imageInsertDigestData))
+			return &e
+		},
+	}, {
+		name: "tag deleted",
+		data: imageDeleteTagData,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudArtifactRegistryImageDeletedEventType)
+			e.SetSource("//artifactregistry.googleapis.com/projects/testproject")
+			e.SetSubject(imageTag)
+			e.SetExtension(schemasv1.CloudArtifactRegistryTagExtension, imageTag)
+			e.SetData(cev2.ApplicationJSON, []byte(imageDeleteTagData))
+			return &e
+		},
+	}, {
+		name:    "unknown action",
+		data:    `{"action":"UPDATE","digest":"` + imageDigest + `"}`,
+		wantErr: true,
+	}, {
+		name:    "no digest nor tag",
+		data:    `{"action":"INSERT"}`,
+		wantErr: true,
+	}, {
+		name:    "not JSON",
+		data:    "test data",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := &pubsub.Message{
+				ID:          "id",
+				PublishTime: publishTime,
+				Data:        []byte(test.data),
+			}
+			ctx := WithProjectKey(context.Background(), "testproject")
+			gotEvent, err := NewPubSubConverter().Convert(ctx, msg, CloudArtifactRegistry)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("converter.Convert got error %v want error=%v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.wantEventFn(), gotEvent); diff != "" {
+				t.Errorf("converter.Convert got unexpected cloudevents.Event (-want +got) %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+// budgetNotification is the part of the payload of the Pub/Sub notifications
+// of Cloud Billing budgets needed to build the events.
+type budgetNotification struct {
+	AlertThresholdExceeded    *float64 `json:"alertThresholdExceeded"`
+	ForecastThresholdExceeded *float64 `json:"forecastThresholdExceeded"`
+}
+
+func convertCloudBillingBudget(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	billingAccountID, ok := msg.Attributes[schemasv1.CloudBillingBudgetBillingAccountID]
+	if !ok {
+		return nil, fmt.Errorf("received event did not have %s", schemasv1.CloudBillingBudgetBillingAccountID)
+	}
+	budgetID, ok := msg.Attributes[schemasv1.CloudBillingBudgetBudgetID]
+	if !ok {
+		return nil, fmt.Errorf("received event did not have %s", schemasv1.CloudBillingBudgetBudgetID)
+	}
+	var n budgetNotification
+	if err := json.Unmarshal(msg.Data, &n); err != nil {
+		return nil, fmt.Errorf("failed to decode budget notification: %w", err)
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	// Budgets are notified several times a day, the message ID is what
+	// identifies the event.
+	event.SetID(msg.ID)
+	event.SetTime(msg.PublishTime)
+	event.SetSource(schemasv1.CloudBillingBudgetEventSource(billingAccountID))
+	event.SetSubject(schemasv1.CloudBillingBudgetEventSubject(budgetID))
+
+	// The notifications keep on holding the highest threshold exceeded until
+	// the end of the budget period.
+	event.SetType(schemasv1.CloudBillingBudgetCostUpdatedEventType)
+	if n.AlertThresholdExceeded != nil {
+		event.SetType(schemasv1.CloudBillingBudgetThresholdExceededEventType)
+		event.SetExtension(schemasv1.CloudBillingBudgetThresholdExtension, formatThreshold(*n.AlertThresholdExceeded))
+	}
+	if n.ForecastThresholdExceeded != nil {
+		event.SetType(schemasv1.CloudBillingBudgetThresholdExceededEventType)
+		event.SetExtension(schemasv1.CloudBillingBudgetForecastThresholdExtension, formatThreshold(*n.ForecastThresholdExceeded))
+	}
+	if v, ok := msg.Attributes[schemasv1.CloudBillingBudgetSchemaVersion]; ok {
+		event.SetExtension(schemasv1.CloudBillingBudgetSchemaVersionExtension, v)
+	}
+
+	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// formatThreshold formats a threshold without trailing zeros, e.g. 0.5 or 1.
+func formatThreshold(threshold float64) string {
+	return strconv.FormatFloat(threshold, 'f', -1, 64)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+const (
+	budgetCostData = `{"budgetDisplayName":"My budget","costAmount":140.32,"costIntervalStart":"2020-10-01T07:00:00Z",` +
+		`"budgetAmount":152.0,"budgetAmountType":"SPECIFIED_AMOUNT","currencyCode":"USD"}`
+	budgetThresholdData = `{"budgetDisplayName":"My budget","alertThresholdExceeded":0.9,"costAmount":140.32,` +
+		`"costIntervalStart":"2020-10-01T07:00:00Z","budgetAmount":152.0,"budgetAmountType":"SPECIFIED_AMOUNT","currencyCode":"USD"}`
+	budgetForecastData = `{"budgetDisplayName":"My budget","forecastThresholdExceeded":1.0,"costAmount":140.32,` +
+		`"costIntervalStart":"2020-10-01T07:00:00Z","budgetAmount":152.0,"budgetAmountType":"SPECIFIED_AMOUNT","currencyCode":"USD"}`
+)
+
+func TestConvertCloudBillingBudget(t *testing.T) {
+	publishTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	budgetAttributes := map[string]string{
+		"billingAccountId": "012345-6789AB-CDEF01",
+		"budgetId":         "budget-id",
+		"schemaVersion":    "1.0",
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		attributes  map[string]string
+		wantEventFn func() *cev2.Event
+		wantErr     bool
+	}{{
+		name:       "cost updated",
+		data:       budgetCostData,
+		attributes: budgetAttributes,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudBillingBudgetCostUpdatedEventType)
+			e.SetSource("//billingbudgets.googleapis.com/billingAccounts/012345-6789AB-CDEF01")
+			e.SetSubject("budgets/budget-id")
+			e.SetExtension(schemasv1.CloudBillingBudgetSchemaVersionExtension, "1.0")
+			e.SetData(cev2.ApplicationJSON, []byte(budgetCostData))
+			return &e
+		},
+	}, {
+		name:       "threshold exceeded",
+		data:       budgetThresholdData,
+		attributes: budgetAttributes,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudBillingBudgetThresholdExceededEventType)
+			e.SetSource("//billingbudgets.googleapis.com/billingAccounts/012345-6789AB-CDEF01")
+			e.SetSubject("budgets/budget-id")
+			e.SetExtension(schemasv1.CloudBillingBudgetThresholdExtension, "0.9")
+			e.SetExtension(schemasv1.CloudBillingBudgetSchemaVersionExtension, "1.0")
+			e.SetData(cev2.ApplicationJSON, []byte(budgetThresholdData))
+			return &e
+		},
+	}, {
+		name: "forecast threshold exceeded, without schema version",
+		data: budgetForecastData,
+		attributes: map[string]string{
+			"billingAccountId": "012345-6789AB-CDEF01",
+			"budgetId":         "budget-id",
+		},
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudBillingBudgetThresholdExceededEventType)
+			e.SetSource("//billingbudgets.googleapis.com/billingAccounts/012345-6789AB-CDEF01")
+			e.SetSubject("budgets/budget-id")
+			e.SetExtension(schemasv1.CloudBillingBudgetForecastThresholdExtension, "1")
+			e.SetData(cev2.ApplicationJSON, []byte(budgetForecastData))
+			return &e
+		},
+	}, {
+		name: "no billing account",
+		data: budgetCostData,
+		attributes: map[string]string{
+			"budgetId": "budget-id",
+		},
+		wantErr: true,
+	}, {
+		name: "no budget",
+		data: budgetCostData,
+		attributes: map[string]string{
+			"billingAccountId": "012345-6789AB-CDEF01",
+		},
+		wantErr: true,
+	}, {
+		name:       "not JSON",
+		data:       "test data",
+		attributes: budgetAttributes,
+		wantErr:    true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := &pubsub.Message{
+				ID:          "id",
+				PublishTime: publishTime,
+				Data:        []byte(test.data),
+				Attributes:  test.attributes,
+			}
+			gotEvent, err := NewPubSubConverter().Convert(context.Background(), msg, CloudBillingBudget)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("converter.Convert got error %v want error=%v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.wantEventFn(), gotEvent); diff != "" {
+				t.Errorf("converter.Convert got unexpected cloudevents.Event (-want +got) %s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package converters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"cloud.google.com/go/pubsub"
@@ -52,6 +53,16 @@ func convertCloudBuild(ctx context.Context, msg *pubsub.Message) (*cev2.Event, e
 		return nil, errors.New("received event did not have build status")
 	} else {
 		event.SetSubject(buildStatus)
+		event.SetExtension(schemasv1.CloudBuildSourceStatusExtension, buildStatus)
+	}
+
+	// The build trigger is only in the payload, which is parsed on a
+	// best-effort basis, as the extension is not required.
+	var build struct {
+		BuildTriggerID string `json:"buildTriggerId"`
+	}
+	if err := json.Unmarshal(msg.Data, &build); err == nil && build.BuildTriggerID != "" {
+		event.SetExtension(schemasv1.CloudBuildSourceTriggerIDExtension, build.BuildTriggerID)
 	}
 
 	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
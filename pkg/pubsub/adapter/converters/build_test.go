@@ -27,21 +27,24 @@ import (
 )
 
 const (
-	buildID     = "c9k3e360-0b36-4df9-b909-3d7810e37a49"
-	buildStatus = "SUCCESS"
+	buildID        = "c9k3e360-0b36-4df9-b909-3d7810e37a49"
+	buildStatus    = "SUCCESS"
+	buildTriggerID = "0e5a4f3c-2b1d-4e8a-9c7b-6d5e4f3a2b1c"
 )
 
 var (
-	buildPublishTime = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
-	data             = []byte("test data")
+	buildPublishTime   = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	data               = []byte("test data")
+	triggeredBuildData = []byte(`{"id":"` + buildID + `","status":"SUCCESS","buildTriggerId":"` + buildTriggerID + `"}`)
 )
 
 func TestConvertCloudBuild(t *testing.T) {
 
 	tests := []struct {
-		name    string
-		message *pubsub.Message
-		wantErr bool
+		name          string
+		message       *pubsub.Message
+		wantTriggerID string
+		wantErr       bool
 	}{{
 		name: "valid event",
 		message: &pubsub.Message{
@@ -56,6 +59,19 @@ func TestConvertCloudBuild(t *testing.T) {
 			},
 		},
 	},
+		{
+			name: "valid event with trigger",
+			message: &pubsub.Message{
+				ID:          "id",
+				PublishTime: buildPublishTime,
+				Data:        triggeredBuildData,
+				Attributes: map[string]string{
+					"buildId": buildID,
+					"status":  buildStatus,
+				},
+			},
+			wantTriggerID: buildTriggerID,
+		},
 		{
 			name: "no buildId attributes",
 			message: &pubsub.Message{
@@ -113,8 +129,14 @@ func TestConvertCloudBuild(t *testing.T) {
 				if gotEvent.DataSchema() != buildSchemaUrl {
 					t.Errorf("DataSchema %q != %q", gotEvent.DataSchema(), buildSchemaUrl)
 				}
-				if !bytes.Equal(gotEvent.Data(), data) {
-					t.Errorf("Data %q != %q", gotEvent.Data(), data)
+				if !bytes.Equal(gotEvent.Data(), test.message.Data) {
+					t.Errorf("Data %q != %q", gotEvent.Data(), test.message.Data)
+				}
+				if got := gotEvent.Extensions()[schemasv1.CloudBuildSourceStatusExtension]; got != buildStatus {
+					t.Errorf("Status extension %q != %q", got, buildStatus)
+				}
+				if got, _ := gotEvent.Extensions()[schemasv1.CloudBuildSourceTriggerIDExtension].(string); got != test.wantTriggerID {
+					t.Errorf("Trigger ID extension %q != %q", got, test.wantTriggerID)
 				}
 			}
 		})
@@ -44,12 +44,18 @@ type ConverterType string
 
 const (
 	// The different type of Converters for the different sources.
-	CloudPubSub    ConverterType = "pubsub"
-	CloudStorage   ConverterType = "storage"
-	CloudAuditLogs ConverterType = "auditlogs"
-	CloudScheduler ConverterType = "scheduler"
-	CloudBuild     ConverterType = "build"
-	PubSubPull     ConverterType = "pubsub_pull"
+	CloudPubSub           ConverterType = "pubsub"
+	CloudStorage          ConverterType = "storage"
+	CloudAuditLogs        ConverterType = "auditlogs"
+	CloudLogging          ConverterType = "logging"
+	CloudScheduler        ConverterType = "scheduler"
+	CloudBuild            ConverterType = "build"
+	CloudMonitoring       ConverterType = "monitoring"
+	CloudBillingBudget    ConverterType = "billingbudget"
+	CloudArtifactRegistry ConverterType = "artifactregistry"
+	PubSubPull            ConverterType = "pubsub_pull"
+	// Custom converts messages as declared by a PullSubscription.
+	Custom ConverterType = "custom"
 )
 
 type converterFn func(context.Context, *pubsub.Message) (*cev2.Event, error)
@@ -71,12 +77,16 @@ type PubSubConverter struct {
 func NewPubSubConverter() Converter {
 	return &PubSubConverter{
 		converters: map[ConverterType]converterFn{
-			CloudPubSub:    convertCloudPubSub,
-			CloudAuditLogs: convertCloudAuditLogs,
-			CloudStorage:   convertCloudStorage,
-			CloudScheduler: convertCloudScheduler,
-			CloudBuild:     convertCloudBuild,
-			PubSubPull:     convertPubSubPull,
+			CloudPubSub:           convertCloudPubSub,
+			CloudAuditLogs:        convertCloudAuditLogs,
+			CloudLogging:          convertCloudLogging,
+			CloudStorage:          convertCloudStorage,
+			CloudScheduler:        convertCloudScheduler,
+			CloudBuild:            convertCloudBuild,
+			CloudMonitoring:       convertCloudMonitoring,
+			CloudBillingBudget:    convertCloudBillingBudget,
+			CloudArtifactRegistry: convertCloudArtifactRegistry,
+			PubSubPull:            convertPubSubPull,
 		},
 	}
 }
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	. "github.com/cloudevents/sdk-go/v2/event"
+
+	intereventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+)
+
+// customMessage is the data the templates of a custom converter are executed
+// against.
+type customMessage struct {
+	ID          string
+	PublishTime time.Time
+	Attributes  map[string]string
+	OrderingKey string
+	Project     string
+	Topic       string
+}
+
+// customConverter converts messages as declared by a ConverterSpec.
+type customConverter struct {
+	typ             *template.Template
+	source          *template.Template
+	subject         *template.Template
+	attributes      map[string]string
+	dataPath        []string
+	dataContentType string
+}
+
+// NewCustomPubSubConverter returns a Converter that, on top of the built-in
+// converters, converts messages as declared by spec for the Custom type.
+func NewCustomPubSubConverter(spec *intereventsv1.ConverterSpec) (Converter, error) {
+	cc, err := newCustomConverter(spec)
+	if err != nil {
+		return nil, err
+	}
+	c := NewPubSubConverter().(*PubSubConverter)
+	c.converters[Custom] = cc.convert
+	return c, nil
+}
+
+func newCustomConverter(spec *intereventsv1.ConverterSpec) (*customConverter, error) {
+	cc := &customConverter{
+		attributes:      spec.Attributes,
+		dataContentType: spec.DataContentType,
+	}
+	var err error
+	if cc.typ, err = parseTemplate("type", spec.Type); err != nil {
+		return nil, err
+	}
+	if cc.source, err = parseTemplate("source", spec.Source); err != nil {
+		return nil, err
+	}
+	if spec.Subject != "" {
+		if cc.subject, err = parseTemplate("subject", spec.Subject); err != nil {
+			return nil, err
+		}
+	}
+	if spec.DataPath != "" {
+		cc.dataPath = strings.Split(spec.DataPath, ".")
+	}
+	if cc.dataContentType == "" {
+		if cc.dataPath != nil {
+			cc.dataContentType = cev2.ApplicationJSON
+		} else {
+			cc.dataContentType = "application/octet-stream"
+		}
+	}
+	return cc, nil
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	// Missing attributes render as empty strings rather than "<no value>".
+	t, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return t, nil
+}
+
+func (c *customConverter) convert(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	project, err := GetProjectKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := GetTopicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &customMessage{
+		ID:          msg.ID,
+		PublishTime: msg.PublishTime,
+		Attributes:  msg.Attributes,
+		OrderingKey: msg.OrderingKey,
+		Project:     project,
+		Topic:       topic,
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID(msg.ID)
+	event.SetTime(msg.PublishTime)
+
+	typ, err := execute(c.typ, m)
+	if err != nil {
+		return nil, err
+	}
+	event.SetType(typ)
+	source, err := execute(c.source, m)
+	if err != nil {
+		return nil, err
+	}
+	event.SetSource(source)
+	if c.subject != nil {
+		subject, err := execute(c.subject, m)
+		if err != nil {
+			return nil, err
+		}
+		if subject != "" {
+			event.SetSubject(subject)
+		}
+	}
+
+	for k, v := range msg.Attributes {
+		name, ok := c.attributes[k]
+		if !ok {
+			// Unmapped attributes are promoted to extensions on a best-effort
+			// basis, as third-party publishers often use names that are not
+			// valid extension names.
+			if IsAlphaNumeric(k) {
+				event.SetExtension(k, v)
+			}
+			continue
+		}
+		if err := setAttribute(&event, name, v); err != nil {
+			return nil, fmt.Errorf("cannot map attribute %q to %q: %w", k, name, err)
+		}
+	}
+
+	data := msg.Data
+	if c.dataPath != nil {
+		if data, err = extractData(msg.Data, c.dataPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := event.SetData(c.dataContentType, data); err != nil {
+		return nil, err
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func execute(t *template.Template, m *customMessage) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, m); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %w", t.Name(), err)
+	}
+	return b.String(), nil
+}
+
+// setAttribute sets the CloudEvent attribute name to value. Attributes other
+// than the mappable context attributes are extensions.
+func setAttribute(event *cev2.Event, name, value string) error {
+	switch name {
+	case "id":
+		event.SetID(value)
+	case "subject":
+		event.SetSubject(value)
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+		event.SetTime(t)
+	case "dataschema":
+		event.SetDataSchema(value)
+	default:
+		event.SetExtension(name, value)
+	}
+	return nil
+}
+
+// extractData returns the JSON encoding of the field at path in the JSON
+// payload.
+func extractData(payload []byte, path []string) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil, fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+	for i, f := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("payload field %q is not found, its parent is not an object", strings.Join(path[:i+1], "."))
+		}
+		if v, ok = obj[f]; !ok {
+			return nil, fmt.Errorf("payload field %q is not found", strings.Join(path[:i+1], "."))
+		}
+	}
+	return json.Marshal(v)
+}
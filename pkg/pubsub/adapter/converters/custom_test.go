@@ -0,0 +1,208 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+
+	intereventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+)
+
+func TestConvertCustom(t *testing.T) {
+	publishTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	eventTime := time.Date(2020, 10, 1, 11, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		spec        *intereventsv1.ConverterSpec
+		message     *pubsub.Message
+		wantEventFn func() *cev2.Event
+		wantErr     bool
+	}{{
+		name: "templates and raw data",
+		spec: &intereventsv1.ConverterSpec{
+			Type:    "com.example.{{.Attributes.kind}}",
+			Source:  "//example.com/projects/{{.Project}}/topics/{{.Topic}}",
+			Subject: "{{index .Attributes \"object-id\"}}",
+		},
+		message: &pubsub.Message{
+			ID:          "id",
+			Data:        []byte("test data"),
+			PublishTime: publishTime,
+			Attributes: map[string]string{
+				"kind":      "created",
+				"object-id": "obj",
+			},
+		},
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType("com.example.created")
+			e.SetSource("//example.com/projects/testproject/topics/testtopic")
+			e.SetSubject("obj")
+			// object-id is not a valid extension name, so it is dropped.
+			e.SetExtension("kind", "created")
+			e.SetData("application/octet-stream", []byte("test data"))
+			return &e
+		},
+	}, {
+		name: "mapped attributes",
+		spec: &intereventsv1.ConverterSpec{
+			Type:   "type",
+			Source: "source",
+			Attributes: map[string]string{
+				"event-id":   "id",
+				"event-time": "time",
+				"schema":     "dataschema",
+				"trace-id":   "traceid",
+			},
+			DataContentType: "text/plain",
+		},
+		message: &pubsub.Message{
+			ID:          "id",
+			Data:        []byte("test data"),
+			PublishTime: publishTime,
+			Attributes: map[string]string{
+				"event-id":   "event",
+				"event-time": eventTime.Format(time.RFC3339),
+				"schema":     "https://example.com/schema",
+				"trace-id":   "trace",
+			},
+		},
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("event")
+			e.SetTime(eventTime)
+			e.SetType("type")
+			e.SetSource("source")
+			e.SetDataSchema("https://example.com/schema")
+			e.SetExtension("traceid", "trace")
+			e.SetData("text/plain", []byte("test data"))
+			return &e
+		},
+	}, {
+		name: "extracted data",
+		spec: &intereventsv1.ConverterSpec{
+			Type:     "type",
+			Source:   "source",
+			DataPath: "payload.record",
+		},
+		message: &pubsub.Message{
+			ID:          "id",
+			Data:        []byte(`{"payload":{"record":{"foo":"bar"}}}`),
+			PublishTime: publishTime,
+		},
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType("type")
+			e.SetSource("source")
+			e.SetData(cev2.ApplicationJSON, []byte(`{"foo":"bar"}`))
+			return &e
+		},
+	}, {
+		name: "data path not found",
+		spec: &intereventsv1.ConverterSpec{
+			Type:     "type",
+			Source:   "source",
+			DataPath: "payload.record",
+		},
+		message: &pubsub.Message{
+			ID:   "id",
+			Data: []byte(`{"payload":"record"}`),
+		},
+		wantErr: true,
+	}, {
+		name: "data not JSON",
+		spec: &intereventsv1.ConverterSpec{
+			Type:     "type",
+			Source:   "source",
+			DataPath: "payload",
+		},
+		message: &pubsub.Message{
+			ID:   "id",
+			Data: []byte("test data"),
+		},
+		wantErr: true,
+	}, {
+		name: "invalid mapped time",
+		spec: &intereventsv1.ConverterSpec{
+			Type:       "type",
+			Source:     "source",
+			Attributes: map[string]string{"event-time": "time"},
+		},
+		message: &pubsub.Message{
+			ID:         "id",
+			Data:       []byte("test data"),
+			Attributes: map[string]string{"event-time": "yesterday"},
+		},
+		wantErr: true,
+	}, {
+		name: "empty type",
+		spec: &intereventsv1.ConverterSpec{
+			Type:   "{{.Attributes.kind}}",
+			Source: "source",
+		},
+		message: &pubsub.Message{
+			ID:   "id",
+			Data: []byte("test data"),
+		},
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := WithProjectKey(context.Background(), "testproject")
+			ctx = WithTopicKey(ctx, "testtopic")
+			ctx = WithSubscriptionKey(ctx, "testsubscription")
+
+			c, err := NewCustomPubSubConverter(test.spec)
+			if err != nil {
+				t.Fatalf("NewCustomPubSubConverter failed: %v", err)
+			}
+			gotEvent, err := c.Convert(ctx, test.message, Custom)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("converter.Convert got error %v want error=%v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.wantEventFn(), gotEvent); diff != "" {
+				t.Errorf("converter.Convert got unexpected cloudevents.Event (-want +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewCustomPubSubConverterInvalidTemplate(t *testing.T) {
+	_, err := NewCustomPubSubConverter(&intereventsv1.ConverterSpec{
+		Type:   "{{.Attributes.kind",
+		Source: "source",
+	})
+	if err == nil {
+		t.Error("NewCustomPubSubConverter got nil error, want error")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/protobuf/ptypes"
+	logpb "google.golang.org/genproto/googleapis/logging/v2"
+
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+// convertCloudLogging converts any LogEntry exported by a Cloud Logging sink,
+// whatever its payload, into a logEntry written event. The LogEntry is
+// forwarded as is as the event data.
+func convertCloudLogging(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	entry := logpb.LogEntry{}
+	if err := jsonpbUnmarshaller.Unmarshal(bytes.NewReader(msg.Data), &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode LogEntry: %w", err)
+	}
+
+	if parentResourceRegexp.FindString(entry.LogName) == "" {
+		return nil, fmt.Errorf("invalid LogName: %q", entry.LogName)
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID(schemasv1.CloudLoggingEventID(entry.InsertId, entry.LogName, ptypes.TimestampString(entry.Timestamp)))
+	if timestamp, err := ptypes.Timestamp(entry.Timestamp); err != nil {
+		return nil, fmt.Errorf("invalid LogEntry timestamp: %w", err)
+	} else {
+		event.SetTime(timestamp)
+	}
+	event.SetType(schemasv1.CloudLoggingLogEntryWrittenEventType)
+	event.SetSource(schemasv1.CloudLoggingEventSource(entry.LogName))
+	if entry.Resource != nil && entry.Resource.Type != "" {
+		event.SetSubject(entry.Resource.Type)
+	}
+	event.SetDataSchema(schemasv1.CloudLoggingEventDataSchema)
+	event.SetExtension(schemasv1.SeverityExtension, entry.Severity.String())
+	event.SetData(cev2.ApplicationJSON, msg.Data)
+	return &event, nil
+}
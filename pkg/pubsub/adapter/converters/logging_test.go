@@ -0,0 +1,141 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+	monitoredres "google.golang.org/genproto/googleapis/api/monitoredres"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	logpb "google.golang.org/genproto/googleapis/logging/v2"
+)
+
+func TestConvertCloudLogging(t *testing.T) {
+	testTime, err := time.Parse(time.RFC3339, testTs)
+	if err != nil {
+		t.Fatalf("Unable to parse test timestamp: %q", err)
+	}
+	ts, err := ptypes.TimestampProto(testTime)
+	if err != nil {
+		t.Fatalf("Invalid test timestamp: %q", err)
+	}
+
+	tests := []struct {
+		name           string
+		entry          *logpb.LogEntry
+		wantSubject    string
+		wantExtensions map[string]interface{}
+		wantErr        bool
+	}{{
+		name: "text payload",
+		entry: &logpb.LogEntry{
+			InsertId:  insertID,
+			LogName:   "projects/test-project/logs/test-log",
+			Timestamp: ts,
+			Resource:  &monitoredres.MonitoredResource{Type: "gce_instance"},
+			Severity:  ltype.LogSeverity_ERROR,
+			Payload: &logpb.LogEntry_TextPayload{
+				TextPayload: "test payload",
+			},
+		},
+		wantSubject:    "gce_instance",
+		wantExtensions: map[string]interface{}{"severity": "ERROR"},
+	}, {
+		name: "audit log",
+		entry: func() *logpb.LogEntry {
+			payload, err := ptypes.MarshalAny(&auditpb.AuditLog{ServiceName: "pubsub.googleapis.com"})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto payload: %v", err)
+			}
+			return &logpb.LogEntry{
+				InsertId:  insertID,
+				LogName:   logName,
+				Timestamp: ts,
+				Payload:   &logpb.LogEntry_ProtoPayload{ProtoPayload: payload},
+			}
+		}(),
+		wantExtensions: map[string]interface{}{"severity": "DEFAULT"},
+	}, {
+		name: "invalid log name",
+		entry: &logpb.LogEntry{
+			InsertId:  insertID,
+			LogName:   "test-log",
+			Timestamp: ts,
+		},
+		wantErr: true,
+	}, {
+		name: "missing timestamp",
+		entry: &logpb.LogEntry{
+			InsertId: insertID,
+			LogName:  "projects/test-project/logs/test-log",
+		},
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := new(jsonpb.Marshaler).Marshal(&buf, test.entry); err != nil {
+				t.Fatalf("Failed to marshal LogEntry pb: %v", err)
+			}
+			msg := pubsub.Message{
+				Data: buf.Bytes(),
+			}
+
+			e, err := NewPubSubConverter().Convert(context.Background(), &msg, CloudLogging)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("converter.Convert got error %v want error=%v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if id := schemasv1.CloudLoggingEventID(insertID, test.entry.LogName, testTs); e.ID() != id {
+				t.Errorf("ID '%s' != '%s'", e.ID(), id)
+			}
+			if !e.Time().Equal(testTime) {
+				t.Errorf("Time '%v' != '%v'", e.Time(), testTime)
+			}
+			if want := schemasv1.CloudLoggingEventSource(test.entry.LogName); e.Source() != want {
+				t.Errorf("Source %q != %q", e.Source(), want)
+			}
+			if e.Type() != schemasv1.CloudLoggingLogEntryWrittenEventType {
+				t.Errorf("Type %q != %q", e.Type(), schemasv1.CloudLoggingLogEntryWrittenEventType)
+			}
+			if e.Subject() != test.wantSubject {
+				t.Errorf("Subject %q != %q", e.Subject(), test.wantSubject)
+			}
+			if e.DataSchema() != schemasv1.CloudLoggingEventDataSchema {
+				t.Errorf("DataSchema got=%s, want=%s", e.DataSchema(), schemasv1.CloudLoggingEventDataSchema)
+			}
+			if !bytes.Equal(e.Data(), msg.Data) {
+				t.Errorf("Data got=%s, want=%s", e.Data(), msg.Data)
+			}
+			if diff := cmp.Diff(test.wantExtensions, e.Extensions()); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
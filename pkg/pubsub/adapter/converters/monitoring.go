@@ -0,0 +1,89 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+// incidentNotification is the part of the payload of the Pub/Sub
+// notifications of Cloud Monitoring needed to build the events.
+type incidentNotification struct {
+	Incident *struct {
+		IncidentID       string `json:"incident_id"`
+		ScopingProjectID string `json:"scoping_project_id"`
+		State            string `json:"state"`
+		PolicyName       string `json:"policy_name"`
+		ConditionName    string `json:"condition_name"`
+	} `json:"incident"`
+}
+
+func convertCloudMonitoring(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	var n incidentNotification
+	if err := json.Unmarshal(msg.Data, &n); err != nil {
+		return nil, fmt.Errorf("failed to decode incident notification: %w", err)
+	}
+	incident := n.Incident
+	if incident == nil || incident.IncidentID == "" {
+		return nil, errors.New("received event did not have an incident")
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	// An incident is notified once when it is opened and once when it is
+	// closed, the message ID is what identifies the event.
+	event.SetID(msg.ID)
+	event.SetTime(msg.PublishTime)
+	switch incident.State {
+	case "open":
+		event.SetType(schemasv1.CloudMonitoringIncidentOpenedEventType)
+	case "closed":
+		event.SetType(schemasv1.CloudMonitoringIncidentClosedEventType)
+	default:
+		return nil, fmt.Errorf("unknown incident state %q", incident.State)
+	}
+
+	project := incident.ScopingProjectID
+	if project == "" {
+		var err error
+		if project, err = GetProjectKey(ctx); err != nil {
+			return nil, err
+		}
+	}
+	event.SetSource(schemasv1.CloudMonitoringEventSource(project))
+	event.SetSubject(schemasv1.CloudMonitoringEventSubject(incident.IncidentID))
+
+	event.SetExtension(schemasv1.CloudMonitoringIncidentStateExtension, incident.State)
+	if incident.PolicyName != "" {
+		event.SetExtension(schemasv1.CloudMonitoringIncidentPolicyExtension, incident.PolicyName)
+	}
+	if incident.ConditionName != "" {
+		event.SetExtension(schemasv1.CloudMonitoringIncidentConditionExtension, incident.ConditionName)
+	}
+
+	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
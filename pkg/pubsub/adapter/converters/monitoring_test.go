@@ -0,0 +1,110 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+const (
+	openIncidentData = `{"incident":{"incident_id":"0.abc123","scoping_project_id":"monitoredproject",` +
+		`"state":"open","policy_name":"High latency","condition_name":"Latency above 1s"},"version":"1.2"}`
+	closedIncidentData = `{"incident":{"incident_id":"0.abc123","state":"closed"},"version":"1.2"}`
+)
+
+func TestConvertCloudMonitoring(t *testing.T) {
+	publishTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		data        string
+		wantEventFn func() *cev2.Event
+		wantErr     bool
+	}{{
+		name: "opened incident",
+		data: openIncidentData,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudMonitoringIncidentOpenedEventType)
+			e.SetSource("//monitoring.googleapis.com/projects/monitoredproject")
+			e.SetSubject("incidents/0.abc123")
+			e.SetExtension(schemasv1.CloudMonitoringIncidentStateExtension, "open")
+			e.SetExtension(schemasv1.CloudMonitoringIncidentPolicyExtension, "High latency")
+			e.SetExtension(schemasv1.CloudMonitoringIncidentConditionExtension, "Latency above 1s")
+			e.SetData(cev2.ApplicationJSON, []byte(openIncidentData))
+			return &e
+		},
+	}, {
+		name: "closed incident without scoping project",
+		data: closedIncidentData,
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetTime(publishTime)
+			e.SetType(schemasv1.CloudMonitoringIncidentClosedEventType)
+			e.SetSource("//monitoring.googleapis.com/projects/testproject")
+			e.SetSubject("incidents/0.abc123")
+			e.SetExtension(schemasv1.CloudMonitoringIncidentStateExtension, "closed")
+			e.SetData(cev2.ApplicationJSON, []byte(closedIncidentData))
+			return &e
+		},
+	}, {
+		name:    "unknown state",
+		data:    `{"incident":{"incident_id":"0.abc123","state":"acknowledged"}}`,
+		wantErr: true,
+	}, {
+		name:    "no incident",
+		data:    `{"version":"1.2"}`,
+		wantErr: true,
+	}, {
+		name:    "not JSON",
+		data:    "test data",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := WithProjectKey(context.Background(), "testproject")
+			msg := &pubsub.Message{
+				ID:          "id",
+				PublishTime: publishTime,
+				Data:        []byte(test.data),
+			}
+			gotEvent, err := NewPubSubConverter().Convert(ctx, msg, CloudMonitoring)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("converter.Convert got error %v want error=%v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.wantEventFn(), gotEvent); diff != "" {
+				t.Errorf("converter.Convert got unexpected cloudevents.Event (-want +got) %s", diff)
+			}
+		})
+	}
+}
@@ -47,6 +47,8 @@ func convertCloudPubSub(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 		return nil, err
 	}
 
+	setOrderingExtensions(&event, msg)
+
 	pushMessage := &schemasv1.PushMessage{
 		Subscription: subscription,
 		Message: &schemasv1.PubSubMessage{
@@ -54,7 +56,9 @@ func convertCloudPubSub(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 			Attributes:  msg.Attributes,
 			PublishTime: msg.PublishTime,
 			Data:        msg.Data,
+			OrderingKey: msg.OrderingKey,
 		},
+		DeliveryAttempt: msg.DeliveryAttempt,
 	}
 
 	if err := event.SetData(cev2.ApplicationJSON, pushMessage); err != nil {
@@ -62,3 +66,14 @@ func convertCloudPubSub(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 	}
 	return &event, nil
 }
+
+// setOrderingExtensions sets the ordering key and delivery attempt extensions
+// of the event, when the message has them.
+func setOrderingExtensions(event *cev2.Event, msg *pubsub.Message) {
+	if msg.OrderingKey != "" {
+		event.SetExtension(schemasv1.OrderingKeyExtension, msg.OrderingKey)
+	}
+	if msg.DeliveryAttempt != nil {
+		event.SetExtension(schemasv1.DeliveryAttemptExtension, *msg.DeliveryAttempt)
+	}
+}
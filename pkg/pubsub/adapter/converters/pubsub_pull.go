@@ -56,6 +56,8 @@ func convertPubSubPull(ctx context.Context, msg *pubsub.Message) (*cev2.Event, e
 			event.SetExtension(k, v)
 		}
 	}
+	setOrderingExtensions(&event, msg)
+
 	// We do not know the content type and we do not want to inspect the payload,
 	// thus we set this generic one.
 	if err := event.SetData("application/octet-stream", msg.Data); err != nil {
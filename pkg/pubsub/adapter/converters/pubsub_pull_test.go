@@ -66,6 +66,20 @@ func TestConvertPubSubPull(t *testing.T) {
 				"attribute2": "value2",
 			})
 		},
+	}, {
+		name: "ordering key and delivery attempt",
+		message: &pubsub.Message{
+			ID:              "id",
+			Data:            []byte("test data"),
+			OrderingKey:     "key",
+			DeliveryAttempt: intPtr(2),
+		},
+		wantEventFn: func() *cev2.Event {
+			e := pubSubPull(nil)
+			e.SetExtension(schemasv1.OrderingKeyExtension, "key")
+			e.SetExtension(schemasv1.DeliveryAttemptExtension, 2)
+			return e
+		},
 	}, {
 		name: "failing with invalid attributes",
 		message: &pubsub.Message{
@@ -63,6 +63,27 @@ func TestConvertCloudPubSub(t *testing.T) {
 		wantEventFn: func() *cev2.Event {
 			return pubSubCloudEvent(nil, "\"InRlc3QgZGF0YSI=\"")
 		},
+	}, {
+		name: "ordering key and delivery attempt",
+		message: &pubsub.Message{
+			ID:              "id",
+			Data:            []byte("\"test data\""), // Data passed in quotes for it to be marshalled properly
+			Attributes:      map[string]string{},
+			OrderingKey:     "key",
+			DeliveryAttempt: intPtr(2),
+		},
+		wantEventFn: func() *cev2.Event {
+			e := cev2.NewEvent(cev2.VersionV1)
+			e.SetID("id")
+			e.SetSource(schemasv1.CloudPubSubEventSource("testproject", "testtopic"))
+			e.SetData(cev2.ApplicationJSON, []byte(`{"subscription":"testsubscription","message":{"messageId":"id","data":"InRlc3QgZGF0YSI=","publishTime":"0001-01-01T00:00:00Z","orderingKey":"key"},"deliveryAttempt":2}`))
+			e.SetType(schemasv1.CloudPubSubMessagePublishedEventType)
+			e.SetDataSchema(schemasv1.CloudPubSubEventDataSchema)
+			e.SetExtension(schemasv1.OrderingKeyExtension, "key")
+			e.SetExtension(schemasv1.DeliveryAttemptExtension, 2)
+			e.DataBase64 = false
+			return &e
+		},
 	}, {
 		name: "invalid context",
 		message: &pubsub.Message{
@@ -114,3 +135,7 @@ func pubSubCloudEvent(attributes map[string]string, data string) *cev2.Event {
 	e.DataBase64 = false
 	return &e
 }
+
+func intPtr(i int) *int {
+	return &i
+}
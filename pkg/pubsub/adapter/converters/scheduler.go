@@ -25,6 +25,7 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	cev2 "github.com/cloudevents/sdk-go/v2"
+	. "github.com/cloudevents/sdk-go/v2/event"
 )
 
 func convertCloudScheduler(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
@@ -40,6 +41,14 @@ func convertCloudScheduler(ctx context.Context, msg *pubsub.Message) (*cev2.Even
 	}
 	event.SetSource(schemasv1.CloudSchedulerEventSource(jobName))
 
+	// The custom attributes of the job are promoted to extensions. The job
+	// name is not, as it is already part of the source.
+	for k, v := range msg.Attributes {
+		if k != v1beta1.CloudSchedulerSourceJobName && IsAlphaNumeric(k) {
+			event.SetExtension(k, v)
+		}
+	}
+
 	if err := event.SetData(cev2.ApplicationJSON, &schemasv1.SchedulerJobData{CustomData: msg.Data}); err != nil {
 		return nil, err
 	}
@@ -50,7 +50,12 @@ func TestConvertCloudSchedulerSource(t *testing.T) {
 			},
 		},
 		wantEventFn: func() *cev2.Event {
-			return schedulerCloudEvent("//cloudscheduler.googleapis.com/projects/knative-gcp-test/locations/us-east4/jobs/cre-scheduler-test")
+			e := schedulerCloudEvent("//cloudscheduler.googleapis.com/projects/knative-gcp-test/locations/us-east4/jobs/cre-scheduler-test")
+			// knative-gcp is not a valid extension name, so it is dropped.
+			e.SetExtension("schedulerName", "scheduler-test")
+			e.SetExtension("attribute1", "value1")
+			e.SetExtension("attribute2", "value2")
+			return e
 		},
 	}, {
 		name: "missing jobName attribute",
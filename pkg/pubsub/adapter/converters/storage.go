@@ -23,6 +23,7 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	cev2 "github.com/cloudevents/sdk-go/v2"
+	. "github.com/cloudevents/sdk-go/v2/event"
 	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
 )
 
@@ -34,13 +35,30 @@ var (
 		"OBJECT_DELETE":          schemasv1.CloudStorageObjectDeletedEventType,
 		"OBJECT_METADATA_UPDATE": schemasv1.CloudStorageObjectMetadataUpdatedEventType,
 	}
+
+	// The attributes GCS sets on every notification, any other one is a
+	// custom attribute of the notification.
+	storageNotificationAttributes = map[string]bool{
+		"notificationConfig":      true,
+		"eventType":               true,
+		"payloadFormat":           true,
+		"bucketId":                true,
+		"objectId":                true,
+		"objectGeneration":        true,
+		"eventTime":               true,
+		"overwroteGeneration":     true,
+		"overwrittenByGeneration": true,
+	}
 )
 
+// storageNoPayload is the payloadFormat attribute of notifications without
+// payload.
+const storageNoPayload = "NONE"
+
 func convertCloudStorage(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
 	event := cev2.NewEvent(cev2.VersionV1)
 	event.SetID(msg.ID)
 	event.SetTime(msg.PublishTime)
-	event.SetDataSchema(schemasv1.CloudStorageEventDataSchema)
 
 	// TODO: figure out if we want to continue to add these as extensions.
 	if val, ok := msg.Attributes["bucketId"]; ok {
@@ -64,6 +82,19 @@ func convertCloudStorage(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 		return nil, errors.New("received event did not have eventType")
 	}
 
+	// The custom attributes of the notification are promoted to extensions.
+	for k, v := range msg.Attributes {
+		if !storageNotificationAttributes[k] && IsAlphaNumeric(k) {
+			event.SetExtension(k, v)
+		}
+	}
+
+	// Notifications without payload only carry the attributes, the events
+	// have no data.
+	if msg.Attributes["payloadFormat"] == storageNoPayload {
+		return &event, nil
+	}
+	event.SetDataSchema(schemasv1.CloudStorageEventDataSchema)
 	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
 		return nil, err
 	}
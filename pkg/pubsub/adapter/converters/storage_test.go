@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
 )
 
@@ -38,9 +40,11 @@ var (
 func TestConvertCloudStorageSource(t *testing.T) {
 
 	tests := []struct {
-		name    string
-		message *pubsub.Message
-		wantErr bool
+		name           string
+		message        *pubsub.Message
+		wantErr        bool
+		wantNoData     bool
+		wantExtensions map[string]interface{}
 	}{{
 		name: "no attributes",
 		message: &pubsub.Message{
@@ -101,6 +105,38 @@ func TestConvertCloudStorageSource(t *testing.T) {
 				"objectId":  objectId,
 			},
 		},
+	}, {
+		name: "custom attributes",
+		message: &pubsub.Message{
+			ID:          "id",
+			PublishTime: storagePublishTime,
+			Data:        []byte("test data"),
+			Attributes: map[string]string{
+				"bucketId":         bucket,
+				"eventType":        eventType,
+				"objectId":         objectId,
+				"objectGeneration": "1",
+				"payloadFormat":    "JSON_API_V1",
+				"team":             "storage",
+				"not-an-extension": "value",
+			},
+		},
+		wantExtensions: map[string]interface{}{
+			"team": "storage",
+		},
+	}, {
+		name: "no payload",
+		message: &pubsub.Message{
+			ID:          "id",
+			PublishTime: storagePublishTime,
+			Attributes: map[string]string{
+				"bucketId":      bucket,
+				"eventType":     eventType,
+				"objectId":      objectId,
+				"payloadFormat": "NONE",
+			},
+		},
+		wantNoData: true,
 	}}
 
 	for _, test := range tests {
@@ -127,9 +163,16 @@ func TestConvertCloudStorageSource(t *testing.T) {
 				if want := schemasv1.CloudStorageEventSubject(objectId); gotEvent.Subject() != want {
 					t.Errorf("Subject %q != %q", gotEvent.Subject(), objectId)
 				}
-				if gotEvent.DataSchema() != schemasv1.CloudStorageEventDataSchema {
+				if test.wantNoData {
+					if gotEvent.Data() != nil || gotEvent.DataSchema() != "" {
+						t.Errorf("unexpected data %q with schema %q", gotEvent.Data(), gotEvent.DataSchema())
+					}
+				} else if gotEvent.DataSchema() != schemasv1.CloudStorageEventDataSchema {
 					t.Errorf("DataSchema %q != %q", gotEvent.DataSchema(), schemasv1.CloudStorageEventDataSchema)
 				}
+				if diff := cmp.Diff(test.wantExtensions, gotEvent.Extensions(), cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("unexpected extensions (-want, +got) = %v", diff)
+				}
 			}
 		})
 	}
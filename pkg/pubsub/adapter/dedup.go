@@ -0,0 +1,142 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+// defaultDedupTTL is how long delivered message IDs are remembered when no
+// TTL is configured.
+const defaultDedupTTL = time.Hour
+
+// DedupStore records the IDs of the messages successfully delivered to the
+// sink, so that their redeliveries by Pub/Sub can be skipped. Implementations
+// backed by an external store allow deduplication across adapter replicas.
+type DedupStore interface {
+	// Delivered tells whether the message with the given ID was already
+	// delivered.
+	Delivered(ctx context.Context, id string) (bool, error)
+
+	// MarkDelivered records that the message with the given ID was delivered.
+	MarkDelivered(ctx context.Context, id string) error
+}
+
+// dedupEntry is a delivered message ID together with the time it is
+// forgotten at.
+type dedupEntry struct {
+	id     string
+	expiry time.Time
+}
+
+// memoryDedupStore is an in-memory DedupStore that remembers at most size
+// message IDs, each for ttl. The least recently delivered IDs are evicted
+// first.
+type memoryDedupStore struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+// NewMemoryDedupStore returns an in-memory DedupStore bounded to size entries,
+// which remembers delivered message IDs for ttl.
+func NewMemoryDedupStore(size int, ttl time.Duration) DedupStore {
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+	return &memoryDedupStore{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (s *memoryDedupStore) Delivered(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return false, nil
+	}
+	if s.now().After(e.Value.(*dedupEntry).expiry) {
+		s.remove(e)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *memoryDedupStore) MarkDelivered(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry := s.now().Add(s.ttl)
+	if e, ok := s.entries[id]; ok {
+		e.Value.(*dedupEntry).expiry = expiry
+		s.ll.MoveToFront(e)
+		return nil
+	}
+	s.entries[id] = s.ll.PushFront(&dedupEntry{id: id, expiry: expiry})
+	for s.ll.Len() > s.size {
+		s.remove(s.ll.Back())
+	}
+	return nil
+}
+
+func (s *memoryDedupStore) remove(e *list.Element) {
+	s.ll.Remove(e)
+	delete(s.entries, e.Value.(*dedupEntry).id)
+}
+
+// isDuplicate tells whether msg was already delivered, in which case it is
+// acked. Lookup errors are logged and the message is delivered again, as
+// duplicates are preferable to losing messages.
+func (a *Adapter) isDuplicate(ctx context.Context, msg *pubsub.Message) bool {
+	if a.dedup == nil {
+		return false
+	}
+	delivered, err := a.dedup.Delivered(ctx, msg.ID)
+	if err != nil {
+		a.logger.Warn("Failed to look up message in the dedup store", zap.String("messageId", msg.ID), zap.Error(err))
+		return false
+	}
+	if delivered {
+		a.logger.Debug("Skipping already delivered message", zap.String("messageId", msg.ID))
+		msg.Ack()
+	}
+	return delivered
+}
+
+// ackDelivered records that msg was delivered and acks it.
+func (a *Adapter) ackDelivered(ctx context.Context, msg *pubsub.Message) {
+	if a.dedup != nil {
+		if err := a.dedup.MarkDelivered(ctx, msg.ID); err != nil {
+			a.logger.Warn("Failed to record message in the dedup store", zap.String("messageId", msg.ID), zap.Error(err))
+		}
+	}
+	msg.Ack()
+}
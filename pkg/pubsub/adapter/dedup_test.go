@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryDedupStore(2, time.Minute).(*memoryDedupStore)
+	s.now = func() time.Time { return now }
+
+	assertDelivered := func(id string, want bool) {
+		t.Helper()
+		got, err := s.Delivered(ctx, id)
+		if err != nil {
+			t.Fatalf("Delivered(%q) failed: %v", id, err)
+		}
+		if got != want {
+			t.Errorf("Delivered(%q) got %v want %v", id, got, want)
+		}
+	}
+
+	assertDelivered("a", false)
+	s.MarkDelivered(ctx, "a")
+	s.MarkDelivered(ctx, "b")
+	assertDelivered("a", true)
+	assertDelivered("b", true)
+
+	// Marking a again makes b the least recently delivered, which is evicted
+	// once the store is full.
+	s.MarkDelivered(ctx, "a")
+	s.MarkDelivered(ctx, "c")
+	assertDelivered("a", true)
+	assertDelivered("b", false)
+	assertDelivered("c", true)
+
+	// Entries expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	assertDelivered("a", false)
+	assertDelivered("c", false)
+	if got := s.ll.Len(); got != 0 {
+		t.Errorf("store got %d entries after expiry, want 0", got)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+
+	"github.com/google/knative-gcp/pkg/broker/eventutil"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+// dropEvent tells whether the event converted from msg is filtered out or
+// sampled out, in which case msg is acked without being delivered and the
+// event is counted as dropped.
+func (a *Adapter) dropEvent(ctx context.Context, msg *pubsub.Message, event *cev2.Event) bool {
+	if a.passFilter(event) && a.passBuildFilter(event) && a.passImageFilter(event) && a.passSample() {
+		return false
+	}
+	a.logger.Debug("Dropping event", zap.String("messageId", msg.ID), zap.String("type", event.Type()))
+	a.reporter.ReportDroppedEventCount(&ReportArgs{
+		EventType:   event.Type(),
+		EventSource: event.Source(),
+	})
+	msg.Ack()
+	return true
+}
+
+// passFilter tells whether event matches the filter attributes, with the
+// same semantics as Trigger filters.
+func (a *Adapter) passFilter(event *cev2.Event) bool {
+	return eventutil.MatchFilterAttributes(a.args.FilterAttributes, event) == nil
+}
+
+// build is the part of a Cloud Build notification payload that builds are
+// filtered on.
+type build struct {
+	Status         string   `json:"status"`
+	BuildTriggerID string   `json:"buildTriggerId"`
+	Tags           []string `json:"tags"`
+	Images         []string `json:"images"`
+}
+
+// passBuildFilter tells whether event is a Cloud Build notification matching
+// the build filter. Events that are not Cloud Build notifications are not
+// filtered.
+func (a *Adapter) passBuildFilter(event *cev2.Event) bool {
+	f := a.args.BuildFilter
+	if f == nil || event.Type() != schemasv1.CloudBuildSourceEventType {
+		return true
+	}
+	var b build
+	if err := json.Unmarshal(event.Data(), &b); err != nil {
+		a.logger.Debug("Failed to parse Cloud Build notification", zap.String("id", event.ID()), zap.Error(err))
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsAny(f.Statuses, []string{b.Status}, equal) {
+		return false
+	}
+	if len(f.TriggerIDs) > 0 && !containsAny(f.TriggerIDs, []string{b.BuildTriggerID}, equal) {
+		return false
+	}
+	if len(f.Tags) > 0 && !containsAny(f.Tags, b.Tags, equal) {
+		return false
+	}
+	if len(f.Images) > 0 && !containsAny(f.Images, b.Images, matchImage) {
+		return false
+	}
+	return true
+}
+
+// passImageFilter tells whether event is an Artifact Registry or Container
+// Registry image notification matching the image filter. Events that are not
+// image notifications are not filtered.
+func (a *Adapter) passImageFilter(event *cev2.Event) bool {
+	f := a.args.ImageFilter
+	if f == nil {
+		return true
+	}
+	if t := event.Type(); t != schemasv1.CloudArtifactRegistryImageInsertedEventType && t != schemasv1.CloudArtifactRegistryImageDeletedEventType {
+		return true
+	}
+	// An image is referred to by digest, by tag, or both.
+	var refs []string
+	for _, ext := range []string{schemasv1.CloudArtifactRegistryDigestExtension, schemasv1.CloudArtifactRegistryTagExtension} {
+		if ref, ok := event.Extensions()[ext].(string); ok && ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	if len(f.Repositories) > 0 && !containsAny(f.Repositories, refs, matchRepository) {
+		return false
+	}
+	if len(f.Images) > 0 && !containsAny(f.Images, refs, matchImage) {
+		return false
+	}
+	return true
+}
+
+// containsAny tells whether any of values matches any of the filter values.
+func containsAny(filter, values []string, match func(filter, value string) bool) bool {
+	for _, f := range filter {
+		for _, v := range values {
+			if match(f, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equal(filter, value string) bool {
+	return filter == value
+}
+
+// matchImage tells whether image is the filter image, regardless of its tag
+// or digest unless the filter has one.
+func matchImage(filter, image string) bool {
+	return image == filter || strings.HasPrefix(image, filter+":") || strings.HasPrefix(image, filter+"@")
+}
+
+// matchRepository tells whether image is in the filter repository, including
+// its nested repositories.
+func matchRepository(filter, image string) bool {
+	return strings.HasPrefix(image, filter+"/")
+}
+
+// passSample tells whether an event is sampled in, based on the sample rate.
+func (a *Adapter) passSample() bool {
+	if a.args.SampleRate <= 0 || a.args.SampleRate >= 1 {
+		return true
+	}
+	return a.random() < a.args.SampleRate
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"testing"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+func TestPassFilter(t *testing.T) {
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID("id")
+	event.SetType("type")
+	event.SetSource("source")
+	event.SetExtension("methodname", "storage.buckets.create")
+
+	tests := []struct {
+		name  string
+		attrs map[string]string
+		want  bool
+	}{{
+		name: "no filter",
+		want: true,
+	}, {
+		name:  "matching attributes",
+		attrs: map[string]string{"type": "type", "methodname": "storage.buckets.create"},
+		want:  true,
+	}, {
+		name:  "any value",
+		attrs: map[string]string{"methodname": ""},
+		want:  true,
+	}, {
+		name:  "non-matching attribute",
+		attrs: map[string]string{"type": "type", "methodname": "storage.buckets.delete"},
+		want:  false,
+	}, {
+		name:  "missing attribute",
+		attrs: map[string]string{"servicename": ""},
+		want:  false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Adapter{args: &AdapterArgs{FilterAttributes: test.attrs}}
+			if got := a.passFilter(&event); got != test.want {
+				t.Errorf("passFilter got %v want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPassBuildFilter(t *testing.T) {
+	build := cev2.NewEvent(cev2.VersionV1)
+	build.SetID("id")
+	build.SetType(schemasv1.CloudBuildSourceEventType)
+	build.SetSource("source")
+	build.SetData(cev2.ApplicationJSON, []byte(`{
+		"status": "SUCCESS",
+		"buildTriggerId": "trigger",
+		"tags": ["release", "nightly"],
+		"images": ["gcr.io/my-project/my-image:v1"]
+	}`))
+
+	other := cev2.NewEvent(cev2.VersionV1)
+	other.SetID("id")
+	other.SetType("type")
+	other.SetSource("source")
+
+	invalid := build.Clone()
+	invalid.SetData(cev2.ApplicationJSON, []byte("test data"))
+
+	tests := []struct {
+		name   string
+		filter *gcpduckv1.BuildFilterSpec
+		event  *cev2.Event
+		want   bool
+	}{{
+		name:  "no filter",
+		event: &build,
+		want:  true,
+	}, {
+		name: "matching build",
+		filter: &gcpduckv1.BuildFilterSpec{
+			Statuses:   []string{"FAILURE", "SUCCESS"},
+			TriggerIDs: []string{"trigger"},
+			Tags:       []string{"nightly"},
+			Images:     []string{"gcr.io/my-project/my-image"},
+		},
+		event: &build,
+		want:  true,
+	}, {
+		name:   "non-matching status",
+		filter: &gcpduckv1.BuildFilterSpec{Statuses: []string{"FAILURE"}},
+		event:  &build,
+		want:   false,
+	}, {
+		name:   "non-matching trigger",
+		filter: &gcpduckv1.BuildFilterSpec{TriggerIDs: []string{"other-trigger"}},
+		event:  &build,
+		want:   false,
+	}, {
+		name:   "non-matching tags",
+		filter: &gcpduckv1.BuildFilterSpec{Tags: []string{"manual"}},
+		event:  &build,
+		want:   false,
+	}, {
+		name:   "matching image tag",
+		filter: &gcpduckv1.BuildFilterSpec{Images: []string{"gcr.io/my-project/my-image:v1"}},
+		event:  &build,
+		want:   true,
+	}, {
+		name:   "non-matching image",
+		filter: &gcpduckv1.BuildFilterSpec{Images: []string{"gcr.io/my-project/my-image:v2", "gcr.io/my-project/my"}},
+		event:  &build,
+		want:   false,
+	}, {
+		name:   "not a build",
+		filter: &gcpduckv1.BuildFilterSpec{Statuses: []string{"FAILURE"}},
+		event:  &other,
+		want:   true,
+	}, {
+		name:   "invalid build",
+		filter: &gcpduckv1.BuildFilterSpec{Statuses: []string{"SUCCESS"}},
+		event:  &invalid,
+		want:   false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Adapter{
+				args:   &AdapterArgs{BuildFilter: test.filter},
+				logger: zap.NewNop(),
+			}
+			if got := a.passBuildFilter(test.event); got != test.want {
+				t.Errorf("passBuildFilter got %v want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPassImageFilter(t *testing.T) {
+	inserted := cev2.NewEvent(cev2.VersionV1)
+	inserted.SetID("id")
+	inserted.SetType(schemasv1.CloudArtifactRegistryImageInsertedEventType)
+	inserted.SetSource("source")
+	inserted.SetExtension(schemasv1.CloudArtifactRegistryDigestExtension, "us-docker.pkg.dev/my-project/my-repo/my-image@sha256:abc")
+	inserted.SetExtension(schemasv1.CloudArtifactRegistryTagExtension, "us-docker.pkg.dev/my-project/my-repo/my-image:v1")
+
+	deleted := cev2.NewEvent(cev2.VersionV1)
+	deleted.SetID("id")
+	deleted.SetType(schemasv1.CloudArtifactRegistryImageDeletedEventType)
+	deleted.SetSource("source")
+	deleted.SetExtension(schemasv1.CloudArtifactRegistryTagExtension, "gcr.io/my-project/my-image:v1")
+
+	other := cev2.NewEvent(cev2.VersionV1)
+	other.SetID("id")
+	other.SetType("type")
+	other.SetSource("source")
+
+	tests := []struct {
+		name   string
+		filter *gcpduckv1.ImageFilterSpec
+		event  *cev2.Event
+		want   bool
+	}{{
+		name:  "no filter",
+		event: &inserted,
+		want:  true,
+	}, {
+		name: "matching image",
+		filter: &gcpduckv1.ImageFilterSpec{
+			Repositories: []string{"gcr.io/my-project", "us-docker.pkg.dev/my-project/my-repo"},
+			Images:       []string{"us-docker.pkg.dev/my-project/my-repo/my-image"},
+		},
+		event: &inserted,
+		want:  true,
+	}, {
+		name:   "matching image digest",
+		filter: &gcpduckv1.ImageFilterSpec{Images: []string{"us-docker.pkg.dev/my-project/my-repo/my-image@sha256:abc"}},
+		event:  &inserted,
+		want:   true,
+	}, {
+		name:   "matching repository of tag only",
+		filter: &gcpduckv1.ImageFilterSpec{Repositories: []string{"gcr.io/my-project"}},
+		event:  &deleted,
+		want:   true,
+	}, {
+		name:   "non-matching repository",
+		filter: &gcpduckv1.ImageFilterSpec{Repositories: []string{"us-docker.pkg.dev/my-project/my"}},
+		event:  &inserted,
+		want:   false,
+	}, {
+		name:   "non-matching image",
+		filter: &gcpduckv1.ImageFilterSpec{Images: []string{"gcr.io/my-project/my-image:v2", "gcr.io/my-project/my"}},
+		event:  &deleted,
+		want:   false,
+	}, {
+		name:   "not an image",
+		filter: &gcpduckv1.ImageFilterSpec{Images: []string{"gcr.io/my-project/my-image"}},
+		event:  &other,
+		want:   true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Adapter{
+				args:   &AdapterArgs{ImageFilter: test.filter},
+				logger: zap.NewNop(),
+			}
+			if got := a.passImageFilter(test.event); got != test.want {
+				t.Errorf("passImageFilter got %v want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPassSample(t *testing.T) {
+	tests := []struct {
+		name       string
+		sampleRate float64
+		random     float64
+		want       bool
+	}{{
+		name:   "no sampling",
+		random: 0.99,
+		want:   true,
+	}, {
+		name:       "sampled in",
+		sampleRate: 0.25,
+		random:     0.1,
+		want:       true,
+	}, {
+		name:       "sampled out",
+		sampleRate: 0.25,
+		random:     0.25,
+		want:       false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Adapter{
+				args:   &AdapterArgs{SampleRate: test.sampleRate},
+				random: func() float64 { return test.random },
+			}
+			if got := a.passSample(); got != test.want {
+				t.Errorf("passSample got %v want %v", got, test.want)
+			}
+		})
+	}
+}
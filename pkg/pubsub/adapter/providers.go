@@ -24,11 +24,42 @@ var AdapterSet wire.ProviderSet = wire.NewSet(
 	NewAdapter,
 	clients.NewPubsubClient,
 	NewPubSubSubscription,
-	converters.NewPubSubConverter,
+	NewDeadLetterTopic,
+	NewConverter,
+	NewDedupStore,
 	NewStatsReporter,
 	clients.NewHTTPClient,
 )
 
-func NewPubSubSubscription(ctx context.Context, client *pubsub.Client, subscriptionID SubscriptionID) *pubsub.Subscription {
-	return client.Subscription(string(subscriptionID))
+func NewPubSubSubscription(ctx context.Context, client *pubsub.Client, subscriptionID SubscriptionID, args *AdapterArgs) *pubsub.Subscription {
+	sub := client.Subscription(string(subscriptionID))
+	sub.ReceiveSettings = args.ReceiveSettings
+	return sub
+}
+
+// NewDeadLetterTopic returns the topic where messages that fail conversion
+// are sent to, or nil if no dead letter topic is configured.
+func NewDeadLetterTopic(client *pubsub.Client, args *AdapterArgs) *pubsub.Topic {
+	if args.DeadLetterTopicID == "" {
+		return nil
+	}
+	return client.Topic(args.DeadLetterTopicID)
+}
+
+// NewConverter returns the converter of the received messages, which also
+// supports the custom converter declared in args, if any.
+func NewConverter(args *AdapterArgs) (converters.Converter, error) {
+	if args.CustomConverter == nil {
+		return converters.NewPubSubConverter(), nil
+	}
+	return converters.NewCustomPubSubConverter(args.CustomConverter)
+}
+
+// NewDedupStore returns the store of the delivered messages, or nil if
+// deduplication is disabled.
+func NewDedupStore(args *AdapterArgs) DedupStore {
+	if args.DedupCacheSize <= 0 {
+		return nil
+	}
+	return NewMemoryDedupStore(args.DedupCacheSize, args.DedupTTL)
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+
+	"cloud.google.com/go/pubsub"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	kntracing "knative.dev/eventing/pkg/tracing"
+
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+	"github.com/google/knative-gcp/pkg/tracing"
+)
+
+// receivePush delivers msg to the sink as Cloud Pub/Sub would to a push
+// endpoint, i.e. as a JSON push message without CloudEvents headers. The
+// message is acked or nacked based on the sink response.
+func (a *Adapter) receivePush(ctx context.Context, msg *pubsub.Message) {
+	if a.isDuplicate(ctx, msg) {
+		return
+	}
+	// Push messages have no attributes to filter on, only sampling applies.
+	if !a.passSample() {
+		a.reporter.ReportDroppedEventCount(&ReportArgs{
+			EventType:   schemasv1.CloudPubSubMessagePublishedEventType,
+			EventSource: schemasv1.CloudPubSubEventSource(a.projectID, a.args.TopicID),
+		})
+		msg.Ack()
+		return
+	}
+
+	ctx, span := trace.StartSpan(ctx, tracing.SourceDestination(a.resourceGroup, a.namespacedName))
+	defer span.End()
+	if span.IsRecordingEvents() {
+		span.AddAttributes(
+			kntracing.MessagingSystemAttribute,
+			tracing.PubSubProtocolAttribute,
+			kntracing.MessagingMessageIDAttribute(msg.ID),
+		)
+	}
+
+	body, err := json.Marshal(&schemasv1.PushMessage{
+		Subscription: a.subscription.String(),
+		Message: &schemasv1.PubSubMessage{
+			ID:          msg.ID,
+			Data:        msg.Data,
+			Attributes:  msg.Attributes,
+			PublishTime: msg.PublishTime,
+			OrderingKey: msg.OrderingKey,
+		},
+		DeliveryAttempt: msg.DeliveryAttempt,
+	})
+	if err != nil {
+		// Only the message contents are marshalled, so this cannot succeed
+		// on redelivery either.
+		a.logger.Error("Failed to marshal push message, dropping it", zap.String("messageId", msg.ID), zap.Error(err))
+		msg.Ack()
+		return
+	}
+
+	response, err := a.sendWithRetries(ctx, func() (*nethttp.Response, error) {
+		return a.sendPushRequest(ctx, body)
+	})
+	if err != nil {
+		a.logger.Error("Failed to send push message to sink", zap.String("address", a.args.SinkURI), zap.Error(err))
+		msg.Nack()
+		return
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			a.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+
+	args := &ReportArgs{
+		EventType:   schemasv1.CloudPubSubMessagePublishedEventType,
+		EventSource: schemasv1.CloudPubSubEventSource(a.projectID, a.args.TopicID),
+	}
+	a.reporter.ReportEventCount(args, response.StatusCode)
+
+	if response.StatusCode/100 != 2 {
+		a.logger.Error("Push message delivery failed", zap.Int("StatusCode", response.StatusCode))
+		a.handleDeliveryFailure(ctx, msg, args, response)
+		return
+	}
+	a.ackDelivered(ctx, msg)
+}
+
+func (a *Adapter) sendPushRequest(ctx context.Context, body []byte) (*nethttp.Response, error) {
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, a.args.SinkURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return a.outbound.Do(req)
+}
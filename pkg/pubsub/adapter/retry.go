@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+const (
+	// defaultBackoffDelay is used when retries are configured without a backoff delay.
+	defaultBackoffDelay = time.Second
+
+	// maxBackoffDelay caps the delay between two attempts, so that a message
+	// is not held by the adapter for too long.
+	maxBackoffDelay = time.Minute
+
+	// backoffJitter is the maximum jitter added to each delay, as a factor of the delay.
+	backoffJitter = 0.5
+)
+
+// sendWithRetries calls send until it succeeds, fails with a non-retryable
+// error, or a.args.Retry retries are exhausted. It returns the result of the
+// last attempt.
+func (a *Adapter) sendWithRetries(ctx context.Context, send func() (*nethttp.Response, error)) (*nethttp.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := send()
+		if attempt >= a.args.Retry || !isRetryable(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain the body so that the connection can be reused.
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		delay := a.backoff(attempt)
+		a.logger.Debug("Retrying event delivery", zap.Int("attempt", attempt+1), zap.Duration("delay", delay), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoff returns the delay to wait before the retry following the given
+// attempt, using the configured backoff policy plus jitter.
+func (a *Adapter) backoff(attempt int) time.Duration {
+	delay := a.args.BackoffDelay
+	if delay <= 0 {
+		delay = defaultBackoffDelay
+	}
+	if a.args.BackoffPolicy == eventingduckv1.BackoffPolicyLinear {
+		delay *= time.Duration(attempt + 1)
+	} else {
+		// Exponential is the default, stop doubling once the cap is reached.
+		for i := 0; i < attempt && delay < maxBackoffDelay; i++ {
+			delay *= 2
+		}
+	}
+	// Cap the delay after adding the jitter, so that the jitter can't exceed
+	// the cap.
+	delay = wait.Jitter(delay, backoffJitter)
+	if delay > maxBackoffDelay {
+		delay = maxBackoffDelay
+	}
+	return delay
+}
+
+// isRetryable tells whether a delivery failed with a transient error. Transport
+// errors (including timeouts), 5xx, 429 and 408 responses are retryable, while
+// other responses are either successful or permanent failures.
+func isRetryable(resp *nethttp.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch {
+	case resp.StatusCode >= 500,
+		resp.StatusCode == nethttp.StatusTooManyRequests,
+		resp.StatusCode == nethttp.StatusRequestTimeout:
+		return true
+	default:
+		return false
+	}
+}
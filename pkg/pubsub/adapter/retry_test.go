@@ -0,0 +1,219 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	logtest "knative.dev/pkg/logging/testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{{
+		name: "transport error",
+		err:  errors.New("connection refused"),
+		want: true,
+	}, {
+		name:   "success",
+		status: http.StatusAccepted,
+		want:   false,
+	}, {
+		name:   "server error",
+		status: http.StatusServiceUnavailable,
+		want:   true,
+	}, {
+		name:   "too many requests",
+		status: http.StatusTooManyRequests,
+		want:   true,
+	}, {
+		name:   "request timeout",
+		status: http.StatusRequestTimeout,
+		want:   true,
+	}, {
+		name:   "bad request",
+		status: http.StatusBadRequest,
+		want:   false,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status}
+			}
+			if got := isRetryable(resp, tc.err); got != tc.want {
+				t.Errorf("unexpected isRetryable, want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  eventingduckv1.BackoffPolicyType
+		delay   time.Duration
+		attempt int
+		want    time.Duration
+	}{{
+		name:    "exponential first retry",
+		policy:  eventingduckv1.BackoffPolicyExponential,
+		delay:   100 * time.Millisecond,
+		attempt: 0,
+		want:    100 * time.Millisecond,
+	}, {
+		name:    "exponential third retry",
+		policy:  eventingduckv1.BackoffPolicyExponential,
+		delay:   100 * time.Millisecond,
+		attempt: 2,
+		want:    400 * time.Millisecond,
+	}, {
+		name:    "linear third retry",
+		policy:  eventingduckv1.BackoffPolicyLinear,
+		delay:   100 * time.Millisecond,
+		attempt: 2,
+		want:    300 * time.Millisecond,
+	}, {
+		name:    "default delay",
+		policy:  eventingduckv1.BackoffPolicyExponential,
+		attempt: 0,
+		want:    defaultBackoffDelay,
+	}, {
+		name:    "capped",
+		policy:  eventingduckv1.BackoffPolicyExponential,
+		delay:   time.Second,
+		attempt: 100,
+		want:    maxBackoffDelay,
+	}, {
+		name:    "capped with jitter",
+		policy:  eventingduckv1.BackoffPolicyLinear,
+		delay:   50 * time.Second,
+		attempt: 0,
+		want:    50 * time.Second,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Adapter{args: &AdapterArgs{BackoffPolicy: tc.policy, BackoffDelay: tc.delay}}
+			got := a.backoff(tc.attempt)
+			max := time.Duration(float64(tc.want) * (1 + backoffJitter))
+			if max > maxBackoffDelay {
+				max = maxBackoffDelay
+			}
+			if got < tc.want || got > max {
+				t.Errorf("unexpected backoff, want in [%v, %v] got %v", tc.want, max, got)
+			}
+		})
+	}
+}
+
+func TestSendWithRetries(t *testing.T) {
+	cases := []struct {
+		name         string
+		retry        int
+		statuses     []int
+		wantAttempts int
+		wantStatus   int
+	}{{
+		name:         "no retries",
+		retry:        0,
+		statuses:     []int{http.StatusInternalServerError},
+		wantAttempts: 1,
+		wantStatus:   http.StatusInternalServerError,
+	}, {
+		name:         "succeeds after retries",
+		retry:        3,
+		statuses:     []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+		wantAttempts: 3,
+		wantStatus:   http.StatusOK,
+	}, {
+		name:         "retries exhausted",
+		retry:        2,
+		statuses:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+		wantAttempts: 3,
+		wantStatus:   http.StatusInternalServerError,
+	}, {
+		name:         "permanent failure is not retried",
+		retry:        3,
+		statuses:     []int{http.StatusBadRequest, http.StatusOK},
+		wantAttempts: 1,
+		wantStatus:   http.StatusBadRequest,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Adapter{
+				args: &AdapterArgs{
+					Retry:         tc.retry,
+					BackoffPolicy: eventingduckv1.BackoffPolicyLinear,
+					BackoffDelay:  time.Millisecond,
+				},
+				logger: logtest.TestLogger(t).Desugar(),
+			}
+			attempts := 0
+			resp, err := a.sendWithRetries(context.Background(), func() (*http.Response, error) {
+				status := tc.statuses[attempts]
+				attempts++
+				return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("unexpected attempts, want %d got %d", tc.wantAttempts, attempts)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("unexpected status, want %d got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestSendWithRetriesContextDone(t *testing.T) {
+	a := &Adapter{
+		args: &AdapterArgs{
+			Retry:        3,
+			BackoffDelay: time.Minute,
+		},
+		logger: logtest.TestLogger(t).Desugar(),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	_, err := a.sendWithRetries(ctx, func() (*http.Response, error) {
+		attempts++
+		cancel()
+		return nil, errors.New("induced error")
+	})
+	if err != context.Canceled {
+		t.Errorf("unexpected error, want %v got %v", context.Canceled, err)
+	}
+	if attempts != 1 {
+		t.Errorf("unexpected attempts, want 1 got %d", attempts)
+	}
+}
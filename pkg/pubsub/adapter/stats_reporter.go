@@ -37,6 +37,14 @@ var (
 		stats.UnitDimensionless,
 	)
 
+	// droppedEventCountM is a counter which records the number of events
+	// filtered out or sampled out instead of being sent.
+	droppedEventCountM = stats.Int64(
+		"dropped_event_count",
+		"Number of events dropped by the filter",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -60,6 +68,10 @@ type ReportArgs struct {
 type StatsReporter interface {
 	// ReportEventCount captures the event count. It records one per call.
 	ReportEventCount(args *ReportArgs, responseCode int) error
+
+	// ReportDroppedEventCount captures the dropped event count. It records
+	// one per call.
+	ReportDroppedEventCount(args *ReportArgs) error
 }
 
 var _ StatsReporter = (*reporter)(nil)
@@ -94,6 +106,21 @@ func (r *reporter) ReportEventCount(args *ReportArgs, responseCode int) error {
 	return nil
 }
 
+func (r *reporter) ReportDroppedEventCount(args *ReportArgs) error {
+	ctx, err := tag.New(
+		emptyContext,
+		tag.Insert(namespaceKey, r.namespace),
+		tag.Insert(eventSourceKey, args.EventSource),
+		tag.Insert(eventTypeKey, args.EventType),
+		tag.Insert(nameKey, r.name),
+		tag.Insert(resourceGroupKey, r.resourceGroup))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, droppedEventCountM.M(1))
+	return nil
+}
+
 func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
 	return tag.New(
 		emptyContext,
@@ -124,5 +151,16 @@ func (r *reporter) register() error {
 			Aggregation: view.Count(),
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Description: droppedEventCountM.Description(),
+			Measure:     droppedEventCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				namespaceKey,
+				eventSourceKey,
+				eventTypeKey,
+				nameKey,
+				resourceGroupKey},
+		},
 	)
 }
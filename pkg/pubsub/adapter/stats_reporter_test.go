@@ -55,6 +55,20 @@ func TestStatsReporter(t *testing.T) {
 		return r.ReportEventCount(args, http.StatusAccepted)
 	})
 	metricstest.CheckCountData(t, "event_count", wantTags, 2)
+
+	wantDroppedTags := map[string]string{
+		metricskey.LabelNamespaceName: "testns",
+		metricskey.LabelEventType:     "dev.knative.event",
+		metricskey.LabelEventSource:   "unit-test",
+		metricskey.LabelName:          "testobject",
+		metricskey.LabelResourceGroup: "testresourcegroup",
+	}
+
+	// test ReportDroppedEventCount
+	expectSuccess(t, func() error {
+		return r.ReportDroppedEventCount(args)
+	})
+	metricstest.CheckCountData(t, "dropped_event_count", wantDroppedTags, 1)
 }
 
 func expectSuccess(t *testing.T, f func() error) {
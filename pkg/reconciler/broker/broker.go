@@ -32,10 +32,12 @@ import (
 	pkgreconciler "knative.dev/pkg/reconciler"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	brokerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/broker"
 	inteventslisters "github.com/google/knative-gcp/pkg/client/listers/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/reconciler"
 	"github.com/google/knative-gcp/pkg/reconciler/broker/resources"
+	brokercellresources "github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 	"github.com/google/knative-gcp/pkg/utils"
 )
@@ -58,6 +60,10 @@ type Reconciler struct {
 	// pubsubClient is used as the Pubsub client when present.
 	pubsubClient *pubsub.Client
 
+	// clients caches the Pub/Sub clients for brokers whose topic and
+	// subscription live in another project, or when pubsubClient isn't present.
+	clients *reconcilerutilspubsub.ClientCache
+
 	dataresidencyStore *dataresidency.Store
 }
 
@@ -95,24 +101,25 @@ func (r *Reconciler) reconcileBroker(ctx context.Context, b *brokerv1beta1.Broke
 	b.Status.InitializeConditions()
 	b.Status.ObservedGeneration = b.Generation
 
-	if err := r.ensureBrokerCellExists(ctx, b); err != nil {
+	bc, err := r.ensureBrokerCellExists(ctx, b)
+	if err != nil {
 		return fmt.Errorf("brokercell reconcile failed: %v", err)
 	}
 
 	// Create decoupling topic and pullsub for this broker. Ingress will push
 	// to this topic and fanout will pull from the pull sub.
-	if err := r.reconcileDecouplingTopicAndSubscription(ctx, b); err != nil {
+	if err := r.reconcileDecouplingTopicAndSubscription(ctx, b, bc); err != nil {
 		return fmt.Errorf("decoupling topic reconcile failed: %v", err)
 	}
 
 	return nil
 }
 
-func (r *Reconciler) reconcileDecouplingTopicAndSubscription(ctx context.Context, b *brokerv1beta1.Broker) error {
+func (r *Reconciler) reconcileDecouplingTopicAndSubscription(ctx context.Context, b *brokerv1beta1.Broker, bc *inteventsv1alpha1.BrokerCell) error {
 	logger := logging.FromContext(ctx)
 	logger.Debug("Reconciling decoupling topic", zap.Any("broker", b))
-	// get ProjectID from metadata if projectID isn't set
-	projectID, err := utils.ProjectIDOrDefault(r.projectID)
+	// get ProjectID from the broker, or from metadata if projectID isn't set
+	projectID, err := r.brokerProjectID(b)
 	if err != nil {
 		logger.Error("Failed to find project id", zap.Error(err))
 		b.Status.MarkTopicUnknown("ProjectIdNotFound", "Failed to find project id: %v", err)
@@ -124,16 +131,14 @@ func (r *Reconciler) reconcileDecouplingTopicAndSubscription(ctx context.Context
 	//b.Status.ProjectID = projectID
 
 	client := r.pubsubClient
-	if client == nil {
-		var err error
-		client, err = pubsub.NewClient(ctx, projectID)
+	if client == nil || b.Project() != "" {
+		client, err = r.clients.Get(projectID)
 		if err != nil {
 			logger.Error("Failed to create Pub/Sub client", zap.Error(err))
 			b.Status.MarkTopicUnknown("PubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			b.Status.MarkSubscriptionUnknown("PubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			return err
 		}
-		defer client.Close()
 	}
 	pubsubReconciler := reconcilerutilspubsub.NewReconciler(client, r.Recorder)
 
@@ -163,11 +168,20 @@ func (r *Reconciler) reconcileDecouplingTopicAndSubscription(ctx context.Context
 	//TODO uncomment when eventing webhook allows this
 	//b.Status.TopicID = topic.ID()
 
+	// Label the subscription with its BrokerCell so that the fanout backlog
+	// metric only covers the subscriptions of the BrokerCell.
+	subLabels := map[string]string{
+		brokercellresources.BrokerCellPubsubLabelKey: brokercellresources.BrokerCellPubsubLabelValue(bc),
+	}
+	for k, v := range labels {
+		subLabels[k] = v
+	}
+
 	// Check if PullSub exists, and if not, create it.
 	subID := resources.GenerateDecouplingSubscriptionName(b)
 	subConfig := pubsub.SubscriptionConfig{
 		Topic:  topic,
-		Labels: labels,
+		Labels: subLabels,
 		//TODO(grantr): configure these settings?
 		// AckDeadline
 		// RetentionDuration
@@ -187,8 +201,8 @@ func (r *Reconciler) deleteDecouplingTopicAndSubscription(ctx context.Context, b
 	logger := logging.FromContext(ctx)
 	logger.Debug("Deleting decoupling topic")
 
-	// get ProjectID from metadata if projectID isn't set
-	projectID, err := utils.ProjectIDOrDefault(r.projectID)
+	// get ProjectID from the broker, or from metadata if projectID isn't set
+	projectID, err := r.brokerProjectID(b)
 	if err != nil {
 		logger.Error("Failed to find project id", zap.Error(err))
 		b.Status.MarkTopicUnknown("FinalizeTopicProjectIdNotFound", "Failed to find project id: %v", err)
@@ -197,15 +211,14 @@ func (r *Reconciler) deleteDecouplingTopicAndSubscription(ctx context.Context, b
 	}
 
 	client := r.pubsubClient
-	if client == nil {
-		client, err := pubsub.NewClient(ctx, projectID)
+	if client == nil || b.Project() != "" {
+		client, err = r.clients.Get(projectID)
 		if err != nil {
 			logger.Error("Failed to create Pub/Sub client", zap.Error(err))
 			b.Status.MarkTopicUnknown("FinalizeTopicPubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			b.Status.MarkSubscriptionUnknown("FinalizeSubscriptionPubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			return err
 		}
-		defer client.Close()
 	}
 	pubsubReconciler := reconcilerutilspubsub.NewReconciler(client, r.Recorder)
 
@@ -218,3 +231,13 @@ func (r *Reconciler) deleteDecouplingTopicAndSubscription(ctx context.Context, b
 
 	return err
 }
+
+// brokerProjectID returns the project the broker's decoupling topic and
+// subscription live in: the broker's project annotation if set, otherwise the
+// controller's project.
+func (r *Reconciler) brokerProjectID(b *brokerv1beta1.Broker) (string, error) {
+	if project := b.Project(); project != "" {
+		return project, nil
+	}
+	return utils.ProjectIDOrDefault(r.projectID)
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
 	"github.com/google/knative-gcp/pkg/broker/ingress"
 
 	corev1 "k8s.io/api/core/v1"
@@ -46,15 +47,17 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	brokercellresources "github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 )
 
 const (
 	testNS     = "testnamespace"
 	brokerName = "test-broker"
 
-	testProject = "test-project-id"
-	testUID     = "abc123"
-	systemNS    = "knative-testing"
+	testProject   = "test-project-id"
+	tenantProject = "tenant-project-id"
+	testUID       = "abc123"
+	systemNS      = "knative-testing"
 
 	brokerFinalizerName = "brokers.eventing.knative.dev"
 )
@@ -149,6 +152,37 @@ func TestAllCases(t *testing.T) {
 			NoTopicsExist(),
 			NoSubscriptionsExist(),
 		},
+	}, {
+		Name: "Broker with project annotation is being deleted, topic and sub exist in the broker's project",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerProject(tenantProject),
+				WithBrokerUID(testUID),
+				WithInitBrokerConditions,
+				WithBrokerDeletionTimestamp,
+				WithBrokerSetDefaults,
+			),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "TopicDeleted", `Deleted PubSub topic "cre-bkr_testnamespace_test-broker_abc123"`),
+			Eventf(corev1.EventTypeNormal, "SubscriptionDeleted", `Deleted PubSub subscription "cre-bkr_testnamespace_test-broker_abc123"`),
+			brokerFinalizedEvent,
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				TopicAndSub("cre-bkr_testnamespace_test-broker_abc123", "cre-bkr_testnamespace_test-broker_abc123"),
+			},
+			"tenantPre": []PubsubAction{
+				TopicAndSub("cre-bkr_testnamespace_test-broker_abc123", "cre-bkr_testnamespace_test-broker_abc123"),
+			},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			// Only the topic and sub in the broker's project are deleted.
+			OnlyTopics("cre-bkr_testnamespace_test-broker_abc123"),
+			OnlySubscriptions("cre-bkr_testnamespace_test-broker_abc123"),
+		},
 	}, {
 		Name: "Create broker with ready brokercell, broker is created",
 		Key:  testKey,
@@ -187,6 +221,48 @@ func TestAllCases(t *testing.T) {
 			TopicExists("cre-bkr_testnamespace_test-broker_abc123"),
 			SubscriptionExists("cre-bkr_testnamespace_test-broker_abc123"),
 		},
+	}, {
+		Name: "Create broker with project annotation, topic and sub are created in the broker's project",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerProject(tenantProject),
+				WithBrokerUID(testUID),
+				WithBrokerDeliverySpec(brokerDeliverySpec),
+				WithBrokerSetDefaults),
+			NewBrokerCell(resources.DefaultBrokerCellName, systemNS,
+				WithBrokerCellReady,
+				WithBrokerCellSetDefaults),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerProject(tenantProject),
+				WithBrokerUID(testUID),
+				WithBrokerDeliverySpec(brokerDeliverySpec),
+				WithBrokerReadyURI(brokerAddress),
+				WithBrokerSetDefaults,
+			),
+		}},
+		WantEvents: []string{
+			brokerFinalizerUpdatedEvent,
+			Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-bkr_testnamespace_test-broker_abc123"`),
+			Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-bkr_testnamespace_test-broker_abc123"`),
+			brokerReconciledEvent,
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, brokerFinalizerName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			TopicExistsInProject(tenantProject, "cre-bkr_testnamespace_test-broker_abc123"),
+			SubscriptionExistsInProject(tenantProject, "cre-bkr_testnamespace_test-broker_abc123"),
+			NoTopicsExist(),
+			NoSubscriptionsExist(),
+		},
 	}, {
 		Name: "Create broker with unready brokercell, broker is created",
 		Key:  testKey,
@@ -310,6 +386,46 @@ func TestAllCases(t *testing.T) {
 			}),
 			SubscriptionExists("cre-bkr_testnamespace_test-broker_abc123"),
 		},
+	}, {
+		Name: "Subscription is labeled with its brokercell",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerDeliverySpec(brokerDeliverySpec),
+				WithBrokerSetDefaults),
+			NewBrokerCell(resources.DefaultBrokerCellName, systemNS,
+				WithBrokerCellUID("bc-uid"),
+				WithBrokerCellReady,
+				WithBrokerCellSetDefaults),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerDeliverySpec(brokerDeliverySpec),
+				WithBrokerReadyURI(brokerAddress),
+				WithBrokerSetDefaults,
+			),
+		}},
+		WantEvents: []string{
+			brokerFinalizerUpdatedEvent,
+			Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-bkr_testnamespace_test-broker_abc123"`),
+			Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-bkr_testnamespace_test-broker_abc123"`),
+			brokerReconciledEvent,
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, brokerFinalizerName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			SubscriptionHasLabels("cre-bkr_testnamespace_test-broker_abc123", map[string]string{
+				"broker_class": "googlecloud", "name": "test-broker", "namespace": "testnamespace", "resource": "brokers", "brokercell": "bc-uid",
+			}),
+		},
 	}, {
 		Name: "Check topic config with correct data residency and label",
 		Key:  testKey,
@@ -359,8 +475,11 @@ func TestAllCases(t *testing.T) {
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
 		// Insert pubsub client for PostConditions and create fixtures
-		psclient, close := TestPubsubClient(ctx, testProject)
-		t.Cleanup(close)
+		srv := pstest.NewServer()
+		t.Cleanup(func() { srv.Close() })
+		createClientFn := GetTestClientCreateFunc(srv.Addr)
+		psclient, _ := createClientFn(ctx, testProject)
+		t.Cleanup(func() { psclient.Close() })
 		if testData != nil {
 			InjectPubsubClient(testData, psclient)
 			if testData["pre"] != nil {
@@ -369,6 +488,13 @@ func TestAllCases(t *testing.T) {
 					f(ctx, t, psclient)
 				}
 			}
+			if testData["tenantPre"] != nil {
+				tenantClient, _ := createClientFn(ctx, tenantProject)
+				t.Cleanup(func() { tenantClient.Close() })
+				for _, f := range testData["tenantPre"].([]PubsubAction) {
+					f(ctx, t, tenantClient)
+				}
+			}
 		}
 		// If we found "dataResidencyConfigMap" in OtherData, we create a store with the configmap
 		var drStore *dataresidency.Store
@@ -383,6 +509,7 @@ func TestAllCases(t *testing.T) {
 			brokerCellLister:   listers.GetBrokerCellLister(),
 			projectID:          testProject,
 			pubsubClient:       psclient,
+			clients:            reconcilerutilspubsub.NewClientCache(ctx, createClientFn),
 			dataresidencyStore: drStore,
 		}
 		return brokerreconciler.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetBrokerLister(), r.Recorder, r, brokerv1beta1.BrokerClass)
@@ -39,6 +39,7 @@ import (
 	brokercellinformer "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell"
 	brokerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/broker"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 	"github.com/google/knative-gcp/pkg/utils"
 )
 
@@ -83,11 +84,19 @@ func newController(ctx context.Context, cmw configmap.Watcher, drs *dataresidenc
 			client.Close()
 		}()
 	}
+	// Clients for other projects are cached across reconciles and closed on
+	// shutdown.
+	clients := reconcilerutilspubsub.NewClientCache(ctx, pubsub.NewClient)
+	go func() {
+		<-ctx.Done()
+		clients.Close()
+	}()
 
 	r := &Reconciler{
 		Base:               reconciler.NewBase(ctx, controllerAgentName, cmw),
 		brokerCellLister:   bcInformer.Lister(),
 		pubsubClient:       client,
+		clients:            clients,
 		dataresidencyStore: drs,
 	}
 
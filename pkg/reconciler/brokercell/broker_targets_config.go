@@ -19,9 +19,11 @@ package brokercell
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/google/knative-gcp/pkg/logging"
 	"go.uber.org/zap"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/apis/eventing"
@@ -39,7 +41,8 @@ const (
 	configFailed = "BrokerTargetsConfigFailed"
 )
 
-func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.BrokerCell) error {
+// reconcileConfig reconciles the broker targets configmap and returns the targets it contains.
+func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.BrokerCell) (config.ReadonlyTargets, error) {
 	// TODO(#866) Only select brokers that point to this brokercell by label selector once the
 	// webhook assigns the brokercell label, i.e.,
 	// r.brokerLister.List(labels.SelectorFromSet(map[string]string{"brokercell":bc.Name, "brokercellns":bc.Namespace}))
@@ -47,12 +50,13 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.Broker
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to list brokers", zap.Error(err))
 		bc.Status.MarkTargetsConfigFailed(configFailed, "failed to list brokers: %v", err)
-		return err
+		return nil, err
 	}
 	// Start with a fresh config and add brokers/triggers into it. This approach is straightforward and reliable,
 	// however not efficient if there are too many triggers. If performance becomes an issue, we can consider
 	// maintaining 2 queues for updated brokers and triggers, and only update the config for updated brokers/triggers.
 	brokerTargets := memory.NewEmptyTargets()
+	var triggerCount int32
 	for _, broker := range brokers {
 		// Filter by `eventing.knative.dev/broker: <name>` here
 		// to get only the triggers for this broker. The trigger webhook will
@@ -61,17 +65,22 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.Broker
 		if err != nil {
 			logging.FromContext(ctx).Error("Failed to list triggers", zap.String("Broker", broker.Name), zap.Error(err))
 			bc.Status.MarkTargetsConfigFailed(configFailed, "failed to list triggers for broker %v: %v", broker.Name, err)
-			return err
+			return nil, err
 		}
 		r.addToConfig(ctx, broker, triggers, brokerTargets)
+		triggerCount += int32(len(triggers))
 	}
-	if err := r.updateTargetsConfig(ctx, bc, brokerTargets); err != nil {
+	gen, err := r.updateTargetsConfig(ctx, bc, brokerTargets)
+	if err != nil {
 		logging.FromContext(ctx).Error("Failed to update broker targets configmap", zap.Error(err))
 		bc.Status.MarkTargetsConfigFailed(configFailed, "failed to update configmap: %v", err)
-		return err
+		return nil, err
 	}
 	bc.Status.MarkTargetsConfigReady()
-	return nil
+	bc.Status.TargetsConfigGeneration = gen
+	bc.Status.BrokerCount = int32(len(brokers))
+	bc.Status.TriggerCount = triggerCount
+	return brokerTargets, nil
 }
 
 // addToConfig reconstructs the data entry for the given broker and add it to targets-config.
@@ -93,8 +102,8 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 		m.SetID(string(b.UID))
 		m.SetAddress(b.Status.Address.URL.String())
 		m.SetDecoupleQueue(&config.Queue{
-			Topic:        brokerresources.GenerateDecouplingTopicName(b),
-			Subscription: brokerresources.GenerateDecouplingSubscriptionName(b),
+			Topic:        queueTopicName(b, brokerresources.GenerateDecouplingTopicName(b)),
+			Subscription: queueSubscriptionName(b, brokerresources.GenerateDecouplingSubscriptionName(b)),
 			State:        brokerQueueState,
 		})
 		if b.Status.IsReady() {
@@ -113,8 +122,8 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 					Broker:    b.Name,
 					Address:   t.Status.SubscriberURI.String(),
 					RetryQueue: &config.Queue{
-						Topic:        brokerresources.GenerateRetryTopicName(t),
-						Subscription: brokerresources.GenerateRetrySubscriptionName(t),
+						Topic:        queueTopicName(b, brokerresources.GenerateRetryTopicName(t)),
+						Subscription: queueSubscriptionName(b, brokerresources.GenerateRetrySubscriptionName(t)),
 					},
 				}
 				if t.Spec.Filter != nil && t.Spec.Filter.Attributes != nil {
@@ -133,12 +142,79 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 	})
 }
 
+// queueTopicName returns the topic name recorded in a queue of the broker. The
+// topics of brokers in the controller's project are recorded by ID, those of
+// brokers in another project by their fully qualified name, so that the data
+// plane addresses them in the right project.
+func queueTopicName(b *brokerv1beta1.Broker, id string) string {
+	if project := b.Project(); project != "" {
+		return config.FullTopicName(project, id)
+	}
+	return id
+}
+
+// queueSubscriptionName returns the subscription name recorded in a queue of
+// the broker, qualified in the same way as queueTopicName.
+func queueSubscriptionName(b *brokerv1beta1.Broker, id string) string {
+	if project := b.Project(); project != "" {
+		return config.FullSubscriptionName(project, id)
+	}
+	return id
+}
+
+// decoupleSubscriptions returns the sorted names of the decouple subscriptions of all brokers in targets.
+func decoupleSubscriptions(targets config.ReadonlyTargets) []string {
+	var subs []string
+	targets.RangeBrokers(func(b *config.Broker) bool {
+		if q := b.GetDecoupleQueue(); q.GetSubscription() != "" {
+			subs = append(subs, q.GetSubscription())
+		}
+		return true
+	})
+	sort.Strings(subs)
+	return subs
+}
+
+// retrySubscriptions returns the sorted names of the retry subscriptions of all targets.
+func retrySubscriptions(targets config.ReadonlyTargets) []string {
+	var subs []string
+	targets.RangeAllTargets(func(t *config.Target) bool {
+		if q := t.GetRetryQueue(); q.GetSubscription() != "" {
+			subs = append(subs, q.GetSubscription())
+		}
+		return true
+	})
+	sort.Strings(subs)
+	return subs
+}
+
+// splitQueueSubscriptions splits the subscription names recorded in the queues
+// of the brokers into the IDs of the subscriptions in the controller's project
+// and the fully qualified names of those in other projects.
+func splitQueueSubscriptions(subs []string) (ids, crossProject []string) {
+	for _, sub := range subs {
+		if project, id := config.ParseQueueName(sub); project == "" {
+			ids = append(ids, id)
+		} else {
+			crossProject = append(crossProject, sub)
+		}
+	}
+	return ids, crossProject
+}
+
 //TODO all this stuff should be in a configmap variant of the config object
-func (r *Reconciler) updateTargetsConfig(ctx context.Context, bc *intv1alpha1.BrokerCell, brokerTargets config.Targets) error {
+// updateTargetsConfig updates the broker targets configmap and returns its generation.
+func (r *Reconciler) updateTargetsConfig(ctx context.Context, bc *intv1alpha1.BrokerCell, brokerTargets config.Targets) (int64, error) {
 	desired, err := resources.MakeTargetsConfig(bc, brokerTargets)
 	if err != nil {
-		return fmt.Errorf("error creating targets config: %w", err)
+		return 0, fmt.Errorf("error creating targets config: %w", err)
 	}
+	existing, err := r.configMapLister.ConfigMaps(desired.Namespace).Get(desired.Name)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return 0, fmt.Errorf("error getting targets config: %w", err)
+	}
+	gen := resources.NextTargetsConfigGeneration(existing, desired)
+	resources.SetTargetsConfigGeneration(desired, gen)
 
 	logging.FromContext(ctx).Debug("Current targets config", zap.Any("targetsConfig", brokerTargets.String()))
 
@@ -147,8 +223,10 @@ func (r *Reconciler) updateTargetsConfig(ctx context.Context, bc *intv1alpha1.Br
 		UpdateFunc: func(oldObj, newObj interface{}) { r.refreshPodVolume(ctx, bc) },
 		DeleteFunc: nil,
 	}
-	_, err = r.cmRec.ReconcileConfigMap(ctx, bc, desired, resources.TargetsConfigMapEqual, handlerFuncs)
-	return err
+	if _, err = r.cmRec.ReconcileConfigMap(ctx, bc, desired, resources.TargetsConfigMapEqual, handlerFuncs); err != nil {
+		return 0, err
+	}
+	return gen, nil
 }
 
 func (r *Reconciler) refreshPodVolume(ctx context.Context, bc *intv1alpha1.BrokerCell) {
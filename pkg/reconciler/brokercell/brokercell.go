@@ -19,27 +19,37 @@ package brokercell
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/knative-gcp/pkg/logging"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	hpav2beta2listers "k8s.io/client-go/listers/autoscaling/v2beta2"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	policyv1beta1listers "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	eventingduck "knative.dev/eventing/pkg/duck"
 	"knative.dev/eventing/pkg/reconciler/names"
 	pkgreconciler "knative.dev/pkg/reconciler"
 
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	"github.com/google/knative-gcp/pkg/broker/config"
 	bcreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
 	brokerlisters "github.com/google/knative-gcp/pkg/client/listers/broker/v1beta1"
 	"github.com/google/knative-gcp/pkg/reconciler"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+	kedaresources "github.com/google/knative-gcp/pkg/reconciler/intevents/pullsubscription/keda/resources"
 	reconcilerutils "github.com/google/knative-gcp/pkg/reconciler/utils"
 )
 
@@ -62,6 +72,7 @@ type listers struct {
 	endpointsLister  corev1listers.EndpointsLister
 	deploymentLister appsv1listers.DeploymentLister
 	podLister        corev1listers.PodLister
+	pdbLister        policyv1beta1listers.PodDisruptionBudgetLister
 }
 
 // NewReconciler creates a new BrokerCell reconciler.
@@ -107,6 +118,10 @@ type Reconciler struct {
 	deploymentRec *reconcilerutils.DeploymentReconciler
 	cmRec         *reconcilerutils.ConfigMapReconciler
 
+	// scaledObjectTracker lists the KEDA ScaledObjects of the components
+	// that scale on their backlog, and notifies us when they change.
+	scaledObjectTracker eventingduck.ListableTracker
+
 	env envConfig
 }
 
@@ -131,7 +146,8 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 
 	// Reconcile broker targets configmap first so that data plane pods are guaranteed to have the configmap volume
 	// mount available.
-	if err := r.reconcileConfig(ctx, bc); err != nil {
+	targets, err := r.reconcileConfig(ctx, bc)
+	if err != nil {
 		return err
 	}
 
@@ -151,6 +167,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 		return err
 	}
 
+	ingressPDB := resources.MakePodDisruptionBudget(ind, r.makePDBArgs(bc, resources.IngressName, bc.Spec.Components.Ingress))
+	if err := r.reconcilePodDisruptionBudget(ctx, bc, ingressPDB); err != nil {
+		logging.FromContext(ctx).Error("Failed to reconcile ingress PDB", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+		bc.Status.MarkIngressFailed("PodDisruptionBudgetFailed", "Failed to reconcile ingress PodDisruptionBudget: %v", err)
+		return err
+	}
+
 	endpoints, err := r.svcRec.ReconcileService(ctx, bc, resources.MakeIngressService(ingressArgs))
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to reconcile ingress service", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
@@ -169,12 +192,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 		return err
 	}
 
-	fanoutHPA := resources.MakeHorizontalPodAutoscaler(fd, r.makeFanoutHPAArgs(bc))
-	if err := r.reconcileAutoscaling(ctx, bc, fanoutHPA); err != nil {
+	if err := r.reconcileScaling(ctx, bc, fd, r.makeFanoutHPAArgs(bc, targets)); err != nil {
 		logging.FromContext(ctx).Error("Failed to reconcile fanout HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
 		bc.Status.MarkFanoutFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile fanout HorizontalPodAutoscaler: %v", err)
 		return err
 	}
+
+	fanoutPDB := resources.MakePodDisruptionBudget(fd, r.makePDBArgs(bc, resources.FanoutName, bc.Spec.Components.Fanout))
+	if err := r.reconcilePodDisruptionBudget(ctx, bc, fanoutPDB); err != nil {
+		logging.FromContext(ctx).Error("Failed to reconcile fanout PDB", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+		bc.Status.MarkFanoutFailed("PodDisruptionBudgetFailed", "Failed to reconcile fanout PodDisruptionBudget: %v", err)
+		return err
+	}
 	bc.Status.PropagateFanoutAvailability(fd)
 
 	// Reconcile retry deployment and HPA.
@@ -185,13 +214,22 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 		return err
 	}
 
-	retryHPA := resources.MakeHorizontalPodAutoscaler(rd, r.makeRetryHPAArgs(bc))
-	if err := r.reconcileAutoscaling(ctx, bc, retryHPA); err != nil {
+	if err := r.reconcileScaling(ctx, bc, rd, r.makeRetryHPAArgs(bc, targets)); err != nil {
 		logging.FromContext(ctx).Error("Failed to reconcile retry HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
 		bc.Status.MarkRetryFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile retry HorizontalPodAutoscaler: %v", err)
 		return err
 	}
+
+	retryPDB := resources.MakePodDisruptionBudget(rd, r.makePDBArgs(bc, resources.RetryName, bc.Spec.Components.Retry))
+	if err := r.reconcilePodDisruptionBudget(ctx, bc, retryPDB); err != nil {
+		logging.FromContext(ctx).Error("Failed to reconcile retry PDB", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+		bc.Status.MarkRetryFailed("PodDisruptionBudgetFailed", "Failed to reconcile retry PodDisruptionBudget: %v", err)
+		return err
+	}
 	bc.Status.PropagateRetryAvailability(rd)
+	r.propagateBacklogScalingStatus(ctx, bc, targets)
+
+	r.propagateComponentsStatus(ctx, bc, ind, fd, rd)
 
 	bc.Status.ObservedGeneration = bc.Generation
 	return pkgreconciler.NewEvent(corev1.EventTypeNormal, "BrokerCellReconciled", "BrokerCell reconciled: \"%s/%s\"", bc.Namespace, bc.Name)
@@ -241,6 +279,7 @@ func (r *Reconciler) makeIngressArgs(bc *intv1alpha1.BrokerCell) resources.Ingre
 			MemoryRequest:      bc.Spec.Components.Ingress.MemoryRequest,
 			MemoryLimit:        bc.Spec.Components.Ingress.MemoryLimit,
 			RolloutRestartTime: bc.GetAnnotations()[resources.IngressRestartTimeAnnotationKey],
+			RolloutStrategy:    bc.Spec.Components.Ingress.RolloutStrategy,
 		},
 		Port: r.env.IngressPort,
 	}
@@ -271,18 +310,24 @@ func (r *Reconciler) makeFanoutArgs(bc *intv1alpha1.BrokerCell) resources.Fanout
 			MemoryRequest:      bc.Spec.Components.Fanout.MemoryRequest,
 			MemoryLimit:        bc.Spec.Components.Fanout.MemoryLimit,
 			RolloutRestartTime: bc.GetAnnotations()[resources.FanoutRestartTimeAnnotationKey],
+			RolloutStrategy:    bc.Spec.Components.Fanout.RolloutStrategy,
+			BacklogScaling:     bc.Spec.Components.Fanout.BacklogScaling,
 		},
 	}
 }
 
-func (r *Reconciler) makeFanoutHPAArgs(bc *intv1alpha1.BrokerCell) resources.AutoscalingArgs {
+func (r *Reconciler) makeFanoutHPAArgs(bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) resources.AutoscalingArgs {
+	subs, _ := splitQueueSubscriptions(decoupleSubscriptions(targets))
 	return resources.AutoscalingArgs{
-		ComponentName:     resources.FanoutName,
-		BrokerCell:        bc,
-		AvgCPUUtilization: bc.Spec.Components.Fanout.AvgCPUUtilization,
-		AvgMemoryUsage:    bc.Spec.Components.Fanout.AvgMemoryUsage,
-		MaxReplicas:       *bc.Spec.Components.Fanout.MaxReplicas,
-		MinReplicas:       *bc.Spec.Components.Fanout.MinReplicas,
+		ComponentName:        resources.FanoutName,
+		BrokerCell:           bc,
+		AvgCPUUtilization:    bc.Spec.Components.Fanout.AvgCPUUtilization,
+		AvgMemoryUsage:       bc.Spec.Components.Fanout.AvgMemoryUsage,
+		MaxReplicas:          *bc.Spec.Components.Fanout.MaxReplicas,
+		MinReplicas:          *bc.Spec.Components.Fanout.MinReplicas,
+		BacklogScaling:       bc.Spec.Components.Fanout.BacklogScaling,
+		BacklogResourceLabel: "brokers",
+		Subscriptions:        subs,
 	}
 }
 
@@ -300,19 +345,141 @@ func (r *Reconciler) makeRetryArgs(bc *intv1alpha1.BrokerCell) resources.RetryAr
 			MemoryRequest:      bc.Spec.Components.Retry.MemoryRequest,
 			MemoryLimit:        bc.Spec.Components.Retry.MemoryLimit,
 			RolloutRestartTime: bc.GetAnnotations()[resources.RetryRestartTimeAnnotationKey],
+			RolloutStrategy:    bc.Spec.Components.Retry.RolloutStrategy,
+			BacklogScaling:     bc.Spec.Components.Retry.BacklogScaling,
 		},
 	}
 }
 
-func (r *Reconciler) makeRetryHPAArgs(bc *intv1alpha1.BrokerCell) resources.AutoscalingArgs {
+func (r *Reconciler) makeRetryHPAArgs(bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) resources.AutoscalingArgs {
+	subs, _ := splitQueueSubscriptions(retrySubscriptions(targets))
 	return resources.AutoscalingArgs{
-		ComponentName:     resources.RetryName,
-		BrokerCell:        bc,
-		AvgCPUUtilization: bc.Spec.Components.Retry.AvgCPUUtilization,
-		AvgMemoryUsage:    bc.Spec.Components.Retry.AvgMemoryUsage,
-		MaxReplicas:       *bc.Spec.Components.Retry.MaxReplicas,
-		MinReplicas:       *bc.Spec.Components.Retry.MinReplicas,
+		ComponentName:        resources.RetryName,
+		BrokerCell:           bc,
+		AvgCPUUtilization:    bc.Spec.Components.Retry.AvgCPUUtilization,
+		AvgMemoryUsage:       bc.Spec.Components.Retry.AvgMemoryUsage,
+		MaxReplicas:          *bc.Spec.Components.Retry.MaxReplicas,
+		MinReplicas:          *bc.Spec.Components.Retry.MinReplicas,
+		BacklogScaling:       bc.Spec.Components.Retry.BacklogScaling,
+		BacklogResourceLabel: "triggers",
+		Subscriptions:        subs,
+	}
+}
+
+// maxReportedSubscriptions is the number of subscriptions named in the
+// BacklogScalingComplete condition. The others are only counted, so that the
+// condition doesn't grow with the number of triggers.
+const maxReportedSubscriptions = 3
+
+// propagateBacklogScalingStatus reports why the fanout and retry components
+// may not scale on the backlog of the subscriptions they pull from. The keda
+// class needs the broker service account key, as the KEDA scaler doesn't
+// support Workload Identity. Both the external metric and the KEDA scaler only
+// see the subscriptions in the controller's project, so those of brokers in
+// other projects are left out.
+func (r *Reconciler) propagateBacklogScalingStatus(ctx context.Context, bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) {
+	fanoutScaling := bc.Spec.Components.Fanout.BacklogScaling
+	retryScaling := bc.Spec.Components.Retry.BacklogScaling
+	if fanoutScaling == nil && retryScaling == nil {
+		bc.Status.ClearBacklogScaling()
+		return
+	}
+	if isKedaBacklogScaling(fanoutScaling) || isKedaBacklogScaling(retryScaling) {
+		found, err := r.hasBrokerCredentials(ctx, bc.Namespace)
+		if err != nil {
+			bc.Status.MarkBacklogScalingIncomplete("CredentialsUnknown", "Failed to get the %q secret: %v", resources.BrokerCredentialsSecretName, err)
+			return
+		}
+		if !found {
+			bc.Status.MarkBacklogScalingIncomplete("CredentialsNotFound",
+				"The keda class requires the %q key of the %q secret, Workload Identity is not supported",
+				resources.BrokerCredentialsSecretKey, resources.BrokerCredentialsSecretName)
+			return
+		}
+	}
+	var crossProject []string
+	// unscaled counts the subscriptions left out of the KEDA ScaledObjects.
+	var unscaled int
+	if fanoutScaling != nil {
+		ids, subs := splitQueueSubscriptions(decoupleSubscriptions(targets))
+		crossProject = append(crossProject, subs...)
+		if isKedaBacklogScaling(fanoutScaling) && len(ids) > resources.MaxScaledObjectTriggers {
+			unscaled += len(ids) - resources.MaxScaledObjectTriggers
+		}
+	}
+	if retryScaling != nil {
+		ids, subs := splitQueueSubscriptions(retrySubscriptions(targets))
+		crossProject = append(crossProject, subs...)
+		if isKedaBacklogScaling(retryScaling) && len(ids) > resources.MaxScaledObjectTriggers {
+			unscaled += len(ids) - resources.MaxScaledObjectTriggers
+		}
+	}
+	if len(crossProject) > 0 {
+		samples := crossProject
+		if len(samples) > maxReportedSubscriptions {
+			samples = append(samples[:maxReportedSubscriptions:maxReportedSubscriptions], "...")
+		}
+		bc.Status.MarkBacklogScalingIncomplete("CrossProjectSubscriptions",
+			"The backlog of subscriptions in other projects is not scaled on, %d in total: %s", len(crossProject), strings.Join(samples, ", "))
+		return
+	}
+	if unscaled > 0 {
+		bc.Status.MarkBacklogScalingIncomplete("TooManySubscriptions",
+			"The keda class scales on the backlog of at most %d subscriptions per component, %d subscriptions are not scaled on",
+			resources.MaxScaledObjectTriggers, unscaled)
+		return
+	}
+	bc.Status.MarkBacklogScalingComplete()
+}
+
+func isKedaBacklogScaling(spec *intv1alpha1.BacklogScalingSpec) bool {
+	return spec != nil && spec.Class == intv1alpha1.BacklogScalingKeda
+}
+
+// hasBrokerCredentials reports whether the broker service account key exists
+// in the given namespace. The secret is read directly rather than from an
+// informer, as only BrokerCells with the keda class read this one secret.
+func (r *Reconciler) hasBrokerCredentials(ctx context.Context, namespace string) (bool, error) {
+	secret, err := r.KubeClientSet.CoreV1().Secrets(namespace).Get(ctx, resources.BrokerCredentialsSecretName, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, ok := secret.Data[resources.BrokerCredentialsSecretKey]
+	return ok, nil
+}
+
+func (r *Reconciler) makePDBArgs(bc *intv1alpha1.BrokerCell, componentName string, params *intv1alpha1.ComponentParameters) resources.PodDisruptionBudgetArgs {
+	return resources.PodDisruptionBudgetArgs{
+		ComponentName:       componentName,
+		BrokerCell:          bc,
+		PodDisruptionBudget: params.PodDisruptionBudget,
+	}
+}
+
+// reconcileScaling reconciles the HPA of the given deployment, or its KEDA
+// ScaledObject if the component scales on its backlog with KEDA. Whichever of
+// the two is not desired is deleted so that they don't compete.
+func (r *Reconciler) reconcileScaling(ctx context.Context, bc *intv1alpha1.BrokerCell, d *appsv1.Deployment, args resources.AutoscalingArgs) error {
+	// A ScaledObject without triggers is invalid, so fall back to the HPA until
+	// there are subscriptions to scale on.
+	if args.BacklogScaling != nil && args.BacklogScaling.Class == intv1alpha1.BacklogScalingKeda && len(args.Subscriptions) > 0 {
+		if err := r.deleteAutoscaling(ctx, bc, d); err != nil {
+			return err
+		}
+		return r.reconcileScaledObject(ctx, bc, resources.MakeScaledObject(d, args))
+	}
+	if args.BacklogScaling != nil && args.BacklogScaling.Class == intv1alpha1.BacklogScalingKeda {
+		// Only the backlog metric is replaced by KEDA; without it the HPA must
+		// not also add it as an external metric.
+		args.BacklogScaling = nil
 	}
+	if err := r.deleteScaledObject(ctx, bc, d); err != nil {
+		return err
+	}
+	return r.reconcileAutoscaling(ctx, bc, resources.MakeHorizontalPodAutoscaler(d, args))
 }
 
 func (r *Reconciler) reconcileAutoscaling(ctx context.Context, bc *intv1alpha1.BrokerCell, desired *hpav2beta2.HorizontalPodAutoscaler) error {
@@ -343,3 +510,149 @@ func (r *Reconciler) reconcileAutoscaling(ctx context.Context, bc *intv1alpha1.B
 	}
 	return nil
 }
+
+func (r *Reconciler) reconcilePodDisruptionBudget(ctx context.Context, bc *intv1alpha1.BrokerCell, desired *policyv1beta1.PodDisruptionBudget) error {
+	existing, err := r.pdbLister.PodDisruptionBudgets(desired.Namespace).Get(desired.Name)
+	if apierrs.IsNotFound(err) {
+		existing, err = r.KubeClientSet.PolicyV1beta1().PodDisruptionBudgets(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if apierrs.IsAlreadyExists(err) {
+			return nil
+		}
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, "PodDisruptionBudgetCreated", "Created PDB %s/%s", desired.Namespace, desired.Name)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	// DeepDerivative ignores unset fields of the desired spec, so also compare
+	// minAvailable and maxUnavailable as switching between them unsets one.
+	if !equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) ||
+		!equality.Semantic.DeepEqual(desired.Spec.MinAvailable, existing.Spec.MinAvailable) ||
+		!equality.Semantic.DeepEqual(desired.Spec.MaxUnavailable, existing.Spec.MaxUnavailable) {
+		// Don't modify the informers copy.
+		copy := existing.DeepCopy()
+		copy.Spec = desired.Spec
+		_, err := r.KubeClientSet.PolicyV1beta1().PodDisruptionBudgets(copy.Namespace).Update(ctx, copy, metav1.UpdateOptions{})
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, "PodDisruptionBudgetUpdated", "Updated PDB %s/%s", desired.Namespace, desired.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteAutoscaling(ctx context.Context, bc *intv1alpha1.BrokerCell, d *appsv1.Deployment) error {
+	name := resources.HorizontalPodAutoscalerName(d)
+	if _, err := r.hpaLister.HorizontalPodAutoscalers(d.Namespace).Get(name); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	err := r.KubeClientSet.AutoscalingV2beta2().HorizontalPodAutoscalers(d.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(bc, corev1.EventTypeNormal, "HorizontalPodAutoscalerDeleted", "Deleted HPA %s/%s", d.Namespace, name)
+	return nil
+}
+
+func (r *Reconciler) reconcileScaledObject(ctx context.Context, bc *intv1alpha1.BrokerCell, desired *unstructured.Unstructured) error {
+	gvr, _ := meta.UnsafeGuessKindToResource(kedaresources.ScaledObjectGVK)
+	client := r.DynamicClientSet.Resource(gvr).Namespace(desired.GetNamespace())
+	lister, err := r.scaledObjectLister(ctx, bc, desired.GetNamespace(), desired.GetName())
+	if err != nil {
+		return fmt.Errorf("failed to list ScaledObjects, KEDA might not be installed: %w", err)
+	}
+	obj, err := lister.Get(desired.GetName())
+	if apierrs.IsNotFound(err) {
+		_, err = client.Create(ctx, desired, metav1.CreateOptions{})
+		if apierrs.IsAlreadyExists(err) {
+			return nil
+		}
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, "ScaledObjectCreated", "Created ScaledObject %s/%s", desired.GetNamespace(), desired.GetName())
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	existing, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	// The lister only keeps the metadata of the ScaledObjects, so compare the
+	// hashes of the specs.
+	hashKey := resources.ScaledObjectSpecHashAnnotationKey
+	if existing.GetAnnotations()[hashKey] != desired.GetAnnotations()[hashKey] {
+		// Replace the whole ScaledObject, KEDA only writes its status.
+		desired.SetResourceVersion(existing.GetResourceVersion())
+		_, err := client.Update(ctx, desired, metav1.UpdateOptions{})
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, "ScaledObjectUpdated", "Updated ScaledObject %s/%s", desired.GetNamespace(), desired.GetName())
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteScaledObject deletes the ScaledObject of the given deployment if it
+// exists. The ScaledObject is only created while the backlog scaling is
+// enabled, and the HPA of the deployment is deleted in the meantime. So it's
+// only looked up when the status shows that the backlog scaling was enabled
+// and the HPA doesn't exist, which avoids listing ScaledObjects otherwise.
+func (r *Reconciler) deleteScaledObject(ctx context.Context, bc *intv1alpha1.BrokerCell, d *appsv1.Deployment) error {
+	if bc.Status.GetCondition(intv1alpha1.BrokerCellConditionBacklogScaling) == nil {
+		return nil
+	}
+	if _, err := r.hpaLister.HorizontalPodAutoscalers(d.Namespace).Get(resources.HorizontalPodAutoscalerName(d)); err == nil {
+		return nil
+	}
+	name := resources.ScaledObjectName(d)
+	// A NotFound error is also returned when KEDA is not installed.
+	lister, err := r.scaledObjectLister(ctx, bc, d.Namespace, name)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if _, err := lister.Get(name); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(kedaresources.ScaledObjectGVK)
+	if err := r.DynamicClientSet.Resource(gvr).Namespace(d.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(bc, corev1.EventTypeNormal, "ScaledObjectDeleted", "Deleted ScaledObject %s/%s", d.Namespace, name)
+	return nil
+}
+
+// scaledObjectLister returns the lister of the ScaledObjects in the given
+// namespace. The lister only keeps their metadata. The named ScaledObject is
+// tracked so that the BrokerCell is reconciled when it changes.
+func (r *Reconciler) scaledObjectLister(ctx context.Context, bc *intv1alpha1.BrokerCell, namespace, name string) (cache.GenericNamespaceLister, error) {
+	apiVersion, kind := kedaresources.ScaledObjectGVK.ToAPIVersionAndKind()
+	ref := corev1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	}
+	if err := r.scaledObjectTracker.TrackInNamespace(ctx, bc)(ref); err != nil {
+		return nil, err
+	}
+	lister, err := r.scaledObjectTracker.ListerFor(ref)
+	if err != nil {
+		return nil, err
+	}
+	return lister.ByNamespace(namespace), nil
+}
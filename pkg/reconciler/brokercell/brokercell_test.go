@@ -19,26 +19,37 @@ package brokercell
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 
+	eventingduck "knative.dev/eventing/pkg/duck"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/ptr"
 	. "knative.dev/pkg/reconciler/testing"
 
 	"github.com/google/go-cmp/cmp"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/broker/podstatus"
+	"github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1/resource"
 	bcreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/testingdata"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
@@ -56,6 +67,11 @@ const (
 var (
 	testKey = fmt.Sprintf("%s/%s", testNS, brokerCellName)
 
+	externalBacklogScaling = &intv1alpha1.BacklogScalingSpec{
+		Class:         intv1alpha1.BacklogScalingExternalMetrics,
+		TargetBacklog: ptr.Int64(100),
+	}
+
 	creatorAnnotation       = map[string]string{"internal.events.cloud.google.com/creator": "googlecloud"}
 	restartedTimeAnnotation = map[string]string{
 		"events.cloud.google.com/ingressRestartRequestedAt": "2020-09-25T16:28:36-04:00",
@@ -71,14 +87,20 @@ var (
 	ingressDeploymentUpdatedEvent = Eventf(corev1.EventTypeNormal, "DeploymentUpdated", "Updated deployment testnamespace/test-brokercell-brokercell-ingress")
 	ingressHPACreatedEvent        = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerCreated", "Created HPA testnamespace/test-brokercell-brokercell-ingress-hpa")
 	ingressHPAUpdatedEvent        = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerUpdated", "Updated HPA testnamespace/test-brokercell-brokercell-ingress-hpa")
+	ingressPDBCreatedEvent        = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetCreated", "Created PDB testnamespace/test-brokercell-brokercell-ingress-pdb")
+	ingressPDBUpdatedEvent        = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetUpdated", "Updated PDB testnamespace/test-brokercell-brokercell-ingress-pdb")
 	fanoutDeploymentCreatedEvent  = Eventf(corev1.EventTypeNormal, "DeploymentCreated", "Created deployment testnamespace/test-brokercell-brokercell-fanout")
 	fanoutDeploymentUpdatedEvent  = Eventf(corev1.EventTypeNormal, "DeploymentUpdated", "Updated deployment testnamespace/test-brokercell-brokercell-fanout")
 	fanoutHPACreatedEvent         = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerCreated", "Created HPA testnamespace/test-brokercell-brokercell-fanout-hpa")
 	fanoutHPAUpdatedEvent         = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerUpdated", "Updated HPA testnamespace/test-brokercell-brokercell-fanout-hpa")
+	fanoutPDBCreatedEvent         = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetCreated", "Created PDB testnamespace/test-brokercell-brokercell-fanout-pdb")
+	fanoutPDBUpdatedEvent         = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetUpdated", "Updated PDB testnamespace/test-brokercell-brokercell-fanout-pdb")
 	retryDeploymentCreatedEvent   = Eventf(corev1.EventTypeNormal, "DeploymentCreated", "Created deployment testnamespace/test-brokercell-brokercell-retry")
 	retryDeploymentUpdatedEvent   = Eventf(corev1.EventTypeNormal, "DeploymentUpdated", "Updated deployment testnamespace/test-brokercell-brokercell-retry")
 	retryHPACreatedEvent          = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerCreated", "Created HPA testnamespace/test-brokercell-brokercell-retry-hpa")
 	retryHPAUpdatedEvent          = Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerUpdated", "Updated HPA testnamespace/test-brokercell-brokercell-retry-hpa")
+	retryPDBCreatedEvent          = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetCreated", "Created PDB testnamespace/test-brokercell-brokercell-retry-pdb")
+	retryPDBUpdatedEvent          = Eventf(corev1.EventTypeNormal, "PodDisruptionBudgetUpdated", "Updated PDB testnamespace/test-brokercell-brokercell-retry-pdb")
 	ingressServiceCreatedEvent    = Eventf(corev1.EventTypeNormal, "ServiceCreated", "Created service testnamespace/test-brokercell-brokercell-ingress")
 	ingressServiceUpdatedEvent    = Eventf(corev1.EventTypeNormal, "ServiceUpdated", "Updated service testnamespace/test-brokercell-brokercell-ingress")
 	deploymentCreationFailedEvent = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for create deployments")
@@ -87,6 +109,8 @@ var (
 	serviceUpdateFailedEvent      = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for update services")
 	hpaCreationFailedEvent        = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for create horizontalpodautoscalers")
 	hpaUpdateFailedEvent          = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for update horizontalpodautoscalers")
+	pdbCreationFailedEvent        = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for create poddisruptionbudgets")
+	pdbUpdateFailedEvent          = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for update poddisruptionbudgets")
 	configmapCreationFailedEvent  = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for create configmaps")
 	configmapUpdateFailedEvent    = Eventf(corev1.EventTypeWarning, "InternalError", "inducing failure for update configmaps")
 	configmapCreatedEvent         = Eventf(corev1.EventTypeNormal, "ConfigMapCreated", "Created configmap testnamespace/test-brokercell-brokercell-broker-targets")
@@ -154,9 +178,9 @@ func TestAllCases(t *testing.T) {
 				),
 			}},
 			WantEvents: []string{configmapUpdateFailedEvent},
-			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: testingdata.Config(t,
+			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: testingdata.WithConfigGeneration(testingdata.Config(t,
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-				NewBroker("broker", testNS, WithBrokerSetDefaults))}},
+				NewBroker("broker", testNS, WithBrokerSetDefaults)), 2)}},
 			WantErr: true,
 		},
 		{
@@ -174,6 +198,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("IngressDeploymentFailed", `Failed to reconcile ingress deployment: inducing failure for create deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -207,6 +232,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("IngressDeploymentFailed", `Failed to reconcile ingress deployment: inducing failure for update deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -234,6 +260,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile ingress HorizontalPodAutoscaler: inducing failure for create horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -262,6 +289,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile ingress HorizontalPodAutoscaler: inducing failure for update horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -273,6 +301,65 @@ func TestAllCases(t *testing.T) {
 			},
 			WantErr: true,
 		},
+		{
+			Name: "Ingress PodDisruptionBudget.Create error",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("create", "poddisruptionbudgets"),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBrokerCell(brokerCellName, testNS,
+					WithInitBrokerCellConditions,
+					WithTargetsCofigReady(),
+					WithBrokerCellIngressFailed("PodDisruptionBudgetFailed", `Failed to reconcile ingress PodDisruptionBudget: inducing failure for create poddisruptionbudgets`),
+					WithBrokerCellTargetsConfigGeneration(1),
+					WithBrokerCellSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				pdbCreationFailedEvent,
+			},
+			WantCreates: []runtime.Object{
+				testingdata.IngressPDB(t),
+			},
+			WantErr: true,
+		},
+		{
+			Name: "Ingress PodDisruptionBudget.Update error",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				emptyPDBSpec(testingdata.IngressPDB(t)),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("update", "poddisruptionbudgets"),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBrokerCell(brokerCellName, testNS,
+					WithInitBrokerCellConditions,
+					WithTargetsCofigReady(),
+					WithBrokerCellIngressFailed("PodDisruptionBudgetFailed", `Failed to reconcile ingress PodDisruptionBudget: inducing failure for update poddisruptionbudgets`),
+					WithBrokerCellTargetsConfigGeneration(1),
+					WithBrokerCellSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				pdbUpdateFailedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: testingdata.IngressPDB(t)},
+			},
+			WantErr: true,
+		},
 		{
 			Name: "Ingress Service.Create error",
 			Key:  testKey,
@@ -281,6 +368,7 @@ func TestAllCases(t *testing.T) {
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 			},
@@ -292,6 +380,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("IngressServiceFailed", `Failed to reconcile ingress service: inducing failure for create services`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -311,6 +400,7 @@ func TestAllCases(t *testing.T) {
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				NewService(brokerCellName+"-brokercell-ingress", testNS,
@@ -327,6 +417,7 @@ func TestAllCases(t *testing.T) {
 					WithInitBrokerCellConditions,
 					WithTargetsCofigReady(),
 					WithBrokerCellIngressFailed("IngressServiceFailed", `Failed to reconcile ingress service: inducing failure for update services`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -345,6 +436,7 @@ func TestAllCases(t *testing.T) {
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				testingdata.IngressDeploymentWithStatus(t),
@@ -360,6 +452,7 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellIngressAvailable(),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutFailed("FanoutDeploymentFailed", `Failed to reconcile fanout deployment: inducing failure for create deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -378,6 +471,7 @@ func TestAllCases(t *testing.T) {
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				testingdata.IngressDeploymentWithStatus(t),
@@ -400,6 +494,7 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellIngressAvailable(),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutFailed("FanoutDeploymentFailed", `Failed to reconcile fanout deployment: inducing failure for update deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -418,6 +513,7 @@ func TestAllCases(t *testing.T) {
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				testingdata.IngressDeploymentWithStatus(t),
@@ -434,6 +530,7 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellIngressAvailable(),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile fanout HorizontalPodAutoscaler: inducing failure for create horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -452,6 +549,7 @@ func TestAllCases(t *testing.T) {
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				testingdata.IngressDeploymentWithStatus(t),
@@ -469,6 +567,7 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellIngressAvailable(),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile fanout HorizontalPodAutoscaler: inducing failure for update horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -492,7 +591,9 @@ func TestAllCases(t *testing.T) {
 				testingdata.IngressServiceWithStatus(t),
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 			},
 			WithReactors: []clientgotesting.ReactionFunc{
 				InduceFailure("create", "deployments"),
@@ -505,6 +606,7 @@ func TestAllCases(t *testing.T) {
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutAvailable(),
 					WithBrokerCellRetryFailed("RetryDeploymentFailed", `Failed to reconcile retry deployment: inducing failure for create deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -529,7 +631,9 @@ func TestAllCases(t *testing.T) {
 				testingdata.IngressServiceWithStatus(t),
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				// Create an deployment such that only the spec is different from expected deployment to trigger an update.
 				NewDeployment(brokerCellName+"-brokercell-retry", testNS,
 					func(d *appsv1.Deployment) {
@@ -549,6 +653,7 @@ func TestAllCases(t *testing.T) {
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutAvailable(),
 					WithBrokerCellRetryFailed("RetryDeploymentFailed", `Failed to reconcile retry deployment: inducing failure for update deployments`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -573,7 +678,9 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 			},
 			WithReactors: []clientgotesting.ReactionFunc{
 				InduceFailure("create", "horizontalpodautoscalers"),
@@ -586,6 +693,7 @@ func TestAllCases(t *testing.T) {
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutAvailable(),
 					WithBrokerCellRetryFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile retry HorizontalPodAutoscaler: inducing failure for create horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -610,7 +718,9 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				emptyHPASpec(testingdata.RetryHPA(t)),
 			},
 			WithReactors: []clientgotesting.ReactionFunc{
@@ -624,6 +734,7 @@ func TestAllCases(t *testing.T) {
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
 					WithBrokerCellFanoutAvailable(),
 					WithBrokerCellRetryFailed("HorizontalPodAutoscalerFailed", `Failed to reconcile retry HorizontalPodAutoscaler: inducing failure for update horizontalpodautoscalers`),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -646,11 +757,14 @@ func TestAllCases(t *testing.T) {
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				testingdata.IngressDeployment(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.IngressService(t),
 				testingdata.FanoutDeployment(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				testingdata.RetryDeployment(t),
 				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{Object: NewBrokerCell(brokerCellName, testNS,
@@ -663,6 +777,7 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellFanoutUnknown("DeploymentUnavailable", `Deployment "test-brokercell-brokercell-fanout" is unavailable.`),
 					WithBrokerCellRetryUnknown("DeploymentUnavailable", `Deployment "test-brokercell-brokercell-retry" is unavailable.`),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				)},
 			},
@@ -670,11 +785,14 @@ func TestAllCases(t *testing.T) {
 				configmapCreatedEvent,
 				ingressDeploymentCreatedEvent,
 				ingressHPACreatedEvent,
+				ingressPDBCreatedEvent,
 				ingressServiceCreatedEvent,
 				fanoutDeploymentCreatedEvent,
 				fanoutHPACreatedEvent,
+				fanoutPDBCreatedEvent,
 				retryDeploymentCreatedEvent,
 				retryHPACreatedEvent,
+				retryPDBCreatedEvent,
 				brokerCellReconciledEvent,
 			},
 		},
@@ -711,20 +829,26 @@ func TestAllCases(t *testing.T) {
 					},
 				),
 				emptyHPASpec(testingdata.IngressHPA(t)),
+				emptyPDBSpec(testingdata.IngressPDB(t)),
 				emptyHPASpec(testingdata.FanoutHPA(t)),
+				emptyPDBSpec(testingdata.FanoutPDB(t)),
 				emptyHPASpec(testingdata.RetryHPA(t)),
+				emptyPDBSpec(testingdata.RetryPDB(t)),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
-				{Object: testingdata.Config(t,
+				{Object: testingdata.WithConfigGeneration(testingdata.Config(t,
 					NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-					NewBroker("broker", testNS, WithBrokerSetDefaults))},
+					NewBroker("broker", testNS, WithBrokerSetDefaults)), 2)},
 				{Object: testingdata.IngressDeployment(t)},
 				{Object: testingdata.IngressHPA(t)},
+				{Object: testingdata.IngressPDB(t)},
 				{Object: testingdata.IngressService(t)},
 				{Object: testingdata.FanoutDeployment(t)},
 				{Object: testingdata.FanoutHPA(t)},
+				{Object: testingdata.FanoutPDB(t)},
 				{Object: testingdata.RetryDeployment(t)},
 				{Object: testingdata.RetryHPA(t)},
+				{Object: testingdata.RetryPDB(t)},
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{Object: NewBrokerCell(brokerCellName, testNS,
@@ -737,6 +861,8 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellFanoutUnknown("DeploymentUnavailable", `Deployment "test-brokercell-brokercell-fanout" is unavailable.`),
 					WithBrokerCellRetryUnknown("DeploymentUnavailable", `Deployment "test-brokercell-brokercell-retry" is unavailable.`),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(2),
+					WithBrokerCellTargetsCount(1, 0),
 					WithBrokerCellSetDefaults,
 				)},
 			},
@@ -744,11 +870,14 @@ func TestAllCases(t *testing.T) {
 				configmapUpdatedEvent,
 				ingressDeploymentUpdatedEvent,
 				ingressHPAUpdatedEvent,
+				ingressPDBUpdatedEvent,
 				ingressServiceUpdatedEvent,
 				fanoutDeploymentUpdatedEvent,
 				fanoutHPAUpdatedEvent,
+				fanoutPDBUpdatedEvent,
 				retryDeploymentUpdatedEvent,
 				retryHPAUpdatedEvent,
+				retryPDBUpdatedEvent,
 				brokerCellReconciledEvent,
 			},
 		},
@@ -765,8 +894,11 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
 			},
 			WithReactors: []clientgotesting.ReactionFunc{
 				InduceFailure("update", "brokercells"),
@@ -775,6 +907,7 @@ func TestAllCases(t *testing.T) {
 				{Object: NewBrokerCell(brokerCellName, testNS,
 					WithBrokerCellReady,
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				)},
 			},
@@ -796,13 +929,17 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{Object: NewBrokerCell(brokerCellName, testNS,
 					WithBrokerCellReady,
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				)},
 			},
@@ -827,18 +964,69 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{Object: NewBrokerCell(brokerCellName, testNS,
 					WithBrokerCellAnnotations(creatorAnnotation),
 					WithBrokerCellReady,
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
+					WithBrokerCellTargetsCount(1, 0),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "Backlog scaling leaves out the subscriptions of brokers in other projects",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellSetDefaults,
+					WithBrokerCellFanoutBacklogScaling(externalBacklogScaling)),
+				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+					NewBroker("broker", testNS, WithBrokerProject("tenant-project"), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerProject("tenant-project"), WithBrokerSetDefaults),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
+				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
+				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				// The metric only selects the subscriptions in the project of the
+				// metrics adapter, so it can't cover those of the broker.
+				{Object: withBacklogMetric(testingdata.FanoutHPA(t), "brokers", 100)},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
+					WithBrokerCellTargetsCount(1, 0),
 					WithBrokerCellSetDefaults,
+					WithBrokerCellFanoutBacklogScaling(externalBacklogScaling),
+					WithBrokerCellBacklogScalingIncomplete("CrossProjectSubscriptions",
+						"The backlog of subscriptions in other projects is not scaled on, 1 in total: "+
+							config.FullSubscriptionName("tenant-project", brokerresources.GenerateDecouplingSubscriptionName(NewBroker("broker", testNS)))),
 				)},
 			},
 			WantEvents: []string{
+				fanoutHPAUpdatedEvent,
 				brokerCellReconciledEvent,
 			},
 		},
@@ -883,6 +1071,86 @@ func TestAllCases(t *testing.T) {
 				},
 			},
 			WantEvents: []string{brokerCellGCEvent},
+		}, {
+			Name: "Data plane pods report a stale targets config",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
+				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
+				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
+				dataPlanePod("ingress-1", resources.IngressName, "1", false),
+				dataPlanePod("fanout-1", resources.FanoutName, "1", false),
+				dataPlanePod("fanout-2", resources.FanoutName, "1", true),
+				// Pods that don't report are ignored.
+				dataPlanePod("retry-1", resources.RetryName, "", false),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
+					func(bc *intv1alpha1.BrokerCell) {
+						bc.Status.Components.Ingress.TargetsConfigGeneration = 1
+						bc.Status.Components.Fanout.TargetsConfigGeneration = 1
+						bc.Status.Components.Fanout.StaleReplicas = 1
+						bc.Status.MarkDataPlaneConfigStale("StaleReplicas", "1 data plane pods failed to sync the broker targets config")
+					},
+					WithBrokerCellSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				brokerCellReconciledEvent,
+			},
+		}, {
+			Name: "Data plane pods report the latest targets config",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
+				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
+				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
+				dataPlanePod("ingress-1", resources.IngressName, "1", false),
+				dataPlanePod("fanout-1", resources.FanoutName, "1", false),
+				dataPlanePod("retry-1", resources.RetryName, "1", false),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
+					func(bc *intv1alpha1.BrokerCell) {
+						bc.Status.Components.Ingress.TargetsConfigGeneration = 1
+						bc.Status.Components.Fanout.TargetsConfigGeneration = 1
+						bc.Status.Components.Retry.TargetsConfigGeneration = 1
+						bc.Status.MarkDataPlaneConfigFresh()
+					},
+					WithBrokerCellSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				brokerCellReconciledEvent,
+			},
 		}, {
 			Name: "Brokercell has restart time annotation, deployments are updated with restart time annotation successfully",
 			Key:  testKey,
@@ -897,14 +1165,18 @@ func TestAllCases(t *testing.T) {
 				testingdata.FanoutDeploymentWithStatus(t),
 				testingdata.RetryDeploymentWithStatus(t),
 				testingdata.IngressHPA(t),
+				testingdata.IngressPDB(t),
 				testingdata.FanoutHPA(t),
+				testingdata.FanoutPDB(t),
 				testingdata.RetryHPA(t),
+				testingdata.RetryPDB(t),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewBrokerCell(brokerCellName, testNS,
 					WithBrokerCellReady,
 					WithBrokerCellAnnotations(restartedTimeAnnotation),
 					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellTargetsConfigGeneration(1),
 					WithBrokerCellSetDefaults,
 				),
 			}},
@@ -934,12 +1206,14 @@ func TestAllCases(t *testing.T) {
 			endpointsLister:  testingListers.GetEndpointsLister(),
 			deploymentLister: testingListers.GetDeploymentLister(),
 			podLister:        testingListers.GetPodLister(),
+			pdbLister:        testingListers.GetPDBLister(),
 		}
 
 		r, err := NewReconciler(base, ls)
 		if err != nil {
 			t.Fatalf("Failed to created BrokerCell reconciler: %v", err)
 		}
+		r.scaledObjectTracker = eventingduck.NewListableTracker(resource.WithDuck(ctx), resource.Get, func(types.NamespacedName) {}, 0)
 		return bcreconciler.NewReconciler(ctx, r.Logger, r.RunClientSet, testingListers.GetBrokerCellLister(), r.Recorder, r)
 	}))
 }
@@ -949,6 +1223,35 @@ func emptyHPASpec(template *hpav2beta2.HorizontalPodAutoscaler) *hpav2beta2.Hori
 	return template
 }
 
+// withBacklogMetric adds the backlog metric of the subscriptions of the given
+// resource to the HPA.
+func withBacklogMetric(hpa *hpav2beta2.HorizontalPodAutoscaler, resource string, target int64) *hpav2beta2.HorizontalPodAutoscaler {
+	hpa.Spec.Metrics = append(hpa.Spec.Metrics, hpav2beta2.MetricSpec{
+		Type: hpav2beta2.ExternalMetricSourceType,
+		External: &hpav2beta2.ExternalMetricSource{
+			Metric: hpav2beta2.MetricIdentifier{
+				Name: "pubsub.googleapis.com|subscription|num_undelivered_messages",
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"metadata.user_labels.resource":                              resource,
+						"metadata.user_labels." + resources.BrokerCellPubsubLabelKey: resources.BrokerCellPubsubLabelValue(NewBrokerCell(brokerCellName, testNS)),
+					},
+				},
+			},
+			Target: hpav2beta2.MetricTarget{
+				Type:         hpav2beta2.AverageValueMetricType,
+				AverageValue: apiresource.NewQuantity(target, apiresource.DecimalSI),
+			},
+		},
+	})
+	return hpa
+}
+
+func emptyPDBSpec(template *policyv1beta1.PodDisruptionBudget) *policyv1beta1.PodDisruptionBudget {
+	template.Spec = policyv1beta1.PodDisruptionBudgetSpec{}
+	return template
+}
+
 // The unit test to test when the brokerCell created successfully, the broker targets config should be updated with broker
 // and trigger. Since the serialization order of the binary data of brokerTargets in the configMap is not guaranteed, we need
 // to deserialization the binary data to a brokerTargets proto to compare, so it should be rewritten without using the tableTest Utility.
@@ -975,6 +1278,7 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 		endpointsLister:  testingListers.GetEndpointsLister(),
 		deploymentLister: testingListers.GetDeploymentLister(),
 		podLister:        testingListers.GetPodLister(),
+		pdbLister:        testingListers.GetPDBLister(),
 	}
 	r, err := NewReconciler(base, ls)
 	if err != nil {
@@ -1008,3 +1312,193 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 		t.Fatalf("Unexpected brokerTargets in ConfigMap(-want, +got): %s", diff)
 	}
 }
+
+func TestPropagateBacklogScalingStatus(t *testing.T) {
+	kedaBacklogScaling := &intv1alpha1.BacklogScalingSpec{
+		Class:         intv1alpha1.BacklogScalingKeda,
+		TargetBacklog: ptr.Int64(100),
+	}
+	brokerCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: resources.BrokerCredentialsSecretName},
+		Data:       map[string][]byte{resources.BrokerCredentialsSecretKey: []byte("{}")},
+	}
+	// brokerTargets makes targets with a broker in its own project per
+	// subscription ID.
+	brokerTargets := func(subs ...string) config.Targets {
+		targets := memory.NewEmptyTargets()
+		for _, sub := range subs {
+			targets.MutateBroker(testNS, sub, func(m config.BrokerMutation) {
+				m.SetDecoupleQueue(&config.Queue{Subscription: config.FullSubscriptionName("tenant-"+sub, sub)})
+			})
+		}
+		return targets
+	}
+	// localBrokerTargets makes targets with the given number of brokers in
+	// the controller's project.
+	localBrokerTargets := func(n int) config.Targets {
+		targets := memory.NewEmptyTargets()
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("broker%d", i)
+			targets.MutateBroker(testNS, name, func(m config.BrokerMutation) {
+				m.SetDecoupleQueue(&config.Queue{Subscription: name})
+			})
+		}
+		return targets
+	}
+
+	testCases := []struct {
+		name       string
+		scaling    *intv1alpha1.BacklogScalingSpec
+		objects    []runtime.Object
+		targets    config.Targets
+		wantStatus corev1.ConditionStatus
+		wantReason string
+		wantMsg    string
+	}{{
+		name:       "keda without the broker credentials",
+		scaling:    kedaBacklogScaling,
+		targets:    brokerTargets(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "CredentialsNotFound",
+		wantMsg:    `The keda class requires the "key.json" key of the "google-broker-key" secret, Workload Identity is not supported`,
+	}, {
+		name:    "keda with a broker credentials secret without key",
+		scaling: kedaBacklogScaling,
+		objects: []runtime.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: resources.BrokerCredentialsSecretName},
+		}},
+		targets:    brokerTargets(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "CredentialsNotFound",
+		wantMsg:    `The keda class requires the "key.json" key of the "google-broker-key" secret, Workload Identity is not supported`,
+	}, {
+		name:       "keda with the broker credentials",
+		scaling:    kedaBacklogScaling,
+		objects:    []runtime.Object{brokerCredentials},
+		targets:    brokerTargets(),
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "external without the broker credentials",
+		scaling:    externalBacklogScaling,
+		targets:    brokerTargets(),
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "few cross-project subscriptions",
+		scaling:    externalBacklogScaling,
+		targets:    brokerTargets("sub1", "sub2"),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "CrossProjectSubscriptions",
+		wantMsg: "The backlog of subscriptions in other projects is not scaled on, 2 in total: " +
+			"projects/tenant-sub1/subscriptions/sub1, projects/tenant-sub2/subscriptions/sub2",
+	}, {
+		name:       "many cross-project subscriptions",
+		scaling:    externalBacklogScaling,
+		targets:    brokerTargets("sub1", "sub2", "sub3", "sub4", "sub5"),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "CrossProjectSubscriptions",
+		wantMsg: "The backlog of subscriptions in other projects is not scaled on, 5 in total: " +
+			"projects/tenant-sub1/subscriptions/sub1, projects/tenant-sub2/subscriptions/sub2, projects/tenant-sub3/subscriptions/sub3, ...",
+	}, {
+		name:       "keda with more subscriptions than triggers",
+		scaling:    kedaBacklogScaling,
+		objects:    []runtime.Object{brokerCredentials},
+		targets:    localBrokerTargets(resources.MaxScaledObjectTriggers + 2),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "TooManySubscriptions",
+		wantMsg:    "The keda class scales on the backlog of at most 20 subscriptions per component, 2 subscriptions are not scaled on",
+	}, {
+		name:       "external with more subscriptions than keda triggers",
+		scaling:    externalBacklogScaling,
+		targets:    localBrokerTargets(resources.MaxScaledObjectTriggers + 2),
+		wantStatus: corev1.ConditionTrue,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{
+				Base: &reconciler.Base{KubeClientSet: fakekubeclientset.NewSimpleClientset(tc.objects...)},
+			}
+			bc := NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults, WithBrokerCellFanoutBacklogScaling(tc.scaling))
+			r.propagateBacklogScalingStatus(context.Background(), bc, tc.targets)
+
+			cond := bc.Status.GetCondition(intv1alpha1.BrokerCellConditionBacklogScaling)
+			if cond == nil {
+				t.Fatal("BacklogScalingComplete condition is not set")
+			}
+			if cond.Status != tc.wantStatus || cond.Reason != tc.wantReason || cond.Message != tc.wantMsg {
+				t.Errorf("BacklogScalingComplete = (%s, %q, %q), want (%s, %q, %q)",
+					cond.Status, cond.Reason, cond.Message, tc.wantStatus, tc.wantReason, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDeleteScaledObjectWithoutBacklogScaling(t *testing.T) {
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "fanout"}}
+	hpa := &hpav2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: resources.HorizontalPodAutoscalerName(d)},
+	}
+	withBacklogScaling := NewBrokerCell(brokerCellName, testNS)
+	withBacklogScaling.Status.MarkBacklogScalingComplete()
+
+	testCases := []struct {
+		name    string
+		bc      *intv1alpha1.BrokerCell
+		objects []runtime.Object
+	}{{
+		name: "backlog scaling never enabled",
+		bc:   NewBrokerCell(brokerCellName, testNS),
+	}, {
+		name:    "backlog scaling enabled with the HPA",
+		bc:      withBacklogScaling,
+		objects: []runtime.Object{hpa},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testingListers := NewListers(tc.objects)
+			// Without a ScaledObject tracker, any lookup of the ScaledObject
+			// panics.
+			r := &Reconciler{listers: listers{hpaLister: testingListers.GetHPALister()}}
+			if err := r.deleteScaledObject(context.Background(), tc.bc, d); err != nil {
+				t.Errorf("deleteScaledObject() = %v", err)
+			}
+		})
+	}
+}
+
+func TestQueueNames(t *testing.T) {
+	b := NewBroker("broker", testNS, WithBrokerSetDefaults)
+	if got, want := queueTopicName(b, "topic-id"), "topic-id"; got != want {
+		t.Errorf("queueTopicName() = %q, want %q", got, want)
+	}
+	if got, want := queueSubscriptionName(b, "sub-id"), "sub-id"; got != want {
+		t.Errorf("queueSubscriptionName() = %q, want %q", got, want)
+	}
+
+	b = NewBroker("broker", testNS, WithBrokerProject("tenant-project"), WithBrokerSetDefaults)
+	if got, want := queueTopicName(b, "topic-id"), "projects/tenant-project/topics/topic-id"; got != want {
+		t.Errorf("queueTopicName() = %q, want %q", got, want)
+	}
+	if got, want := queueSubscriptionName(b, "sub-id"), "projects/tenant-project/subscriptions/sub-id"; got != want {
+		t.Errorf("queueSubscriptionName() = %q, want %q", got, want)
+	}
+}
+
+// dataPlanePod creates a pod of the given BrokerCell component. An empty
+// generation means the pod doesn't report its config status.
+func dataPlanePod(name, component, generation string, stale bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNS,
+			Labels:    resources.Labels(brokerCellName, component),
+		},
+	}
+	if generation != "" {
+		pod.Annotations = map[string]string{
+			podstatus.ConfigGenerationAnnotationKey: generation,
+			podstatus.ConfigStaleAnnotationKey:      strconv.FormatBool(stale),
+		}
+	}
+	return pod
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercell
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	"github.com/google/knative-gcp/pkg/broker/podstatus"
+	"github.com/google/knative-gcp/pkg/logging"
+	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+)
+
+// propagateComponentsStatus reports the replicas of each component and the
+// broker targets config generation served by its pods, as reported by the pods
+// through annotations.
+func (r *Reconciler) propagateComponentsStatus(ctx context.Context, bc *intv1alpha1.BrokerCell, ind, fd, rd *appsv1.Deployment) {
+	var reported bool
+	bc.Status.Components.Ingress, reported = r.componentStatus(ctx, bc, resources.IngressName, ind)
+	anyReported := reported
+	bc.Status.Components.Fanout, reported = r.componentStatus(ctx, bc, resources.FanoutName, fd)
+	anyReported = anyReported || reported
+	bc.Status.Components.Retry, reported = r.componentStatus(ctx, bc, resources.RetryName, rd)
+	anyReported = anyReported || reported
+
+	// Pods of older versions don't report their config status; don't claim
+	// anything about it until at least one pod has reported.
+	if !anyReported {
+		return
+	}
+	cs := bc.Status.Components
+	if stale := cs.Ingress.StaleReplicas + cs.Fanout.StaleReplicas + cs.Retry.StaleReplicas; stale > 0 {
+		bc.Status.MarkDataPlaneConfigStale("StaleReplicas", "%d data plane pods failed to sync the broker targets config", stale)
+		return
+	}
+	for _, c := range []intv1alpha1.ComponentStatus{cs.Ingress, cs.Fanout, cs.Retry} {
+		if c.TargetsConfigGeneration < bc.Status.TargetsConfigGeneration {
+			bc.Status.MarkDataPlaneConfigUnknown("ConfigPropagating", "Broker targets config generation %d is being propagated to the data plane pods", bc.Status.TargetsConfigGeneration)
+			return
+		}
+	}
+	bc.Status.MarkDataPlaneConfigFresh()
+}
+
+// componentStatus aggregates the status of the given component's deployment
+// and pods. It also returns whether any pod reported its config status.
+func (r *Reconciler) componentStatus(ctx context.Context, bc *intv1alpha1.BrokerCell, component string, d *appsv1.Deployment) (intv1alpha1.ComponentStatus, bool) {
+	var cs intv1alpha1.ComponentStatus
+	if d.Spec.Replicas != nil {
+		cs.Replicas = *d.Spec.Replicas
+	}
+	cs.ReadyReplicas = d.Status.ReadyReplicas
+
+	pods, err := r.podLister.Pods(bc.Namespace).List(labels.SelectorFromSet(resources.Labels(bc.Name, component)))
+	if err != nil {
+		// The component status is informational only, don't fail the reconciliation.
+		logging.FromContext(ctx).Warn("Failed to list pods", zap.String("component", component), zap.Error(err))
+		return cs, false
+	}
+	reported := false
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		genStr, ok := pod.Annotations[podstatus.ConfigGenerationAnnotationKey]
+		if !ok {
+			continue
+		}
+		gen, err := strconv.ParseInt(genStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		if !reported || gen < cs.TargetsConfigGeneration {
+			cs.TargetsConfigGeneration = gen
+		}
+		reported = true
+		if stale, _ := strconv.ParseBool(pod.Annotations[podstatus.ConfigStaleAnnotationKey]); stale {
+			cs.StaleReplicas++
+		}
+	}
+	return cs, reported
+}
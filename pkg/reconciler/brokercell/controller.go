@@ -25,10 +25,13 @@ import (
 	"go.uber.org/zap"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	"github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1/resource"
 	brokerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker"
 	triggerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/trigger"
 	brokercellinformer "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell"
 	hpainformer "github.com/google/knative-gcp/pkg/client/injection/kube/informers/autoscaling/v2beta2/horizontalpodautoscaler"
+	podinformer "github.com/google/knative-gcp/pkg/client/injection/kube/informers/core/v1/pod/filtered"
+	pdbinformer "github.com/google/knative-gcp/pkg/client/injection/kube/informers/policy/v1beta1/poddisruptionbudget"
 	v1alpha1brokercell "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
 	"github.com/google/knative-gcp/pkg/logging"
 	"github.com/google/knative-gcp/pkg/metrics"
@@ -39,10 +42,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	eventingduck "knative.dev/eventing/pkg/duck"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
-	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -81,6 +84,7 @@ func NewController(
 		endpointsLister:  endpointsinformer.Get(ctx).Lister(),
 		deploymentLister: deploymentinformer.Get(ctx).Lister(),
 		podLister:        podinformer.Get(ctx).Lister(),
+		pdbLister:        pdbinformer.Get(ctx).Lister(),
 	}
 
 	base := reconciler.NewBase(ctx, controllerAgentName, cmw)
@@ -131,6 +135,15 @@ func NewController(
 	hpainformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
 	// 4. Watch the broker targets configmap.
 	configmapinformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
+	// 5. Watch pdb for ingress, fanout and retry deployments
+	pdbinformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
+	// 6. Watch data plane pods, which report the broker targets config they
+	// have loaded through annotations. The informer only watches the pods with
+	// the BrokerCell labels.
+	podinformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
+	// 7. Track the KEDA ScaledObjects of the components that scale on their
+	// backlog. Their informer is only started once a ScaledObject is tracked.
+	r.scaledObjectTracker = eventingduck.NewListableTracker(ctx, resource.Get, impl.EnqueueKey, controller.GetTrackerLease(ctx))
 
 	return impl
 }
@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
@@ -30,17 +31,23 @@ import (
 	"knative.dev/pkg/system"
 	tracingconfig "knative.dev/pkg/tracing/config"
 
+	"github.com/google/knative-gcp/pkg/client/injection/kube/informers/factory/filtered"
+	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+
 	// Fake injection informers
+	_ "github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1/resource/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/trigger/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/kube/informers/autoscaling/v2beta2/horizontalpodautoscaler/fake"
+	_ "github.com/google/knative-gcp/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
+	_ "github.com/google/knative-gcp/pkg/client/injection/kube/informers/policy/v1beta1/poddisruptionbudget/fake"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/conditions/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
-	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	_ "knative.dev/pkg/injection/clients/dynamicclient/fake"
 )
 
 func TestNew(t *testing.T) {
@@ -77,6 +84,18 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestPodInformerSelectsDataPlanePods(t *testing.T) {
+	selector, err := labels.Parse(filtered.LabelSelector)
+	if err != nil {
+		t.Fatalf("Failed to parse the pod informer label selector: %v", err)
+	}
+	for _, component := range []string{resources.IngressName, resources.FanoutName, resources.RetryName} {
+		if !selector.Matches(labels.Set(resources.Labels("default", component))) {
+			t.Errorf("The pod informer label selector %q doesn't select the %s pods", filtered.LabelSelector, component)
+		}
+	}
+}
+
 func setReconcilerEnv() {
 	_ = os.Setenv("BROKER_CELL_INGRESS_IMAGE", "ingress")
 	_ = os.Setenv("BROKER_CELL_FANOUT_IMAGE", "fanout")
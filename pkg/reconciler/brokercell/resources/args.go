@@ -38,6 +38,10 @@ const (
 	FanoutRestartTimeAnnotationKey  = "events.cloud.google.com/fanoutRestartRequestedAt"
 	RetryRestartTimeAnnotationKey   = "events.cloud.google.com/retryRestartRequestedAt"
 	RolloutRestartTimeAnnotationKey = "events.cloud.google.com/RestartRequestedAt"
+
+	// AdminTokenSecretName is the optional secret holding the token of the
+	// data plane admin endpoints under the "token" key.
+	AdminTokenSecretName = "broker-admin-token"
 )
 
 var (
@@ -57,6 +61,12 @@ type Args struct {
 	MemoryRequest      string
 	MemoryLimit        string
 	RolloutRestartTime string
+	// BacklogScaling is set when the component scales on its Pub/Sub
+	// subscription backlog.
+	BacklogScaling *intv1alpha1.BacklogScalingSpec
+	// RolloutStrategy optionally overrides the rolling update parameters of
+	// the deployment.
+	RolloutStrategy *intv1alpha1.RolloutStrategySpec
 }
 
 // IngressArgs are the arguments to create a Broker's ingress Deployment.
@@ -83,6 +93,16 @@ type AutoscalingArgs struct {
 	AvgMemoryUsage    *string
 	MaxReplicas       int32
 	MinReplicas       int32
+	// BacklogScaling optionally adds the Pub/Sub subscription backlog to the
+	// metrics the component scales on.
+	BacklogScaling *intv1alpha1.BacklogScalingSpec
+	// BacklogResourceLabel is the value of the "resource" user label carried
+	// by the Pub/Sub subscriptions the component pulls from.
+	BacklogResourceLabel string
+	// Subscriptions are the IDs of the Pub/Sub subscriptions the component
+	// pulls from in the project of the broker credentials. The subscriptions
+	// of brokers in other projects are not scaled on.
+	Subscriptions []string
 }
 
 // Labels generates the labels present on all resources representing the
@@ -18,12 +18,14 @@ package resources
 
 import (
 	"fmt"
+	"strconv"
 
 	"google.golang.org/protobuf/proto"
 	"knative.dev/pkg/kmeta"
 
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/volume"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,12 +33,55 @@ import (
 const (
 	targetsCMName = "broker-targets"
 	targetsCMKey  = "targets"
+	// targetsCMGenerationKey is mounted next to the targets config, so it must
+	// match the file name the data plane reads the generation from.
+	targetsCMGenerationKey = volume.GenerationFileName
 )
 
-// TargetsConfigMapEqual compares the binary data contained in two TargetsConfig
+// TargetsConfigMapEqual compares the binary data and the generation contained in
+// two TargetsConfig ConfigMaps and returns true if and only if the inputs are
+// valid and the unmarshaled binary data and the generations are equal.
+func TargetsConfigMapEqual(cm1, cm2 *corev1.ConfigMap) bool {
+	return targetsEqual(cm1, cm2) && TargetsConfigGeneration(cm1) == TargetsConfigGeneration(cm2)
+}
+
+// TargetsConfigGeneration returns the generation of the TargetsConfig ConfigMap,
+// or 0 if it doesn't have one.
+func TargetsConfigGeneration(cm *corev1.ConfigMap) int64 {
+	if cm == nil {
+		return 0
+	}
+	gen, _ := strconv.ParseInt(cm.Data[targetsCMGenerationKey], 10, 64)
+	return gen
+}
+
+// SetTargetsConfigGeneration sets the generation of the TargetsConfig ConfigMap.
+func SetTargetsConfigGeneration(cm *corev1.ConfigMap, gen int64) {
+	if cm.Data == nil {
+		cm.Data = make(map[string]string, 1)
+	}
+	cm.Data[targetsCMGenerationKey] = strconv.FormatInt(gen, 10)
+}
+
+// NextTargetsConfigGeneration returns the generation the desired TargetsConfig
+// ConfigMap should have given the existing one, which may be nil. The
+// generation is only bumped when the targets change, so that data plane pods
+// can report which version of the config they have loaded.
+func NextTargetsConfigGeneration(existing, desired *corev1.ConfigMap) int64 {
+	gen := TargetsConfigGeneration(existing)
+	if gen == 0 || !targetsEqual(existing, desired) {
+		gen++
+	}
+	return gen
+}
+
+// targetsEqual compares the binary data contained in two TargetsConfig
 // ConfigMaps and returns true if and only if the inputs are valid and the
 // unmarshaled binary data are equal.
-func TargetsConfigMapEqual(cm1, cm2 *corev1.ConfigMap) bool {
+func targetsEqual(cm1, cm2 *corev1.ConfigMap) bool {
+	if cm1 == nil || cm2 == nil {
+		return false
+	}
 	// The broker targets ConfigMap BinaryData holds the serialized TargetsConfig
 	// proto, and therefore cannot be safely compared with equality.Semantic.DeepEqual.
 	// Instead, use proto.Equal to compare protos.
@@ -115,3 +115,48 @@ func TestMakeTargetsConfig(t *testing.T) {
 		t.Errorf("Error making TargetsConfig: %v", err)
 	}
 }
+
+func TestNextTargetsConfigGeneration(t *testing.T) {
+	withGen := func(cm *corev1.ConfigMap, gen int64) *corev1.ConfigMap {
+		cm = cm.DeepCopy()
+		SetTargetsConfigGeneration(cm, gen)
+		return cm
+	}
+	var tests = []struct {
+		name     string
+		existing *corev1.ConfigMap
+		desired  *corev1.ConfigMap
+		wantGen  int64
+	}{
+		{
+			name:    "no existing config",
+			desired: testConfigMap([]string{"broker1"}, "ns"),
+			wantGen: 1,
+		},
+		{
+			name:     "existing config without generation",
+			existing: testConfigMap([]string{"broker1"}, "ns"),
+			desired:  testConfigMap([]string{"broker1"}, "ns"),
+			wantGen:  1,
+		},
+		{
+			name:     "same targets",
+			existing: withGen(testConfigMap([]string{"broker1"}, "ns"), 3),
+			desired:  testConfigMap([]string{"broker1"}, "ns"),
+			wantGen:  3,
+		},
+		{
+			name:     "different targets",
+			existing: withGen(testConfigMap([]string{"broker1"}, "ns"), 3),
+			desired:  testConfigMap([]string{"broker2"}, "ns"),
+			wantGen:  4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := NextTargetsConfigGeneration(test.existing, test.desired); got != test.wantGen {
+				t.Errorf("Unexpected generation %d, wanted %d", got, test.wantGen)
+			}
+		})
+	}
+}
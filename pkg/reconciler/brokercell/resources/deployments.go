@@ -19,6 +19,7 @@ package resources
 import (
 	"strconv"
 
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/handler"
 	resourceutil "github.com/google/knative-gcp/pkg/utils/resource"
 	appsv1 "k8s.io/api/apps/v1"
@@ -71,6 +72,9 @@ func MakeIngressDeployment(args IngressArgs) *appsv1.Deployment {
 func MakeFanoutDeployment(args FanoutArgs) *appsv1.Deployment {
 	container := containerTemplate(args.Args)
 	container.Resources = resourceutil.BuildResourceRequirements(args.CPURequest, args.CPULimit, args.MemoryRequest, args.MemoryLimit)
+	if args.BacklogScaling != nil && args.BacklogScaling.Class == intv1alpha1.BacklogScalingKeda {
+		container.Env = append(container.Env, kedaCredentialsEnvVar())
+	}
 	container.Ports = append(container.Ports,
 		corev1.ContainerPort{
 			Name:          "http-health",
@@ -102,6 +106,9 @@ func MakeFanoutDeployment(args FanoutArgs) *appsv1.Deployment {
 func MakeRetryDeployment(args RetryArgs) *appsv1.Deployment {
 	container := containerTemplate(args.Args)
 	container.Resources = resourceutil.BuildResourceRequirements(args.CPURequest, args.CPULimit, args.MemoryRequest, args.MemoryLimit)
+	if args.BacklogScaling != nil && args.BacklogScaling.Class == intv1alpha1.BacklogScalingKeda {
+		container.Env = append(container.Env, kedaCredentialsEnvVar())
+	}
 	container.Ports = append(container.Ports,
 		corev1.ContainerPort{
 			Name:          "http-health",
@@ -143,10 +150,7 @@ func deploymentTemplate(args Args, containers []corev1.Container) *appsv1.Deploy
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: Labels(args.BrokerCell.Name, args.ComponentName)},
 			Strategy: appsv1.DeploymentStrategy{
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxSurge:       &intstr.IntOrString{IntVal: 1},
-					MaxUnavailable: &intstr.IntOrString{IntVal: 0},
-				},
+				RollingUpdate: rollingUpdate(args.RolloutStrategy),
 			},
 			MinReadySeconds: 60,
 			Template: corev1.PodTemplateSpec{
@@ -174,6 +178,25 @@ func deploymentTemplate(args Args, containers []corev1.Container) *appsv1.Deploy
 	}
 }
 
+// rollingUpdate returns the rolling update parameters of a data plane
+// deployment. By default, new pods must be ready before old ones are taken down.
+func rollingUpdate(rs *intv1alpha1.RolloutStrategySpec) *appsv1.RollingUpdateDeployment {
+	ru := &appsv1.RollingUpdateDeployment{
+		MaxSurge:       &intstr.IntOrString{IntVal: 1},
+		MaxUnavailable: &intstr.IntOrString{IntVal: 0},
+	}
+	if rs == nil {
+		return ru
+	}
+	if rs.MaxSurge != nil {
+		ru.MaxSurge = rs.MaxSurge
+	}
+	if rs.MaxUnavailable != nil {
+		ru.MaxUnavailable = rs.MaxUnavailable
+	}
+	return ru
+}
+
 // containerTemplate returns a common template for broker data plane containers.
 func containerTemplate(args Args) corev1.Container {
 	return corev1.Container{
@@ -209,6 +232,17 @@ func containerTemplate(args Args) corev1.Container {
 				Name:  "METRICS_DOMAIN",
 				Value: "knative.dev/internal/eventing",
 			},
+			{
+				// Enables the config snapshot endpoint if the secret exists.
+				Name: "ADMIN_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: AdminTokenSecretName},
+						Key:                  "token",
+						Optional:             ptr.Bool(true),
+					},
+				},
+			},
 		},
 		Ports: []corev1.ContainerPort{
 			{
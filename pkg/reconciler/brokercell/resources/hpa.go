@@ -23,8 +23,27 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 )
 
+// undeliveredMessagesMetric is the Cloud Monitoring metric of the number of
+// unacknowledged messages in a subscription, as exposed through the external
+// metrics API.
+const undeliveredMessagesMetric = "pubsub.googleapis.com|subscription|num_undelivered_messages"
+
+// BrokerCellPubsubLabelKey is the user label carried by the Pub/Sub
+// subscriptions of the Brokers and Triggers served by a BrokerCell, so that
+// the backlog metric of the BrokerCell only covers its own subscriptions.
+const BrokerCellPubsubLabelKey = "brokercell"
+
+// BrokerCellPubsubLabelValue returns the value of the BrokerCellPubsubLabelKey
+// user label for the given BrokerCell. The UID is used rather than the name so
+// that the BrokerCells of clusters sharing a project are told apart.
+func BrokerCellPubsubLabelValue(bc *intv1alpha1.BrokerCell) string {
+	return string(bc.UID)
+}
+
 // MakeHorizontalPodAutoscaler makes an HPA for the given arguments.
 func MakeHorizontalPodAutoscaler(deployment *appsv1.Deployment, args AutoscalingArgs) *hpav2beta2.HorizontalPodAutoscaler {
 	autoscalingMetrics := []hpav2beta2.MetricSpec{}
@@ -56,10 +75,35 @@ func MakeHorizontalPodAutoscaler(deployment *appsv1.Deployment, args Autoscaling
 			autoscalingMetrics = append(autoscalingMetrics, memoryMetric)
 		}
 	}
+	if args.BacklogScaling != nil && args.BacklogScaling.TargetBacklog != nil {
+		// Subscription IDs are usually too long to be used as label values, so select
+		// the subscriptions by the user labels set on them by the Broker and Trigger
+		// reconcilers. The metric only covers the subscriptions in the project of the
+		// metrics adapter, the same as args.Subscriptions.
+		backlogMetric := hpav2beta2.MetricSpec{
+			Type: hpav2beta2.ExternalMetricSourceType,
+			External: &hpav2beta2.ExternalMetricSource{
+				Metric: hpav2beta2.MetricIdentifier{
+					Name: undeliveredMessagesMetric,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"metadata.user_labels.resource":                    args.BacklogResourceLabel,
+							"metadata.user_labels." + BrokerCellPubsubLabelKey: BrokerCellPubsubLabelValue(args.BrokerCell),
+						},
+					},
+				},
+				Target: hpav2beta2.MetricTarget{
+					Type:         hpav2beta2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(*args.BacklogScaling.TargetBacklog, resource.DecimalSI),
+				},
+			},
+		}
+		autoscalingMetrics = append(autoscalingMetrics, backlogMetric)
+	}
 
 	return &hpav2beta2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            deployment.Name + "-hpa",
+			Name:            HorizontalPodAutoscalerName(deployment),
 			Namespace:       deployment.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(args.BrokerCell)},
 			Labels:          Labels(args.BrokerCell.Name, args.ComponentName),
@@ -76,3 +120,8 @@ func MakeHorizontalPodAutoscaler(deployment *appsv1.Deployment, args Autoscaling
 		},
 	}
 }
+
+// HorizontalPodAutoscalerName returns the name of the HPA for the given deployment.
+func HorizontalPodAutoscalerName(deployment *appsv1.Deployment) string {
+	return deployment.Name + "-hpa"
+}
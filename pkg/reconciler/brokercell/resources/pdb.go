@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+)
+
+// defaultPDBMaxUnavailable lets node drains evict one pod of a component at a
+// time, without blocking them for components running a single replica.
+var defaultPDBMaxUnavailable = intstr.FromInt(1)
+
+// PodDisruptionBudgetArgs are the arguments to create a PDB for deployments.
+type PodDisruptionBudgetArgs struct {
+	ComponentName       string
+	BrokerCell          *intv1alpha1.BrokerCell
+	PodDisruptionBudget *intv1alpha1.PodDisruptionBudgetSpec
+}
+
+// MakePodDisruptionBudget makes a PDB for the pods of the given deployment.
+func MakePodDisruptionBudget(deployment *appsv1.Deployment, args PodDisruptionBudgetArgs) *policyv1beta1.PodDisruptionBudget {
+	spec := policyv1beta1.PodDisruptionBudgetSpec{
+		Selector: deployment.Spec.Selector,
+	}
+	if pdb := args.PodDisruptionBudget; pdb != nil && (pdb.MinAvailable != nil || pdb.MaxUnavailable != nil) {
+		spec.MinAvailable = pdb.MinAvailable
+		spec.MaxUnavailable = pdb.MaxUnavailable
+	} else {
+		maxUnavailable := defaultPDBMaxUnavailable
+		spec.MaxUnavailable = &maxUnavailable
+	}
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            PodDisruptionBudgetName(deployment),
+			Namespace:       deployment.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(args.BrokerCell)},
+			Labels:          Labels(args.BrokerCell.Name, args.ComponentName),
+		},
+		Spec: spec,
+	}
+}
+
+// PodDisruptionBudgetName returns the name of the PDB for the given deployment.
+func PodDisruptionBudgetName(deployment *appsv1.Deployment) string {
+	return deployment.Name + "-pdb"
+}
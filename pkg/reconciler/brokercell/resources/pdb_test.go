@@ -0,0 +1,97 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+)
+
+func TestMakePodDisruptionBudget(t *testing.T) {
+	bc := NewBrokerCell("bc", "ns")
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "bc-brokercell-ingress", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: Labels("bc", IngressName)},
+		},
+	}
+	minAvailable := intstr.FromString("50%")
+
+	tests := []struct {
+		name               string
+		pdb                *intv1alpha1.PodDisruptionBudgetSpec
+		wantMin            *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{{
+		name:               "default",
+		wantMaxUnavailable: &defaultPDBMaxUnavailable,
+	}, {
+		name:               "empty spec uses default",
+		pdb:                &intv1alpha1.PodDisruptionBudgetSpec{},
+		wantMaxUnavailable: &defaultPDBMaxUnavailable,
+	}, {
+		name:    "min available",
+		pdb:     &intv1alpha1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable},
+		wantMin: &minAvailable,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MakePodDisruptionBudget(d, PodDisruptionBudgetArgs{
+				ComponentName:       IngressName,
+				BrokerCell:          bc,
+				PodDisruptionBudget: tc.pdb,
+			})
+			if got.Name != "bc-brokercell-ingress-pdb" || got.Namespace != "ns" {
+				t.Errorf("Unexpected PDB name %s/%s", got.Namespace, got.Name)
+			}
+			want := policyv1beta1.PodDisruptionBudgetSpec{
+				Selector:       d.Spec.Selector,
+				MinAvailable:   tc.wantMin,
+				MaxUnavailable: tc.wantMaxUnavailable,
+			}
+			if diff := cmp.Diff(want, got.Spec); diff != "" {
+				t.Errorf("Unexpected PDB spec (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRollingUpdate(t *testing.T) {
+	maxSurge := intstr.FromString("25%")
+	got := rollingUpdate(&intv1alpha1.RolloutStrategySpec{MaxSurge: &maxSurge})
+	want := &appsv1.RollingUpdateDeployment{
+		MaxSurge:       &maxSurge,
+		MaxUnavailable: &intstr.IntOrString{IntVal: 0},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected rolling update (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(rollingUpdate(nil), &appsv1.RollingUpdateDeployment{
+		MaxSurge:       &intstr.IntOrString{IntVal: 1},
+		MaxUnavailable: &intstr.IntOrString{IntVal: 0},
+	}); diff != "" {
+		t.Errorf("Unexpected default rolling update (-want, +got): %s", diff)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kedaresources "github.com/google/knative-gcp/pkg/reconciler/intevents/pullsubscription/keda/resources"
+)
+
+const (
+	// credentialsJSONEnvKey is the environment variable KEDA reads the Google
+	// credentials from to query the subscription backlog.
+	credentialsJSONEnvKey = "GOOGLE_APPLICATION_CREDENTIALS_JSON"
+
+	// BrokerCredentialsSecretName is the name of the secret holding the
+	// broker service account key.
+	BrokerCredentialsSecretName = "google-broker-key"
+
+	// BrokerCredentialsSecretKey is the key of the service account key in
+	// the BrokerCredentialsSecretName secret.
+	BrokerCredentialsSecretKey = "key.json"
+
+	// ScaledObjectSpecHashAnnotationKey is the annotation holding the hash of
+	// the spec of a ScaledObject. The ScaledObjects are read from a lister
+	// that only keeps their metadata, so the hash tells whether their spec is
+	// up to date.
+	ScaledObjectSpecHashAnnotationKey = "events.cloud.google.com/scaledObjectSpecHash"
+
+	// MaxScaledObjectTriggers is the maximum number of subscriptions a
+	// ScaledObject scales on. KEDA's gcp-pubsub scaler queries the backlog of
+	// each subscription separately, so the ScaledObject would otherwise grow
+	// with the number of brokers and triggers.
+	MaxScaledObjectTriggers = 20
+)
+
+// kedaCredentialsEnvVar exposes the broker service account key to KEDA. The
+// key is optional, as with the google-broker-key volume, so that the pods
+// still start with Workload Identity. KEDA's gcp-pubsub scaler only supports
+// service account keys though, so the BrokerCell reports that the backlog
+// scaling doesn't work when the key is missing.
+func kedaCredentialsEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: credentialsJSONEnvKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: BrokerCredentialsSecretName},
+				Key:                  BrokerCredentialsSecretKey,
+				Optional:             &optionalSecretVolume,
+			},
+		},
+	}
+}
+
+// ScaledObjectName returns the name of the KEDA ScaledObject for the given deployment.
+func ScaledObjectName(deployment *appsv1.Deployment) string {
+	return deployment.Name + "-so"
+}
+
+// MakeScaledObject makes a KEDA ScaledObject scaling the given deployment on
+// the backlog of each of the first MaxScaledObjectTriggers of
+// args.Subscriptions. The gcp-pubsub scaler looks the subscriptions up by ID in
+// the project of its credentials.
+func MakeScaledObject(deployment *appsv1.Deployment, args AutoscalingArgs) *unstructured.Unstructured {
+	subs := args.Subscriptions
+	if len(subs) > MaxScaledObjectTriggers {
+		subs = subs[:MaxScaledObjectTriggers]
+	}
+	triggers := make([]interface{}, 0, len(subs))
+	for _, sub := range subs {
+		triggers = append(triggers, map[string]interface{}{
+			"type": "gcp-pubsub",
+			"metadata": map[string]interface{}{
+				"subscriptionSize": strconv.FormatInt(*args.BacklogScaling.TargetBacklog, 10),
+				"subscriptionName": sub,
+				"credentials":      credentialsJSONEnvKey,
+			},
+		})
+	}
+	labels := make(map[string]interface{})
+	for k, v := range Labels(args.BrokerCell.Name, args.ComponentName) {
+		labels[k] = v
+	}
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"deploymentName": deployment.Name,
+		},
+		"minReplicaCount": int64(args.MinReplicas),
+		"maxReplicaCount": int64(args.MaxReplicas),
+		"cooldownPeriod":  int64(*args.BacklogScaling.CooldownPeriod),
+		"pollingInterval": int64(*args.BacklogScaling.PollingInterval),
+		"triggers":        triggers,
+	}
+
+	apiVersion, kind := kedaresources.ScaledObjectGVK.ToAPIVersionAndKind()
+	// Use Unstructured instead of depending on KEDA, the same as the KEDA
+	// PullSubscription reconciler.
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"namespace": deployment.Namespace,
+				"name":      ScaledObjectName(deployment),
+				"labels":    labels,
+				"annotations": map[string]interface{}{
+					ScaledObjectSpecHashAnnotationKey: specHash(spec),
+				},
+				"ownerReferences": []interface{}{
+					map[string]interface{}{
+						"apiVersion":         args.BrokerCell.GetGroupVersionKind().GroupVersion().String(),
+						"kind":               args.BrokerCell.GetGroupVersionKind().Kind,
+						"blockOwnerDeletion": true,
+						"controller":         true,
+						"name":               args.BrokerCell.Name,
+						"uid":                string(args.BrokerCell.UID),
+					}},
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// specHash returns the hash of the given unstructured spec. Maps are encoded in
+// key order, so equal specs have the same hash.
+func specHash(spec map[string]interface{}) string {
+	// The spec only holds strings, integers, maps and slices, which can always
+	// be encoded.
+	b, _ := json.Marshal(spec)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/ptr"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+)
+
+func TestMakeScaledObject(t *testing.T) {
+	bc := NewBrokerCell("bc", "ns")
+	bc.UID = "uid"
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "bc-brokercell-fanout", Namespace: "ns"}}
+	args := AutoscalingArgs{
+		ComponentName: FanoutName,
+		BrokerCell:    bc,
+		MinReplicas:   1,
+		MaxReplicas:   5,
+		BacklogScaling: &intv1alpha1.BacklogScalingSpec{
+			Class:           intv1alpha1.BacklogScalingKeda,
+			TargetBacklog:   ptr.Int64(10),
+			PollingInterval: ptr.Int32(15),
+			CooldownPeriod:  ptr.Int32(120),
+		},
+		Subscriptions: []string{"sub1", "sub2"},
+	}
+
+	want := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "keda.k8s.io/v1beta1",
+			"kind":       "ScaledObject",
+			"metadata": map[string]interface{}{
+				"namespace": "ns",
+				"name":      "bc-brokercell-fanout-so",
+				"labels": map[string]interface{}{
+					"app":        "cloud-run-events",
+					"brokerCell": "bc",
+					"role":       "fanout",
+				},
+				"ownerReferences": []interface{}{
+					map[string]interface{}{
+						"apiVersion":         "internal.events.cloud.google.com/v1alpha1",
+						"kind":               "BrokerCell",
+						"blockOwnerDeletion": true,
+						"controller":         true,
+						"name":               "bc",
+						"uid":                "uid",
+					}},
+			},
+			"spec": map[string]interface{}{
+				"scaleTargetRef": map[string]interface{}{
+					"deploymentName": "bc-brokercell-fanout",
+				},
+				"minReplicaCount": int64(1),
+				"maxReplicaCount": int64(5),
+				"cooldownPeriod":  int64(120),
+				"pollingInterval": int64(15),
+				"triggers": []interface{}{
+					map[string]interface{}{
+						"type": "gcp-pubsub",
+						"metadata": map[string]interface{}{
+							"subscriptionSize": "10",
+							"subscriptionName": "sub1",
+							"credentials":      "GOOGLE_APPLICATION_CREDENTIALS_JSON",
+						},
+					},
+					map[string]interface{}{
+						"type": "gcp-pubsub",
+						"metadata": map[string]interface{}{
+							"subscriptionSize": "10",
+							"subscriptionName": "sub2",
+							"credentials":      "GOOGLE_APPLICATION_CREDENTIALS_JSON",
+						},
+					},
+				},
+			},
+		},
+	}
+	want.SetAnnotations(map[string]string{
+		ScaledObjectSpecHashAnnotationKey: specHash(want.Object["spec"].(map[string]interface{})),
+	})
+	if diff := cmp.Diff(want, MakeScaledObject(d, args)); diff != "" {
+		t.Errorf("Unexpected ScaledObject (-want, +got): %s", diff)
+	}
+
+	// The spec hash changes with the spec.
+	args.MaxReplicas = 10
+	if MakeScaledObject(d, args).GetAnnotations()[ScaledObjectSpecHashAnnotationKey] == want.GetAnnotations()[ScaledObjectSpecHashAnnotationKey] {
+		t.Error("ScaledObject spec hash didn't change with the spec")
+	}
+}
+
+func TestMakeScaledObjectMaxTriggers(t *testing.T) {
+	bc := NewBrokerCell("bc", "ns")
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "bc-brokercell-fanout", Namespace: "ns"}}
+	var subs []string
+	for i := 0; i < MaxScaledObjectTriggers+5; i++ {
+		subs = append(subs, fmt.Sprintf("sub%d", i))
+	}
+	args := AutoscalingArgs{
+		ComponentName: FanoutName,
+		BrokerCell:    bc,
+		MinReplicas:   1,
+		MaxReplicas:   5,
+		BacklogScaling: &intv1alpha1.BacklogScalingSpec{
+			Class:           intv1alpha1.BacklogScalingKeda,
+			TargetBacklog:   ptr.Int64(10),
+			PollingInterval: ptr.Int32(15),
+			CooldownPeriod:  ptr.Int32(120),
+		},
+		Subscriptions: subs,
+	}
+
+	triggers, _, _ := unstructured.NestedSlice(MakeScaledObject(d, args).Object, "spec", "triggers")
+	if len(triggers) != MaxScaledObjectTriggers {
+		t.Errorf("ScaledObject triggers got=%d, want=%d", len(triggers), MaxScaledObjectTriggers)
+	}
+}
+
+func TestMakeHorizontalPodAutoscalerWithBacklog(t *testing.T) {
+	bc := NewBrokerCell("bc", "ns")
+	bc.UID = "uid"
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "bc-brokercell-retry", Namespace: "ns"}}
+	args := AutoscalingArgs{
+		ComponentName:     RetryName,
+		BrokerCell:        bc,
+		AvgCPUUtilization: ptr.Int32(95),
+		MinReplicas:       1,
+		MaxReplicas:       5,
+		BacklogScaling: &intv1alpha1.BacklogScalingSpec{
+			Class:         intv1alpha1.BacklogScalingExternalMetrics,
+			TargetBacklog: ptr.Int64(10),
+		},
+		BacklogResourceLabel: "triggers",
+	}
+
+	hpa := MakeHorizontalPodAutoscaler(d, args)
+	if got, want := len(hpa.Spec.Metrics), 2; got != want {
+		t.Fatalf("Unexpected number of metrics, got %d, want %d", got, want)
+	}
+	backlog := hpa.Spec.Metrics[1]
+	if backlog.Type != hpav2beta2.ExternalMetricSourceType {
+		t.Fatalf("Unexpected metric type %q", backlog.Type)
+	}
+	if got, want := backlog.External.Metric.Name, "pubsub.googleapis.com|subscription|num_undelivered_messages"; got != want {
+		t.Errorf("Unexpected metric name, got %q, want %q", got, want)
+	}
+	wantSelector := map[string]string{
+		"metadata.user_labels.resource":   "triggers",
+		"metadata.user_labels.brokercell": "uid",
+	}
+	if diff := cmp.Diff(wantSelector, backlog.External.Metric.Selector.MatchLabels); diff != "" {
+		t.Errorf("Unexpected metric selector (-want, +got): %s", diff)
+	}
+	if got, want := backlog.External.Target.AverageValue.Value(), int64(10); got != want {
+		t.Errorf("Unexpected target average value, got %d, want %d", got, want)
+	}
+}
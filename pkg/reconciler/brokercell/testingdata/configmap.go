@@ -30,6 +30,13 @@ import (
 
 func EmptyConfig(t *testing.T, bc *intv1alpha1.BrokerCell) *corev1.ConfigMap {
 	cm, _ := resources.MakeTargetsConfig(bc, memory.NewEmptyTargets())
+	resources.SetTargetsConfigGeneration(cm, 1)
+	return cm
+}
+
+// WithConfigGeneration sets the generation of the given targets configmap.
+func WithConfigGeneration(cm *corev1.ConfigMap, gen int64) *corev1.ConfigMap {
+	resources.SetTargetsConfigGeneration(cm, gen)
 	return cm
 }
 
@@ -52,8 +59,8 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 			Broker:    broker.Name,
 			Address:   t.Status.SubscriberURI.String(),
 			RetryQueue: &config.Queue{
-				Topic:        brokerresources.GenerateRetryTopicName(t),
-				Subscription: brokerresources.GenerateRetrySubscriptionName(t),
+				Topic:        queueTopicName(broker, brokerresources.GenerateRetryTopicName(t)),
+				Subscription: queueSubscriptionName(broker, brokerresources.GenerateRetrySubscriptionName(t)),
 			},
 			State:            state,
 			FilterAttributes: filterAttributes,
@@ -77,8 +84,8 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 		Namespace: broker.Namespace,
 		Address:   broker.Status.Address.URL.String(),
 		DecoupleQueue: &config.Queue{
-			Topic:        brokerresources.GenerateDecouplingTopicName(broker),
-			Subscription: brokerresources.GenerateDecouplingSubscriptionName(broker),
+			Topic:        queueTopicName(broker, brokerresources.GenerateDecouplingTopicName(broker)),
+			Subscription: queueSubscriptionName(broker, brokerresources.GenerateDecouplingSubscriptionName(broker)),
 			State:        brokerQueueState,
 		},
 		Targets: targets,
@@ -91,5 +98,24 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 	}
 	brokerTargets := memory.NewTargets(bt)
 	cm, _ := resources.MakeTargetsConfig(bc, brokerTargets)
+	resources.SetTargetsConfigGeneration(cm, 1)
 	return cm
 }
+
+// queueTopicName qualifies the topic ID with the project of a broker in
+// another project, the same as the BrokerCell reconciler does.
+func queueTopicName(b *brokerv1beta1.Broker, id string) string {
+	if project := b.Project(); project != "" {
+		return config.FullTopicName(project, id)
+	}
+	return id
+}
+
+// queueSubscriptionName qualifies the subscription ID in the same way as
+// queueTopicName.
+func queueSubscriptionName(b *brokerv1beta1.Broker, id string) string {
+	if project := b.Project(); project != "" {
+		return config.FullSubscriptionName(project, id)
+	}
+	return id
+}
@@ -26,6 +26,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -85,6 +86,18 @@ func RetryHPA(t *testing.T) *hpav2beta2.HorizontalPodAutoscaler {
 	return getHPA(t, "testingdata/retry_hpa.yaml")
 }
 
+func IngressPDB(t *testing.T) *policyv1beta1.PodDisruptionBudget {
+	return getPDB(t, "testingdata/ingress_pdb.yaml")
+}
+
+func FanoutPDB(t *testing.T) *policyv1beta1.PodDisruptionBudget {
+	return getPDB(t, "testingdata/fanout_pdb.yaml")
+}
+
+func RetryPDB(t *testing.T) *policyv1beta1.PodDisruptionBudget {
+	return getPDB(t, "testingdata/retry_pdb.yaml")
+}
+
 func getHPA(t *testing.T, path string) *hpav2beta2.HorizontalPodAutoscaler {
 	hpa := &hpav2beta2.HorizontalPodAutoscaler{}
 	if err := getSpecFromFile(path, hpa); err != nil {
@@ -93,6 +106,14 @@ func getHPA(t *testing.T, path string) *hpav2beta2.HorizontalPodAutoscaler {
 	return hpa
 }
 
+func getPDB(t *testing.T, path string) *policyv1beta1.PodDisruptionBudget {
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	if err := getSpecFromFile(path, pdb); err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	return pdb
+}
+
 func getDeployment(t *testing.T, path string) *appsv1.Deployment {
 	d := &appsv1.Deployment{}
 	if err := getSpecFromFile(path, d); err != nil {
@@ -80,7 +80,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, s *v1.CloudAuditLogsSour
 	}
 
 	topic := resources.GenerateTopicName(s)
-	t, ps, err := c.PubSubBase.ReconcilePubSub(ctx, s, topic, resourceGroup)
+	t, ps, err := c.PubSubBase.ReconcilePubSubWithAdapterType(ctx, s, topic, resourceGroup, string(resources.ReceiveAdapterType(s)))
 	if err != nil {
 		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
 	}
@@ -116,7 +116,7 @@ func (c *Reconciler) ensureSinkCreated(ctx context.Context, s *v1.CloudAuditLogs
 	if sinkID == "" {
 		sinkID = resources.GenerateSinkName(s)
 	}
-	logadminClient, err := c.logadminClientProvider(ctx, s.Status.ProjectID)
+	logadminClient, err := c.logadminClientProvider(ctx, resources.GenerateSinkParent(s))
 	if err != nil {
 		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
 		return nil, err
@@ -128,10 +128,14 @@ func (c *Reconciler) ensureSinkCreated(ctx context.Context, s *v1.CloudAuditLogs
 		if s.Spec.ResourceName != "" {
 			filterBuilder.WithResourceName(s.Spec.ResourceName)
 		}
+		if s.Spec.LogFilter != "" {
+			filterBuilder.WithLogFilter(s.Spec.LogFilter)
+		}
 		sink = &logadmin.Sink{
-			ID:          sinkID,
-			Destination: resources.GenerateTopicResourceName(s),
-			Filter:      filterBuilder.GetFilterQuery(),
+			ID:              sinkID,
+			Destination:     resources.GenerateTopicResourceName(s),
+			Filter:          filterBuilder.GetFilterQuery(),
+			IncludeChildren: resources.SinkIncludesChildren(s),
 		}
 		sink, err = logadminClient.CreateSinkOpt(ctx, sink, logadmin.SinkOptions{UniqueWriterIdentity: true})
 		// Handle AlreadyExists in-case of a race between another create call.
@@ -168,12 +172,13 @@ func (c *Reconciler) ensureSinkIsPublisher(ctx context.Context, s *v1.CloudAudit
 }
 
 // deleteSink looks at status.SinkID and if non-empty will delete the
-// previously created stackdriver sink.
+// previously created stackdriver sink, from the project or the parent the
+// source was created with.
 func (c *Reconciler) deleteSink(ctx context.Context, s *v1.CloudAuditLogsSource) error {
 	if s.Status.StackdriverSink == "" {
 		return nil
 	}
-	logadminClient, err := c.logadminClientProvider(ctx, s.Status.ProjectID)
+	logadminClient, err := c.logadminClientProvider(ctx, resources.GenerateSinkParent(s))
 	if err != nil {
 		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
 		s.Status.MarkSinkUnknown(deleteSinkFailed, "Failed to create LogAdmin Client: %s", err.Error())
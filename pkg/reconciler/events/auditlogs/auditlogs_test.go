@@ -57,15 +57,17 @@ import (
 )
 
 const (
-	sourceName   = "test-cal"
-	sourceUID    = "test-cal-uid"
-	testNS       = "testnamespace"
-	testProject  = "test-project-id"
-	testTopicURI = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
+	sourceName       = "test-cal"
+	sourceUID        = "test-cal-uid"
+	testNS           = "testnamespace"
+	testProject      = "test-project-id"
+	testOrganization = "organizations/123456789"
+	testTopicURI     = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
 
 	testServiceName = "test-service"
 	testMethodName  = "test-method"
 	testFilter      = `protoPayload.methodName="test-method" AND protoPayload.serviceName="test-service" AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`
+	testLogFilter   = `resource.type="gce_instance" AND severity>=ERROR`
 
 	sinkName = "sink"
 	sinkDNS  = sinkName + ".mynamespace.svc.cluster.local"
@@ -1155,6 +1157,146 @@ func TestAllCases(t *testing.T) {
 				v1.WithCloudAuditLogsSourceSetDefaults,
 			),
 		}},
+	}, {
+		Name: "aggregated sink created",
+		Objects: []runtime.Object{
+			v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceParent(testOrganization),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+			v1.NewTopic(sourceName, testNS,
+				v1.WithTopicSpec(inteventsv1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				v1.WithTopicReady(testTopicID),
+				v1.WithTopicAddress(testTopicURI),
+				v1.WithTopicProjectID(testProject),
+				v1.WithTopicSetDefaults,
+			),
+			v1.NewPullSubscription(sourceName, testNS,
+				v1.WithPullSubscriptionReady(sinkURI),
+				v1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret: &secret,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: newSinkDestination(),
+						},
+					},
+					AdapterType: string(converters.CloudAuditLogs),
+				})),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"sinkParent": testOrganization,
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:              testSinkID,
+					Filter:          testFilter,
+					Destination:     testTopicResource,
+					IncludeChildren: true,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudAuditLogsSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceParent(testOrganization),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceProjectID(testProject),
+				v1.WithCloudAuditLogsSourceSubscriptionID(v1.SubscriptionID),
+				v1.WithInitCloudAuditLogsSourceConditions,
+				v1.WithCloudAuditLogsSourceTopicReady(testTopicID),
+				v1.WithCloudAuditLogsSourcePullSubscriptionReady,
+				v1.WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				v1.WithCloudAuditLogsSourceSinkReady,
+				v1.WithCloudAuditLogsSourceSinkID(testSinkID),
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "log filter sink created",
+		Objects: []runtime.Object{
+			v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceLogFilter(testLogFilter),
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+			v1.NewTopic(sourceName, testNS,
+				v1.WithTopicSpec(inteventsv1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				v1.WithTopicReady(testTopicID),
+				v1.WithTopicAddress(testTopicURI),
+				v1.WithTopicProjectID(testProject),
+				v1.WithTopicSetDefaults,
+			),
+			v1.NewPullSubscription(sourceName, testNS,
+				v1.WithPullSubscriptionReady(sinkURI),
+				v1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret: &secret,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: newSinkDestination(),
+						},
+					},
+					AdapterType: string(converters.CloudLogging),
+				})),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:          testSinkID,
+					Filter:      testLogFilter,
+					Destination: testTopicResource,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudAuditLogsSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceLogFilter(testLogFilter),
+				v1.WithCloudAuditLogsSourceProjectID(testProject),
+				v1.WithCloudAuditLogsSourceSubscriptionID(v1.SubscriptionID),
+				v1.WithInitCloudAuditLogsSourceConditions,
+				v1.WithCloudAuditLogsSourceTopicReady(testTopicID),
+				v1.WithCloudAuditLogsSourcePullSubscriptionReady,
+				v1.WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				v1.WithCloudAuditLogsSourceSinkReady,
+				v1.WithCloudAuditLogsSourceSinkID(testSinkID),
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
 	}, {
 		Name: "sink exists",
 		Objects: []runtime.Object{
@@ -1355,6 +1497,73 @@ func TestAllCases(t *testing.T) {
 				Name: sourceName,
 			},
 		},
+	}, {
+		Name: "aggregated sink delete succeeds",
+		Objects: []runtime.Object{
+			v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceParent(testOrganization),
+				v1.WithCloudAuditLogsSourceProjectID(testProject),
+				v1.WithInitCloudAuditLogsSourceConditions,
+				v1.WithCloudAuditLogsSourceTopicReady(testTopicID),
+				v1.WithCloudAuditLogsSourcePullSubscriptionReady,
+				v1.WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				v1.WithCloudAuditLogsSourceSinkReady,
+				v1.WithCloudAuditLogsSourceSinkID(testSinkID),
+				v1.WithCloudAuditLogsSourceDeletionTimestamp,
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+			v1.NewTopic(sourceName, testNS,
+				v1.WithTopicReady(testTopicID),
+				v1.WithTopicAddress(testTopicURI),
+				v1.WithTopicProjectID(testProject),
+				v1.WithTopicSetDefaults,
+			),
+			v1.NewPullSubscription(sourceName, testNS,
+				v1.WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"sinkParent": testOrganization,
+			"existingSinks": []logadmin.Sink{{
+				ID:              testSinkID,
+				Filter:          testFilter,
+				Destination:     testTopicResource,
+				IncludeChildren: true,
+			}},
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: nil,
+			},
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: v1.NewCloudAuditLogsSource(sourceName, testNS,
+				v1.WithCloudAuditLogsSourceUID(sourceUID),
+				v1.WithCloudAuditLogsSourceMethodName(testMethodName),
+				v1.WithCloudAuditLogsSourceServiceName(testServiceName),
+				v1.WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				v1.WithCloudAuditLogsSourceParent(testOrganization),
+				v1.WithInitCloudAuditLogsSourceConditions,
+				v1.WithCloudAuditLogsSourceSinkDeleted,
+				v1.WithCloudAuditLogsSourceTopicDeleted,
+				v1.WithCloudAuditLogsSourcePullSubscriptionDeleted,
+				v1.WithCloudAuditLogsSourceDeletionTimestamp,
+				v1.WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
 	}, {
 		Name: "delete succeeds, sink does not exist",
 		Objects: []runtime.Object{
@@ -1418,8 +1627,12 @@ func TestAllCases(t *testing.T) {
 	for _, tt := range table {
 		t.Run(tt.Name, func(t *testing.T) {
 			logadminClientProvider := glogadmintesting.TestClientCreator(tt.OtherTestData["logadmin"])
+			sinkParent := testProject
+			if parent, ok := tt.OtherTestData["sinkParent"]; ok {
+				sinkParent = parent.(string)
+			}
 			if existingSinks := tt.OtherTestData["existingSinks"]; existingSinks != nil {
-				createSinks(t, logadminClientProvider, existingSinks.([]logadmin.Sink))
+				createSinks(t, logadminClientProvider, sinkParent, existingSinks.([]logadmin.Sink))
 			}
 			tt.Test(t, MakeFactory(
 				func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
@@ -1440,14 +1653,14 @@ func TestAllCases(t *testing.T) {
 					return cloudauditlogssource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudAuditLogsSourceLister(), r.Recorder, r)
 				}))
 			if expectedSinks := tt.OtherTestData["expectedSinks"]; expectedSinks != nil {
-				expectSinks(t, logadminClientProvider, expectedSinks.(map[string]*logadmin.Sink))
+				expectSinks(t, logadminClientProvider, sinkParent, expectedSinks.(map[string]*logadmin.Sink))
 			}
 		})
 	}
 }
 
-func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks []logadmin.Sink) {
-	logadminClient, err := clientProvider(context.Background(), testProject)
+func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, parent string, sinks []logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), parent)
 	if err != nil {
 		t.Fatalf("failed to create logadmin client during setup: %s", err)
 	}
@@ -1456,8 +1669,8 @@ func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks []logadm
 	}
 }
 
-func expectSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks map[string]*logadmin.Sink) {
-	logadminClient, err := clientProvider(context.Background(), testProject)
+func expectSinks(t *testing.T, clientProvider glogadmin.CreateFn, parent string, sinks map[string]*logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), parent)
 	if err != nil {
 		t.Fatalf("failed to create logadmin client during verification: %s", err)
 	}
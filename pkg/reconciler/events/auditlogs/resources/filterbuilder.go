@@ -16,11 +16,12 @@ const (
 
 // Stackdriver query builder for querying audit logs. Currently
 // supports querying by the AuditLog serviceName, methodName, and
-// resourceName.
+// resourceName, or by an arbitrary advanced log filter.
 type FilterBuilder struct {
 	serviceName  string
 	methodName   string
 	resourceName string
+	logFilter    string
 }
 
 func (fb *FilterBuilder) WithServiceName(serviceName string) *FilterBuilder {
@@ -38,7 +39,17 @@ func (fb *FilterBuilder) WithResourceName(resourceName string) *FilterBuilder {
 	return fb
 }
 
+// WithLogFilter sets an advanced log filter, which is used as is in place
+// of the AuditLog field filters.
+func (fb *FilterBuilder) WithLogFilter(logFilter string) *FilterBuilder {
+	fb.logFilter = logFilter
+	return fb
+}
+
 func (fb *FilterBuilder) GetFilterQuery() string {
+	if fb.logFilter != "" {
+		return fb.logFilter
+	}
 	var filters []string
 	if fb.methodName != "" {
 		filters = append(filters, filter{methodKey, fb.methodName}.String())
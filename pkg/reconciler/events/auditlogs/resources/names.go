@@ -19,8 +19,10 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	"github.com/google/knative-gcp/pkg/utils/naming"
 )
 
@@ -41,3 +43,30 @@ func GenerateTopicResourceName(s *v1.CloudAuditLogsSource) string {
 func GenerateSinkName(s *v1.CloudAuditLogsSource) string {
 	return naming.TruncatedLoggingSinkResourceName("cre-src", s.Namespace, s.Name, s.UID)
 }
+
+// GenerateSinkParent returns the parent of the Stackdriver sink of an
+// CloudAuditLogsSource. It is the organization, folder or billing account of
+// the source if set, and its project otherwise.
+func GenerateSinkParent(s *v1.CloudAuditLogsSource) string {
+	if s.Spec.Parent != "" {
+		return s.Spec.Parent
+	}
+	return s.Status.ProjectID
+}
+
+// SinkIncludesChildren tells whether the Stackdriver sink of an
+// CloudAuditLogsSource is an aggregated sink, also exporting the audit logs
+// of the children of its organization or folder.
+func SinkIncludesChildren(s *v1.CloudAuditLogsSource) bool {
+	return strings.HasPrefix(s.Spec.Parent, "organizations/") || strings.HasPrefix(s.Spec.Parent, "folders/")
+}
+
+// ReceiveAdapterType returns the converter the receive adapter of an
+// CloudAuditLogsSource uses. Sources with an advanced log filter may receive
+// any log entry, so they are converted generically rather than as audit logs.
+func ReceiveAdapterType(s *v1.CloudAuditLogsSource) converters.ConverterType {
+	if s.Spec.LogFilter != "" {
+		return converters.CloudLogging
+	}
+	return converters.CloudAuditLogs
+}
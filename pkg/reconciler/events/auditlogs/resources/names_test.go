@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -70,3 +71,54 @@ func TestGenerateSinkName(t *testing.T) {
 		t.Errorf("unexpected (-want, +got) = %v", diff)
 	}
 }
+
+func TestGenerateSinkParent(t *testing.T) {
+	tests := []struct {
+		parent              string
+		wantParent          string
+		wantIncludeChildren bool
+	}{{
+		wantParent: "project",
+	}, {
+		parent:              "organizations/123",
+		wantParent:          "organizations/123",
+		wantIncludeChildren: true,
+	}, {
+		parent:              "folders/456",
+		wantParent:          "folders/456",
+		wantIncludeChildren: true,
+	}, {
+		parent:     "billingAccounts/ABC-123",
+		wantParent: "billingAccounts/ABC-123",
+	}}
+
+	for _, test := range tests {
+		s := &v1.CloudAuditLogsSource{
+			Spec: v1.CloudAuditLogsSourceSpec{
+				Parent: test.parent,
+			},
+			Status: v1.CloudAuditLogsSourceStatus{
+				PubSubStatus: duckv1.PubSubStatus{
+					ProjectID: "project",
+				},
+			},
+		}
+		if diff := cmp.Diff(test.wantParent, GenerateSinkParent(s)); diff != "" {
+			t.Errorf("unexpected (-want, +got) = %v", diff)
+		}
+		if got := SinkIncludesChildren(s); got != test.wantIncludeChildren {
+			t.Errorf("unexpected includeChildren for %q, want %v, got %v", test.parent, test.wantIncludeChildren, got)
+		}
+	}
+}
+
+func TestReceiveAdapterType(t *testing.T) {
+	s := &v1.CloudAuditLogsSource{}
+	if got := ReceiveAdapterType(s); got != converters.CloudAuditLogs {
+		t.Errorf("unexpected receive adapter type, want %q, got %q", converters.CloudAuditLogs, got)
+	}
+	s.Spec.LogFilter = "severity>=ERROR"
+	if got := ReceiveAdapterType(s); got != converters.CloudLogging {
+		t.Errorf("unexpected receive adapter type, want %q, got %q", converters.CloudLogging, got)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	budgetspb "google.golang.org/genproto/googleapis/cloud/billing/budgets/v1beta1"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	cloudbillingbudgetsourcereconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1/cloudbillingbudgetsource"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1"
+	gbudgets "github.com/google/knative-gcp/pkg/gclient/budgets"
+	"github.com/google/knative-gcp/pkg/reconciler/events/budgets/resources"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	"github.com/google/knative-gcp/pkg/utils"
+)
+
+const (
+	resourceGroup = "cloudbillingbudgetsources.events.cloud.google.com"
+
+	deleteBudgetTopicFailed      = "BudgetTopicDeleteFailed"
+	deletePubSubFailed           = "PubSubDeleteFailed"
+	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
+	reconciledFailedReason       = "BudgetReconcileFailed"
+	reconciledPubSubFailedReason = "PubSubReconcileFailed"
+	reconciledSuccessReason      = "CloudBillingBudgetSourceReconciled"
+	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
+)
+
+const (
+	// schemaVersion is the version of the schema of the budget notifications
+	// the converter understands.
+	schemaVersion = "1.0"
+
+	// pubsubTopicPath and schemaVersionPath are the update mask paths of the
+	// Pub/Sub topic and schema version of the budget notifications.
+	pubsubTopicPath   = "all_updates_rule.pubsub_topic"
+	schemaVersionPath = "all_updates_rule.schema_version"
+)
+
+// Reconciler is the controller implementation for Google Cloud Billing budget notifications.
+type Reconciler struct {
+	*intevents.PubSubBase
+	// identity reconciler for reconciling workload identity.
+	*identity.Identity
+	// budgetSourceLister for reading CloudBillingBudgetSources.
+	budgetSourceLister listers.CloudBillingBudgetSourceLister
+
+	createClientFn gbudgets.CreateFn
+}
+
+// Check that our Reconciler implements Interface.
+var _ cloudbillingbudgetsourcereconciler.Interface = (*Reconciler)(nil)
+
+func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.CloudBillingBudgetSource) reconciler.Event {
+	ctx = logging.WithLogger(ctx, r.Logger.With(zap.Any("billingbudget", source)))
+
+	source.Status.InitializeConditions()
+	source.Status.ObservedGeneration = source.Generation
+
+	// If ServiceAccountName is provided, reconcile workload identity.
+	if source.Spec.ServiceAccountName != "" {
+		if _, err := r.Identity.ReconcileWorkloadIdentity(ctx, source.Spec.Project, source); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, workloadIdentityFailed, "Failed to reconcile CloudBillingBudgetSource workload identity: %s", err.Error())
+		}
+	}
+
+	topic := resources.GenerateTopicName(source)
+	_, _, err := r.PubSubBase.ReconcilePubSub(ctx, source, topic, resourceGroup)
+	if err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+	}
+
+	if source.Status.ProjectID == "" {
+		projectID, err := utils.ProjectIDOrDefault(source.Spec.Project)
+		if err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to find project id", zap.Error(err))
+			source.Status.MarkBudgetNotReady(reconciledFailedReason, "Failed to find project id: %s", err.Error())
+			return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: %s", err.Error())
+		}
+		// Set the projectID in the status.
+		source.Status.ProjectID = projectID
+	}
+
+	budgetName, err := r.reconcileBudget(ctx, source, resources.GenerateTopicResourceName(source, topic))
+	if err != nil {
+		source.Status.MarkBudgetNotReady(reconciledFailedReason, "Failed to reconcile CloudBillingBudgetSource budget: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: %s", err.Error())
+	}
+	source.Status.MarkBudgetReady(budgetName)
+
+	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudBillingBudgetSource reconciled: "%s/%s"`, source.Namespace, source.Name)
+}
+
+// reconcileBudget makes sure the budget publishes its notifications to
+// topicName. It returns the name of the budget.
+func (r *Reconciler) reconcileBudget(ctx context.Context, source *v1.CloudBillingBudgetSource, topicName string) (string, error) {
+	budgetName := resources.GenerateBudgetName(source)
+
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudBillingBudgetSource client", zap.Error(err))
+		return "", err
+	}
+	defer client.Close()
+
+	budget, err := client.GetBudget(ctx, &budgetspb.GetBudgetRequest{Name: budgetName})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed from CloudBillingBudgetSource client while retrieving CloudBillingBudgetSource budget", zap.String("budgetName", budgetName), zap.Error(err))
+		return "", err
+	}
+
+	rule := budget.AllUpdatesRule
+	if rule == nil {
+		rule = &budgetspb.AllUpdatesRule{}
+	}
+	if rule.PubsubTopic == topicName && rule.SchemaVersion == schemaVersion {
+		return budgetName, nil
+	}
+	// A budget has a single Pub/Sub topic, do not take it over from whoever
+	// set it.
+	if rule.PubsubTopic != "" && rule.PubsubTopic != topicName {
+		return "", fmt.Errorf("budget %q already publishes its notifications to %q", budgetName, rule.PubsubTopic)
+	}
+
+	if _, err := client.UpdateBudget(ctx, &budgetspb.UpdateBudgetRequest{
+		Budget: &budgetspb.Budget{
+			Name: budgetName,
+			Etag: budget.Etag,
+			AllUpdatesRule: &budgetspb.AllUpdatesRule{
+				PubsubTopic:   topicName,
+				SchemaVersion: schemaVersion,
+			},
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{pubsubTopicPath, schemaVersionPath}},
+	}); err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to set the Pub/Sub topic of CloudBillingBudgetSource budget", zap.String("budgetName", budgetName), zap.Error(err))
+		return "", err
+	}
+	return budgetName, nil
+}
+
+// deleteBudgetTopic looks at the status.BudgetName and if non-empty, hence
+// indicating that we have set the topic of the budget successfully, unsets
+// it. The topic of a budget that no longer publishes to our topic is left
+// as is.
+func (r *Reconciler) deleteBudgetTopic(ctx context.Context, source *v1.CloudBillingBudgetSource) error {
+	budgetName := source.Status.BudgetName
+	if budgetName == "" {
+		return nil
+	}
+
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudBillingBudgetSource client", zap.Error(err))
+		source.Status.MarkBudgetUnknown(deleteBudgetTopicFailed, "Failed to create CloudBillingBudgetSource client: %s", err.Error())
+		return err
+	}
+	defer client.Close()
+
+	budget, err := client.GetBudget(ctx, &budgetspb.GetBudgetRequest{Name: budgetName})
+	if err != nil {
+		if st, ok := gstatus.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil
+		}
+		logging.FromContext(ctx).Desugar().Error("Failed from CloudBillingBudgetSource client while retrieving CloudBillingBudgetSource budget", zap.String("budgetName", budgetName), zap.Error(err))
+		source.Status.MarkBudgetUnknown(deleteBudgetTopicFailed, "Failed to delete CloudBillingBudgetSource budget topic: %s", err.Error())
+		return err
+	}
+	topic := resources.GenerateTopicName(source)
+	if budget.AllUpdatesRule == nil || budget.AllUpdatesRule.PubsubTopic != resources.GenerateTopicResourceName(source, topic) {
+		return nil
+	}
+
+	if _, err := client.UpdateBudget(ctx, &budgetspb.UpdateBudgetRequest{
+		Budget: &budgetspb.Budget{
+			Name:           budgetName,
+			Etag:           budget.Etag,
+			AllUpdatesRule: &budgetspb.AllUpdatesRule{},
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{pubsubTopicPath}},
+	}); err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to unset the Pub/Sub topic of CloudBillingBudgetSource budget", zap.String("budgetName", budgetName), zap.Error(err))
+		source.Status.MarkBudgetUnknown(deleteBudgetTopicFailed, "Failed to delete CloudBillingBudgetSource budget topic: %s", err.Error())
+		return err
+	}
+	logging.FromContext(ctx).Desugar().Debug("Deleted CloudBillingBudgetSource budget topic", zap.String("budgetName", budgetName))
+	return nil
+}
+
+func (r *Reconciler) FinalizeKind(ctx context.Context, source *v1.CloudBillingBudgetSource) reconciler.Event {
+	// If k8s ServiceAccount exists, binds to the default GCP ServiceAccount, and it only has one ownerReference,
+	// remove the corresponding GCP ServiceAccount iam policy binding.
+	// No need to delete k8s ServiceAccount, it will be automatically handled by k8s Garbage Collection.
+	if source.Spec.ServiceAccountName != "" {
+		if err := r.Identity.DeleteWorkloadIdentity(ctx, source.Spec.Project, source); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, deleteWorkloadIdentityFailed, "Failed to delete CloudBillingBudgetSource workload identity: %s", err.Error())
+		}
+	}
+
+	logging.FromContext(ctx).Desugar().Debug("Deleting CloudBillingBudgetSource budget topic")
+	if err := r.deleteBudgetTopic(ctx, source); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deleteBudgetTopicFailed, "Failed to delete CloudBillingBudgetSource budget topic: %s", err.Error())
+	}
+
+	if err := r.PubSubBase.DeletePubSub(ctx, source); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deletePubSubFailed, "Failed to delete CloudBillingBudgetSource PubSub: %s", err.Error())
+	}
+
+	return nil
+}
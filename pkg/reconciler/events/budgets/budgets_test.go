@@ -0,0 +1,554 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	. "knative.dev/pkg/reconciler/testing"
+
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	budgetsv1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	. "github.com/google/knative-gcp/pkg/apis/intevents"
+	inteventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
+	"github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1/cloudbillingbudgetsource"
+	gbudgets "github.com/google/knative-gcp/pkg/gclient/budgets/testing"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+	reconcilertestingv1 "github.com/google/knative-gcp/pkg/reconciler/testing/v1"
+
+	budgetspb "google.golang.org/genproto/googleapis/cloud/billing/budgets/v1beta1"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+)
+
+const (
+	sourceName = "my-test-budget"
+	sourceUID  = "test-billing-budget-uid"
+	sinkName   = "sink"
+
+	testNS       = "testnamespace"
+	testProject  = "test-project-id"
+	testTopicURI = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
+
+	billingAccount = "012345-6789AB-CDEF01"
+	budgetID       = "budget-id"
+	budgetName     = "billingAccounts/" + billingAccount + "/budgets/" + budgetID
+	budgetEtag     = "etag"
+	otherTopic     = "projects/other-project/topics/other-topic"
+
+	// Message for when the topic and pullsubscription with the above variables are not ready.
+	failedToReconcileTopicMsg  = `Topic has not yet been reconciled`
+	failedToReconcileBudgetMsg = `Failed to reconcile CloudBillingBudgetSource budget`
+	failedToDeleteBudgetMsg    = `Failed to delete CloudBillingBudgetSource budget topic`
+)
+
+var (
+	trueVal  = true
+	falseVal = false
+
+	sinkDNS = sinkName + ".mynamespace.svc.cluster.local"
+	sinkURI = apis.HTTP(sinkDNS)
+
+	testTopicID = fmt.Sprintf("cre-src_%s_%s_%s", testNS, sourceName, sourceUID)
+
+	sinkGVK = metav1.GroupVersionKind{
+		Group:   "testing.cloud.google.com",
+		Version: "v1",
+		Kind:    "Sink",
+	}
+
+	secret = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "google-cloud-key",
+		},
+		Key: "key.json",
+	}
+)
+
+func init() {
+	// Add types to scheme
+	_ = budgetsv1.AddToScheme(scheme.Scheme)
+}
+
+// Returns an ownerref for the test CloudBillingBudgetSource object
+func ownerRef() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         "events.cloud.google.com/v1",
+		Kind:               "CloudBillingBudgetSource",
+		Name:               sourceName,
+		UID:                sourceUID,
+		Controller:         &trueVal,
+		BlockOwnerDeletion: &trueVal,
+	}
+}
+
+func patchFinalizers(namespace, name string, add bool) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	var fname string
+	if add {
+		fname = fmt.Sprintf("%q", resourceGroup)
+	}
+	patch := `{"metadata":{"finalizers":[` + fname + `],"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func newSink() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "testing.cloud.google.com/v1",
+			"kind":       "Sink",
+			"metadata": map[string]interface{}{
+				"namespace": testNS,
+				"name":      sinkName,
+			},
+			"status": map[string]interface{}{
+				"address": map[string]interface{}{
+					"hostname": sinkDNS,
+				},
+			},
+		},
+	}
+}
+
+// newSource returns the test CloudBillingBudgetSource, with the options
+// applied on top of its spec.
+func newSource(opts ...reconcilertestingv1.CloudBillingBudgetSourceOption) *budgetsv1.CloudBillingBudgetSource {
+	return reconcilertestingv1.NewCloudBillingBudgetSource(sourceName, testNS, append([]reconcilertestingv1.CloudBillingBudgetSourceOption{
+		reconcilertestingv1.WithCloudBillingBudgetSourceProject(testProject),
+		reconcilertestingv1.WithCloudBillingBudgetSourceSink(sinkGVK, sinkName),
+		reconcilertestingv1.WithCloudBillingBudgetSourceBudget(billingAccount, budgetID),
+		reconcilertestingv1.WithCloudBillingBudgetSourceSetDefaults,
+	}, opts...)...)
+}
+
+// newReadySource returns the test CloudBillingBudgetSource with a ready
+// topic and pullsubscription, with the options applied on top of its status.
+func newReadySource(opts ...reconcilertestingv1.CloudBillingBudgetSourceOption) *budgetsv1.CloudBillingBudgetSource {
+	return newSource(append([]reconcilertestingv1.CloudBillingBudgetSourceOption{
+		reconcilertestingv1.WithInitCloudBillingBudgetSourceConditions,
+		reconcilertestingv1.WithCloudBillingBudgetSourceTopicReady(testTopicID, testProject),
+		reconcilertestingv1.WithCloudBillingBudgetSourcePullSubscriptionReady,
+		reconcilertestingv1.WithCloudBillingBudgetSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+		reconcilertestingv1.WithCloudBillingBudgetSourceSinkURI(sinkURI),
+	}, opts...)...)
+}
+
+func newReadyTopic() *inteventsv1.Topic {
+	return reconcilertestingv1.NewTopic(sourceName, testNS,
+		reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+			Topic:             testTopicID,
+			PropagationPolicy: "CreateDelete",
+			Project:           testProject,
+			EnablePublisher:   &falseVal,
+		}),
+		reconcilertestingv1.WithTopicReady(testTopicID),
+		reconcilertestingv1.WithTopicAddress(testTopicURI),
+		reconcilertestingv1.WithTopicProjectID(testProject),
+		reconcilertestingv1.WithTopicSetDefaults,
+	)
+}
+
+func newReadyPullSubscription() *inteventsv1.PullSubscription {
+	return reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+		reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+		reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+			Topic: testTopicID,
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Secret: &secret,
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "testing.cloud.google.com/v1",
+							Kind:       "Sink",
+							Name:       sinkName,
+						},
+					},
+				},
+				Project: testProject,
+			},
+			AdapterType: string(converters.CloudBillingBudget),
+		}),
+	)
+}
+
+// newBudget returns the test budget, publishing its notifications to topic.
+func newBudget(topic string) *budgetspb.Budget {
+	b := &budgetspb.Budget{
+		Name: budgetName,
+		Etag: budgetEtag,
+	}
+	if topic != "" {
+		b.AllUpdatesRule = &budgetspb.AllUpdatesRule{
+			PubsubTopic:   topic,
+			SchemaVersion: "1.0",
+		}
+	}
+	return b
+}
+
+func TestAllCases(t *testing.T) {
+	ourTopic := "projects/" + testProject + "/topics/" + testTopicID
+
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "topic created, not ready",
+		Objects: []runtime.Object{
+			newSource(),
+			newSink(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newSource(
+				reconcilertestingv1.WithInitCloudBillingBudgetSourceConditions,
+				reconcilertestingv1.WithCloudBillingBudgetSourceTopicUnknown("TopicNotConfigured", failedToReconcileTopicMsg),
+			),
+		}},
+		WantCreates: []runtime.Object{
+			reconcilertestingv1.NewTopic(sourceName, testNS,
+				reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					Project:           testProject,
+					EnablePublisher:   &falseVal,
+				}),
+				reconcilertestingv1.WithTopicLabels(map[string]string{
+					"receive-adapter": receiveAdapterName,
+					SourceLabelKey:    sourceName,
+				}),
+				reconcilertestingv1.WithTopicOwnerReferences([]metav1.OwnerReference{ownerRef()}),
+				reconcilertestingv1.WithTopicSetDefaults,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: Topic %q has not yet been reconciled", sourceName),
+		},
+	}, {
+		Name: "topic and pullsubscription exist and ready, create client fails",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				CreateClientErr: errors.New("create-client-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: create-client-induced-error", failedToReconcileBudgetMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: create-client-induced-error"),
+		},
+	}, {
+		Name: "get budget fails",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				GetBudgetErr: gstatus.Error(codes.NotFound, "get-budget-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: rpc error: code = NotFound desc = get-budget-induced-error", failedToReconcileBudgetMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: rpc error: code = NotFound desc = get-budget-induced-error"),
+		},
+	}, {
+		Name: "budget publishes to another topic",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget: newBudget(otherTopic),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: budget %q already publishes its notifications to %q", failedToReconcileBudgetMsg, budgetName, otherTopic)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: budget %q already publishes its notifications to %q", budgetName, otherTopic),
+		},
+	}, {
+		Name: "update budget fails",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget:          newBudget(""),
+				UpdateBudgetErr: gstatus.Error(codes.PermissionDenied, "update-budget-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: rpc error: code = PermissionDenied desc = update-budget-induced-error", failedToReconcileBudgetMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Budget failed with: rpc error: code = PermissionDenied desc = update-budget-induced-error"),
+		},
+	}, {
+		Name: "budget topic set",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget: newBudget(""),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudBillingBudgetSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "budget already publishes to the topic",
+		Objects: []runtime.Object{
+			newSource(),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget: newBudget(ourTopic),
+				// The budget must not be updated.
+				UpdateBudgetErr: errors.New("update-budget-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudBillingBudgetSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "budget topic fails to delete",
+		Objects: []runtime.Object{
+			newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget:          newBudget(ourTopic),
+				UpdateBudgetErr: gstatus.Error(codes.Unknown, "update-budget-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetUnknown(deleteBudgetTopicFailed,
+					fmt.Sprintf("%s: rpc error: code = Unknown desc = update-budget-induced-error", failedToDeleteBudgetMsg)),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, deleteBudgetTopicFailed, "Failed to delete CloudBillingBudgetSource budget topic: rpc error: code = Unknown desc = update-budget-induced-error"),
+		},
+	}, {
+		Name: "budget topic successfully deleted",
+		Objects: []runtime.Object{
+			newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				Budget: newBudget(ourTopic),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newSource(
+				reconcilertestingv1.WithInitCloudBillingBudgetSourceConditions,
+				reconcilertestingv1.WithCloudBillingBudgetSourceTopicDeleted,
+				reconcilertestingv1.WithCloudBillingBudgetSourcePullSubscriptionDeleted,
+				reconcilertestingv1.WithCloudBillingBudgetSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
+	}, {
+		Name: "budget deleted out of band, successfully deleted",
+		Objects: []runtime.Object{
+			newReadySource(
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"budgets": gbudgets.TestClientData{
+				GetBudgetErr: gstatus.Error(codes.NotFound, "get-budget-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newSource(
+				reconcilertestingv1.WithInitCloudBillingBudgetSourceConditions,
+				reconcilertestingv1.WithCloudBillingBudgetSourceTopicDeleted,
+				reconcilertestingv1.WithCloudBillingBudgetSourcePullSubscriptionDeleted,
+				reconcilertestingv1.WithCloudBillingBudgetSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+				reconcilertestingv1.WithCloudBillingBudgetSourceBudgetReady(budgetName),
+				reconcilertestingv1.WithCloudBillingBudgetSourceDeletionTimestamp,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
+	}}
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
+		r := &Reconciler{
+			PubSubBase: intevents.NewPubSubBase(ctx,
+				&intevents.PubSubBaseArgs{
+					ControllerAgentName: controllerAgentName,
+					ReceiveAdapterName:  receiveAdapterName,
+					ReceiveAdapterType:  string(converters.CloudBillingBudget),
+					ConfigWatcher:       cmw,
+				}),
+			Identity:           identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
+			budgetSourceLister: listers.GetCloudBillingBudgetSourceLister(),
+			createClientFn:     gbudgets.TestClientCreator(testData["budgets"]),
+		}
+		return cloudbillingbudgetsource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudBillingBudgetSourceLister(), r.Recorder, r)
+	}))
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	"github.com/google/knative-gcp/pkg/utils/naming"
+)
+
+// GenerateTopicName generates a topic name for the CloudBillingBudgetSource. This refers to the underlying Pub/Sub
+// topic, and not our Topic resource.
+func GenerateTopicName(source *v1.CloudBillingBudgetSource) string {
+	return naming.TruncatedPubsubResourceName("cre-src", source.Namespace, source.Name, source.UID)
+}
+
+// GenerateTopicResourceName generates the full name of the Pub/Sub topic the budget notifications are published
+// to, like this: projects/PROJECT_ID/topics/TOPIC_ID.
+func GenerateTopicResourceName(source *v1.CloudBillingBudgetSource, topic string) string {
+	return fmt.Sprintf("projects/%s/topics/%s", source.Status.ProjectID, topic)
+}
+
+// GenerateBudgetName generates the name of the budget of the CloudBillingBudgetSource, like this:
+// billingAccounts/BILLING_ACCOUNT_ID/budgets/BUDGET_ID.
+func GenerateBudgetName(source *v1.CloudBillingBudgetSource) string {
+	return fmt.Sprintf("billingAccounts/%s/budgets/%s", source.Spec.BillingAccount, source.Spec.Budget)
+}
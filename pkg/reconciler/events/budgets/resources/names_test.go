@@ -0,0 +1,62 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+)
+
+func newSource() *v1.CloudBillingBudgetSource {
+	return &v1.CloudBillingBudgetSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "budget",
+			Namespace: "ns",
+			UID:       "uid",
+		},
+		Spec: v1.CloudBillingBudgetSourceSpec{
+			BillingAccount: "012345-6789AB-CDEF01",
+			Budget:         "budget-id",
+		},
+		Status: v1.CloudBillingBudgetSourceStatus{
+			PubSubStatus: duckv1.PubSubStatus{
+				ProjectID: "project",
+			},
+		},
+	}
+}
+
+func TestGenerateTopicResourceName(t *testing.T) {
+	want := "projects/project/topics/topic"
+	got := GenerateTopicResourceName(newSource(), "topic")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateBudgetName(t *testing.T) {
+	want := "billingAccounts/012345-6789AB-CDEF01/budgets/budget-id"
+	got := GenerateBudgetName(newSource())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
@@ -82,6 +82,11 @@ var (
 		Key: "key.json",
 	}
 
+	buildFilter = gcpduckv1.BuildFilterSpec{
+		Statuses: []string{"SUCCESS", "FAILURE"},
+		Tags:     []string{"release"},
+	}
+
 	gServiceAccount = "test123@test123.iam.gserviceaccount.com"
 )
 
@@ -219,6 +224,69 @@ func TestAllCases(t *testing.T) {
 				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", buildName),
 				Eventf(corev1.EventTypeWarning, intevents.PullSubscriptionStatusPropagateFailedReason, "%s: PullSubscription %q has not yet been reconciled", failedToPropagatePullSubscriptionStatusMsg, buildName),
 			},
+		}, {
+			Name: "pullsubscription created with build filter",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudBuildSource(buildName, testNS,
+					reconcilertestingv1.WithCloudBuildSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudBuildSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudBuildSourceBuildFilter(&buildFilter),
+					reconcilertestingv1.WithCloudBuildSourceAnnotations(map[string]string{
+						duck.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+					}),
+					reconcilertestingv1.WithCloudBuildSourceSetDefault,
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + buildName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudBuildSource(buildName, testNS,
+					reconcilertestingv1.WithCloudBuildSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudBuildSourceStatusObservedGeneration(generation),
+					reconcilertestingv1.WithCloudBuildSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudBuildSourceBuildFilter(&buildFilter),
+					reconcilertestingv1.WithInitCloudBuildSourceConditions,
+					reconcilertestingv1.WithCloudBuildSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudBuildSourceAnnotations(map[string]string{
+						duck.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+					}),
+					reconcilertestingv1.WithCloudBuildSourceSetDefault,
+					reconcilertestingv1.WithCloudBuildSourcePullSubscriptionUnknown("PullSubscriptionNotConfigured", "PullSubscription has not yet been reconciled"),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				reconcilertestingv1.NewPullSubscription(buildName, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudBuild),
+						BuildFilter: &buildFilter,
+					}),
+					reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithPullSubscriptionLabels(map[string]string{
+						"receive-adapter":                     receiveAdapterName,
+						"events.cloud.google.com/source-name": buildName,
+					}),
+					reconcilertestingv1.WithPullSubscriptionAnnotations(map[string]string{
+						"metrics-resource-group":   resourceGroup,
+						duck.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+					}),
+					reconcilertestingv1.WithPullSubscriptionOwnerReferences([]metav1.OwnerReference{ownerRef()}),
+					reconcilertestingv1.WithPullSubscriptionDefaultGCPAuth,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, buildName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", buildName),
+				Eventf(corev1.EventTypeWarning, intevents.PullSubscriptionStatusPropagateFailedReason, "%s: PullSubscription %q has not yet been reconciled", failedToPropagatePullSubscriptionStatusMsg, buildName),
+			},
 		}, {
 			Name: "pullsubscription exists and the status is false",
 			Objects: []runtime.Object{
@@ -0,0 +1,311 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	cloudmonitoringalertsourcereconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1/cloudmonitoringalertsource"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1"
+	gmonitoring "github.com/google/knative-gcp/pkg/gclient/monitoring"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring/resources"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	"github.com/google/knative-gcp/pkg/utils"
+)
+
+const (
+	resourceGroup = "cloudmonitoringalertsources.events.cloud.google.com"
+
+	deleteNotificationChannelFailed     = "NotificationChannelDeleteFailed"
+	deletePubSubFailed                  = "PubSubDeleteFailed"
+	deleteWorkloadIdentityFailed        = "WorkloadIdentityDeleteFailed"
+	reconciledAlertPoliciesFailedReason = "AlertPoliciesReconcileFailed"
+	reconciledFailedReason              = "NotificationChannelReconcileFailed"
+	reconciledPubSubFailedReason        = "PubSubReconcileFailed"
+	reconciledSuccessReason             = "CloudMonitoringAlertSourceReconciled"
+	workloadIdentityFailed              = "WorkloadIdentityReconcileFailed"
+)
+
+// notificationChannelsPath is the update mask path of the notification
+// channels of an alert policy.
+const notificationChannelsPath = "notification_channels"
+
+// Reconciler is the controller implementation for Google Cloud Monitoring alert notifications.
+type Reconciler struct {
+	*intevents.PubSubBase
+	// identity reconciler for reconciling workload identity.
+	*identity.Identity
+	// alertSourceLister for reading CloudMonitoringAlertSources.
+	alertSourceLister listers.CloudMonitoringAlertSourceLister
+
+	createClientFn gmonitoring.CreateFn
+}
+
+// Check that our Reconciler implements Interface.
+var _ cloudmonitoringalertsourcereconciler.Interface = (*Reconciler)(nil)
+
+func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.CloudMonitoringAlertSource) reconciler.Event {
+	ctx = logging.WithLogger(ctx, r.Logger.With(zap.Any("monitoringalert", source)))
+
+	source.Status.InitializeConditions()
+	source.Status.ObservedGeneration = source.Generation
+
+	// If ServiceAccountName is provided, reconcile workload identity.
+	if source.Spec.ServiceAccountName != "" {
+		if _, err := r.Identity.ReconcileWorkloadIdentity(ctx, source.Spec.Project, source); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, workloadIdentityFailed, "Failed to reconcile CloudMonitoringAlertSource workload identity: %s", err.Error())
+		}
+	}
+
+	topic := resources.GenerateTopicName(source)
+	_, _, err := r.PubSubBase.ReconcilePubSub(ctx, source, topic, resourceGroup)
+	if err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+	}
+
+	if source.Status.ProjectID == "" {
+		projectID, err := utils.ProjectIDOrDefault(source.Spec.Project)
+		if err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to find project id", zap.Error(err))
+			source.Status.MarkNotificationChannelNotReady(reconciledFailedReason, "Failed to find project id: %s", err.Error())
+			return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: %s", err.Error())
+		}
+		// Set the projectID in the status.
+		source.Status.ProjectID = projectID
+	}
+
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudMonitoringAlertSource client", zap.Error(err))
+		source.Status.MarkNotificationChannelNotReady(reconciledFailedReason, "Failed to create CloudMonitoringAlertSource client: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: %s", err.Error())
+	}
+	defer client.Close()
+
+	channelName, err := r.reconcileNotificationChannel(ctx, client, source, topic)
+	if err != nil {
+		source.Status.MarkNotificationChannelNotReady(reconciledFailedReason, "Failed to reconcile CloudMonitoringAlertSource notification channel: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: %s", err.Error())
+	}
+	source.Status.MarkNotificationChannelReady(channelName)
+
+	policyNames, err := r.reconcileAlertPolicies(ctx, client, source, channelName)
+	if err != nil {
+		source.Status.MarkAlertPoliciesNotReady(reconciledAlertPoliciesFailedReason, "Failed to reconcile CloudMonitoringAlertSource alert policies: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledAlertPoliciesFailedReason, "Reconcile AlertPolicies failed with: %s", err.Error())
+	}
+	source.Status.MarkAlertPoliciesReady(policyNames)
+
+	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, source.Namespace, source.Name)
+}
+
+// reconcileNotificationChannel makes sure the Pub/Sub notification channel
+// recorded in the status exists and publishes to topic, adopting an existing
+// channel that publishes to topic or creating one if needed. It returns the
+// name of the channel.
+func (r *Reconciler) reconcileNotificationChannel(ctx context.Context, client gmonitoring.Client, source *v1.CloudMonitoringAlertSource, topic string) (string, error) {
+	desired := resources.MakeNotificationChannel(source, topic)
+
+	// Channel names are assigned on creation, so the channel is looked up by
+	// the name recorded in the status first.
+	if channelName := source.Status.NotificationChannelName; channelName != "" {
+		existing, err := client.GetNotificationChannel(ctx, &monitoringpb.GetNotificationChannelRequest{Name: channelName})
+		if err == nil {
+			if resources.ChannelTopic(existing) == resources.ChannelTopic(desired) {
+				return channelName, nil
+			}
+			// The channel was modified out of band, point it back to the topic.
+			desired.Name = channelName
+			if _, err := client.UpdateNotificationChannel(ctx, &monitoringpb.UpdateNotificationChannelRequest{
+				NotificationChannel: desired,
+				UpdateMask:          &field_mask.FieldMask{Paths: []string{"labels"}},
+			}); err != nil {
+				logging.FromContext(ctx).Desugar().Error("Failed to update CloudMonitoringAlertSource notification channel", zap.String("channelName", channelName), zap.Error(err))
+				return "", err
+			}
+			return channelName, nil
+		}
+		if st, ok := gstatus.FromError(err); !ok || st.Code() != codes.NotFound {
+			logging.FromContext(ctx).Desugar().Error("Failed from CloudMonitoringAlertSource client while retrieving CloudMonitoringAlertSource notification channel", zap.String("channelName", channelName), zap.Error(err))
+			return "", err
+		}
+		// The channel was deleted out of band, create a new one.
+	}
+
+	// A channel may have been created without its name being recorded in the
+	// status, e.g. if the status update failed, so adopt it instead of
+	// creating another one.
+	channels, err := client.ListNotificationChannels(ctx, &monitoringpb.ListNotificationChannelsRequest{
+		Name:   resources.GenerateProjectName(source),
+		Filter: resources.ChannelFilter(desired),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to list CloudMonitoringAlertSource notification channels", zap.Error(err))
+		return "", err
+	}
+	for _, channel := range channels {
+		if resources.ChannelTopic(channel) == resources.ChannelTopic(desired) {
+			return channel.Name, nil
+		}
+	}
+
+	created, err := client.CreateNotificationChannel(ctx, &monitoringpb.CreateNotificationChannelRequest{
+		Name:                resources.GenerateProjectName(source),
+		NotificationChannel: desired,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudMonitoringAlertSource notification channel", zap.Error(err))
+		return "", err
+	}
+	return created.Name, nil
+}
+
+// reconcileAlertPolicies adds the notification channel to the alert policies
+// of the spec, and removes it from the ones it was previously added to that
+// are no longer in the spec. It returns the names of the alert policies of
+// the spec.
+func (r *Reconciler) reconcileAlertPolicies(ctx context.Context, client gmonitoring.Client, source *v1.CloudMonitoringAlertSource, channelName string) ([]string, error) {
+	policyNames := make([]string, 0, len(source.Spec.AlertPolicies))
+	desired := make(map[string]bool, len(source.Spec.AlertPolicies))
+	for _, id := range source.Spec.AlertPolicies {
+		policyName := resources.GenerateAlertPolicyName(source, id)
+		if err := setPolicyChannel(ctx, client, policyName, channelName, true); err != nil {
+			return nil, err
+		}
+		policyNames = append(policyNames, policyName)
+		desired[policyName] = true
+	}
+
+	for _, policyName := range source.Status.AlertPolicies {
+		if desired[policyName] {
+			continue
+		}
+		if err := setPolicyChannel(ctx, client, policyName, channelName, false); err != nil {
+			return nil, err
+		}
+	}
+	return policyNames, nil
+}
+
+// setPolicyChannel adds the notification channel to, or removes it from, the
+// alert policy. Removing the channel from a policy that no longer exists is a
+// no-op.
+func setPolicyChannel(ctx context.Context, client gmonitoring.Client, policyName, channelName string, add bool) error {
+	policy, err := client.GetAlertPolicy(ctx, &monitoringpb.GetAlertPolicyRequest{Name: policyName})
+	if err != nil {
+		if st, ok := gstatus.FromError(err); ok && st.Code() == codes.NotFound && !add {
+			return nil
+		}
+		logging.FromContext(ctx).Desugar().Error("Failed from CloudMonitoringAlertSource client while retrieving alert policy", zap.String("policyName", policyName), zap.Error(err))
+		return err
+	}
+
+	channels := make([]string, 0, len(policy.NotificationChannels)+1)
+	found := false
+	for _, c := range policy.NotificationChannels {
+		if c == channelName {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		channels = append(channels, c)
+	}
+	if found == add {
+		return nil
+	}
+	if add {
+		channels = append(channels, channelName)
+	}
+
+	policy.NotificationChannels = channels
+	_, err = client.UpdateAlertPolicy(ctx, &monitoringpb.UpdateAlertPolicyRequest{
+		AlertPolicy: policy,
+		UpdateMask:  &field_mask.FieldMask{Paths: []string{notificationChannelsPath}},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to update the notification channels of alert policy", zap.String("policyName", policyName), zap.Bool("add", add), zap.Error(err))
+	}
+	return err
+}
+
+// deleteNotificationChannel looks at the status.NotificationChannelName and
+// if non-empty, hence indicating that we have created a channel successfully,
+// removes it. The channel is forcibly deleted, which also removes it from the
+// alert policies.
+func (r *Reconciler) deleteNotificationChannel(ctx context.Context, source *v1.CloudMonitoringAlertSource) error {
+	channelName := source.Status.NotificationChannelName
+	if channelName == "" {
+		return nil
+	}
+
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudMonitoringAlertSource client", zap.Error(err))
+		source.Status.MarkNotificationChannelUnknown(deleteNotificationChannelFailed, "Failed to create CloudMonitoringAlertSource client: %s", err.Error())
+		return err
+	}
+	defer client.Close()
+
+	err = client.DeleteNotificationChannel(ctx, &monitoringpb.DeleteNotificationChannelRequest{
+		Name:  channelName,
+		Force: true,
+	})
+	if err == nil {
+		logging.FromContext(ctx).Desugar().Debug("Deleted CloudMonitoringAlertSource notification channel", zap.String("channelName", channelName))
+		return nil
+	}
+	if st, ok := gstatus.FromError(err); !ok || st.Code() != codes.NotFound {
+		logging.FromContext(ctx).Desugar().Error("Failed to delete CloudMonitoringAlertSource notification channel", zap.String("channelName", channelName), zap.Error(err))
+		source.Status.MarkNotificationChannelUnknown(deleteNotificationChannelFailed, "Failed to delete CloudMonitoringAlertSource notification channel: %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) FinalizeKind(ctx context.Context, source *v1.CloudMonitoringAlertSource) reconciler.Event {
+	// If k8s ServiceAccount exists, binds to the default GCP ServiceAccount, and it only has one ownerReference,
+	// remove the corresponding GCP ServiceAccount iam policy binding.
+	// No need to delete k8s ServiceAccount, it will be automatically handled by k8s Garbage Collection.
+	if source.Spec.ServiceAccountName != "" {
+		if err := r.Identity.DeleteWorkloadIdentity(ctx, source.Spec.Project, source); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, deleteWorkloadIdentityFailed, "Failed to delete CloudMonitoringAlertSource workload identity: %s", err.Error())
+		}
+	}
+
+	logging.FromContext(ctx).Desugar().Debug("Deleting CloudMonitoringAlertSource notification channel")
+	if err := r.deleteNotificationChannel(ctx, source); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deleteNotificationChannelFailed, "Failed to delete CloudMonitoringAlertSource notification channel: %s", err.Error())
+	}
+
+	if err := r.PubSubBase.DeletePubSub(ctx, source); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deletePubSubFailed, "Failed to delete CloudMonitoringAlertSource PubSub: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,654 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	. "knative.dev/pkg/reconciler/testing"
+
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	monitoringv1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	. "github.com/google/knative-gcp/pkg/apis/intevents"
+	inteventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
+	"github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1/cloudmonitoringalertsource"
+	gmonitoring "github.com/google/knative-gcp/pkg/gclient/monitoring/testing"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+	reconcilertestingv1 "github.com/google/knative-gcp/pkg/reconciler/testing/v1"
+
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+)
+
+const (
+	sourceName = "my-test-alerts"
+	sourceUID  = "test-monitoring-alert-uid"
+	sinkName   = "sink"
+
+	testNS       = "testnamespace"
+	testProject  = "test-project-id"
+	testTopicURI = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
+
+	channelID          = "111"
+	recreatedChannelID = "222"
+	channelName        = "projects/" + testProject + "/notificationChannels/" + channelID
+	recreatedChannel   = "projects/" + testProject + "/notificationChannels/" + recreatedChannelID
+	otherChannelName   = "projects/" + testProject + "/notificationChannels/999"
+	policyID           = "123"
+	otherPolicyID      = "456"
+	policyName         = "projects/" + testProject + "/alertPolicies/" + policyID
+	otherPolicyName    = "projects/" + testProject + "/alertPolicies/" + otherPolicyID
+
+	// Message for when the topic and pullsubscription with the above variables are not ready.
+	failedToReconcileTopicMsg         = `Topic has not yet been reconciled`
+	failedToReconcileChannelMsg       = `Failed to reconcile CloudMonitoringAlertSource notification channel`
+	failedToReconcileAlertPoliciesMsg = `Failed to reconcile CloudMonitoringAlertSource alert policies`
+	failedToDeleteChannelMsg          = `Failed to delete CloudMonitoringAlertSource notification channel`
+)
+
+var (
+	trueVal  = true
+	falseVal = false
+
+	sinkDNS = sinkName + ".mynamespace.svc.cluster.local"
+	sinkURI = apis.HTTP(sinkDNS)
+
+	testTopicID = fmt.Sprintf("cre-src_%s_%s_%s", testNS, sourceName, sourceUID)
+
+	sinkGVK = metav1.GroupVersionKind{
+		Group:   "testing.cloud.google.com",
+		Version: "v1",
+		Kind:    "Sink",
+	}
+
+	secret = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "google-cloud-key",
+		},
+		Key: "key.json",
+	}
+)
+
+func init() {
+	// Add types to scheme
+	_ = monitoringv1.AddToScheme(scheme.Scheme)
+}
+
+// Returns an ownerref for the test CloudMonitoringAlertSource object
+func ownerRef() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         "events.cloud.google.com/v1",
+		Kind:               "CloudMonitoringAlertSource",
+		Name:               sourceName,
+		UID:                sourceUID,
+		Controller:         &trueVal,
+		BlockOwnerDeletion: &trueVal,
+	}
+}
+
+func patchFinalizers(namespace, name string, add bool) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	var fname string
+	if add {
+		fname = fmt.Sprintf("%q", resourceGroup)
+	}
+	patch := `{"metadata":{"finalizers":[` + fname + `],"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func newSink() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "testing.cloud.google.com/v1",
+			"kind":       "Sink",
+			"metadata": map[string]interface{}{
+				"namespace": testNS,
+				"name":      sinkName,
+			},
+			"status": map[string]interface{}{
+				"address": map[string]interface{}{
+					"hostname": sinkDNS,
+				},
+			},
+		},
+	}
+}
+
+// newSource returns the test CloudMonitoringAlertSource for the alert
+// policies, with the options applied on top of its spec.
+func newSource(policies []string, opts ...reconcilertestingv1.CloudMonitoringAlertSourceOption) *monitoringv1.CloudMonitoringAlertSource {
+	return reconcilertestingv1.NewCloudMonitoringAlertSource(sourceName, testNS, append([]reconcilertestingv1.CloudMonitoringAlertSourceOption{
+		reconcilertestingv1.WithCloudMonitoringAlertSourceProject(testProject),
+		reconcilertestingv1.WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+		reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPolicies(policies...),
+		reconcilertestingv1.WithCloudMonitoringAlertSourceSetDefaults,
+	}, opts...)...)
+}
+
+// newReadySource returns the test CloudMonitoringAlertSource with a ready
+// topic and pullsubscription, with the options applied on top of its status.
+func newReadySource(policies []string, opts ...reconcilertestingv1.CloudMonitoringAlertSourceOption) *monitoringv1.CloudMonitoringAlertSource {
+	return newSource(policies, append([]reconcilertestingv1.CloudMonitoringAlertSourceOption{
+		reconcilertestingv1.WithInitCloudMonitoringAlertSourceConditions,
+		reconcilertestingv1.WithCloudMonitoringAlertSourceTopicReady(testTopicID, testProject),
+		reconcilertestingv1.WithCloudMonitoringAlertSourcePullSubscriptionReady,
+		reconcilertestingv1.WithCloudMonitoringAlertSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+		reconcilertestingv1.WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+	}, opts...)...)
+}
+
+func newReadyTopic() *inteventsv1.Topic {
+	return reconcilertestingv1.NewTopic(sourceName, testNS,
+		reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+			Topic:             testTopicID,
+			PropagationPolicy: "CreateDelete",
+			Project:           testProject,
+			EnablePublisher:   &falseVal,
+		}),
+		reconcilertestingv1.WithTopicReady(testTopicID),
+		reconcilertestingv1.WithTopicAddress(testTopicURI),
+		reconcilertestingv1.WithTopicProjectID(testProject),
+		reconcilertestingv1.WithTopicSetDefaults,
+	)
+}
+
+func newReadyPullSubscription() *inteventsv1.PullSubscription {
+	return reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+		reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+		reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+			Topic: testTopicID,
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Secret: &secret,
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "testing.cloud.google.com/v1",
+							Kind:       "Sink",
+							Name:       sinkName,
+						},
+					},
+				},
+				Project: testProject,
+			},
+			AdapterType: string(converters.CloudMonitoring),
+		}),
+	)
+}
+
+// newChannel returns a notification channel that publishes to topic.
+func newChannel(name, topic string) *monitoringpb.NotificationChannel {
+	return &monitoringpb.NotificationChannel{
+		Name: name,
+		Type: "pubsub",
+		Labels: map[string]string{
+			"topic": "projects/" + testProject + "/topics/" + topic,
+		},
+	}
+}
+
+func TestAllCases(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "topic created, not ready",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newSink(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newSource([]string{policyID},
+				reconcilertestingv1.WithInitCloudMonitoringAlertSourceConditions,
+				reconcilertestingv1.WithCloudMonitoringAlertSourceTopicUnknown("TopicNotConfigured", failedToReconcileTopicMsg),
+			),
+		}},
+		WantCreates: []runtime.Object{
+			reconcilertestingv1.NewTopic(sourceName, testNS,
+				reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					Project:           testProject,
+					EnablePublisher:   &falseVal,
+				}),
+				reconcilertestingv1.WithTopicLabels(map[string]string{
+					"receive-adapter": receiveAdapterName,
+					SourceLabelKey:    sourceName,
+				}),
+				reconcilertestingv1.WithTopicOwnerReferences([]metav1.OwnerReference{ownerRef()}),
+				reconcilertestingv1.WithTopicSetDefaults,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: Topic %q has not yet been reconciled", sourceName),
+		},
+	}, {
+		Name: "topic and pullsubscription exist and ready, create client fails",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				CreateClientErr: errors.New("create-client-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason, "Failed to create CloudMonitoringAlertSource client: create-client-induced-error"),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: create-client-induced-error"),
+		},
+	}, {
+		Name: "create notification channel fails",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				CreateNotificationChannelErr: gstatus.Error(codes.PermissionDenied, "create-channel-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: rpc error: code = PermissionDenied desc = create-channel-induced-error", failedToReconcileChannelMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: rpc error: code = PermissionDenied desc = create-channel-induced-error"),
+		},
+	}, {
+		Name: "list notification channels fails",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				ListNotificationChannelsErr: gstatus.Error(codes.PermissionDenied, "list-channels-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: rpc error: code = PermissionDenied desc = list-channels-induced-error", failedToReconcileChannelMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: rpc error: code = PermissionDenied desc = list-channels-induced-error"),
+		},
+	}, {
+		Name: "notification channel not recorded in the status, adopted",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				// Creating another channel fails the test.
+				CreateNotificationChannelErr: gstatus.Error(codes.AlreadyExists, "create-channel-induced-error"),
+				NotificationChannels: []*monitoringpb.NotificationChannel{
+					newChannel(otherChannelName, "other-topic"),
+					newChannel(channelName, testTopicID),
+				},
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "notification channel created and added to alert policies",
+		Objects: []runtime.Object{
+			newSource([]string{policyID, otherPolicyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				NotificationChannelID: channelID,
+				AlertPolicies: map[string]*monitoringpb.AlertPolicy{
+					otherPolicyName: {
+						Name:                 otherPolicyName,
+						NotificationChannels: []string{otherChannelName},
+					},
+				},
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID, otherPolicyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName, otherPolicyName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "notification channel exists, alert policy removed from spec",
+		Objects: []runtime.Object{
+			newReadySource([]string{otherPolicyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				NotificationChannel: newChannel(channelName, testTopicID),
+				AlertPolicies: map[string]*monitoringpb.AlertPolicy{
+					policyName: {
+						Name:                 policyName,
+						NotificationChannels: []string{channelName, otherChannelName},
+					},
+				},
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{otherPolicyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(otherPolicyName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "notification channel deleted out of band, recreated",
+		Objects: []runtime.Object{
+			newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				GetNotificationChannelErr: gstatus.Error(codes.NotFound, "get-channel-induced-error"),
+				NotificationChannelID:     recreatedChannelID,
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(recreatedChannel),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+	}, {
+		Name: "notification channel modified out of band, update fails",
+		Objects: []runtime.Object{
+			newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				NotificationChannel:          newChannel(channelName, "other-topic"),
+				UpdateNotificationChannelErr: gstatus.Error(codes.Unknown, "update-channel-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason,
+					fmt.Sprintf("%s: rpc error: code = Unknown desc = update-channel-induced-error", failedToReconcileChannelMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: rpc error: code = Unknown desc = update-channel-induced-error"),
+		},
+	}, {
+		Name: "alert policy update fails",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				NotificationChannelID: channelID,
+				UpdateAlertPolicyErr:  gstatus.Error(codes.PermissionDenied, "update-policy-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesNotReady(reconciledAlertPoliciesFailedReason,
+					fmt.Sprintf("%s: rpc error: code = PermissionDenied desc = update-policy-induced-error", failedToReconcileAlertPoliciesMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledAlertPoliciesFailedReason, "Reconcile AlertPolicies failed with: rpc error: code = PermissionDenied desc = update-policy-induced-error"),
+		},
+	}, {
+		Name: "alert policy not found",
+		Objects: []runtime.Object{
+			newSource([]string{policyID}),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				NotificationChannelID: channelID,
+				GetAlertPolicyErr:     gstatus.Error(codes.NotFound, "get-policy-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesNotReady(reconciledAlertPoliciesFailedReason,
+					fmt.Sprintf("%s: rpc error: code = NotFound desc = get-policy-induced-error", failedToReconcileAlertPoliciesMsg)),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledAlertPoliciesFailedReason, "Reconcile AlertPolicies failed with: rpc error: code = NotFound desc = get-policy-induced-error"),
+		},
+	}, {
+		Name: "notification channel fails to delete",
+		Objects: []runtime.Object{
+			newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceDeletionTimestamp,
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				DeleteNotificationChannelErr: gstatus.Error(codes.Unknown, "delete-channel-induced-error"),
+			},
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelUnknown(deleteNotificationChannelFailed,
+					fmt.Sprintf("%s: rpc error: code = Unknown desc = delete-channel-induced-error", failedToDeleteChannelMsg)),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceDeletionTimestamp,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, deleteNotificationChannelFailed, "Failed to delete CloudMonitoringAlertSource notification channel: rpc error: code = Unknown desc = delete-channel-induced-error"),
+		},
+	}, {
+		Name: "notification channel successfully deleted",
+		Objects: []runtime.Object{
+			newReadySource([]string{policyID},
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceDeletionTimestamp,
+			),
+			newReadyTopic(),
+			newReadyPullSubscription(),
+			newSink(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newSource([]string{policyID},
+				reconcilertestingv1.WithInitCloudMonitoringAlertSourceConditions,
+				reconcilertestingv1.WithCloudMonitoringAlertSourceTopicDeleted,
+				reconcilertestingv1.WithCloudMonitoringAlertSourcePullSubscriptionDeleted,
+				reconcilertestingv1.WithCloudMonitoringAlertSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceNotificationChannelReady(channelName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceAlertPoliciesReady(policyName),
+				reconcilertestingv1.WithCloudMonitoringAlertSourceDeletionTimestamp,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
+	}}
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
+		r := &Reconciler{
+			PubSubBase: intevents.NewPubSubBase(ctx,
+				&intevents.PubSubBaseArgs{
+					ControllerAgentName: controllerAgentName,
+					ReceiveAdapterName:  receiveAdapterName,
+					ReceiveAdapterType:  string(converters.CloudMonitoring),
+					ConfigWatcher:       cmw,
+				}),
+			Identity:          identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
+			alertSourceLister: listers.GetCloudMonitoringAlertSourceLister(),
+			createClientFn:    gmonitoring.TestClientCreator(testData["monitoring"]),
+		}
+		return cloudmonitoringalertsource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudMonitoringAlertSourceLister(), r.Recorder, r)
+	}))
+}
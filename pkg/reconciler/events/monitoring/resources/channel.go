@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+)
+
+const (
+	// PubSubChannelType is the type of the notification channels that publish to a Pub/Sub topic.
+	PubSubChannelType = "pubsub"
+	// TopicLabel is the label of a Pub/Sub notification channel with the name of its topic.
+	TopicLabel = "topic"
+)
+
+// MakeNotificationChannel generates the Pub/Sub notification channel that
+// publishes the incidents of the alert policies of the
+// CloudMonitoringAlertSource to topic.
+func MakeNotificationChannel(source *v1.CloudMonitoringAlertSource, topic string) *monitoringpb.NotificationChannel {
+	return &monitoringpb.NotificationChannel{
+		Type:        PubSubChannelType,
+		DisplayName: fmt.Sprintf("CloudMonitoringAlertSource %s/%s", source.Namespace, source.Name),
+		Labels: map[string]string{
+			TopicLabel: fmt.Sprintf("projects/%s/topics/%s", source.Status.ProjectID, topic),
+		},
+	}
+}
+
+// ChannelTopic returns the topic a Pub/Sub notification channel publishes to.
+func ChannelTopic(channel *monitoringpb.NotificationChannel) string {
+	return channel.GetLabels()[TopicLabel]
+}
+
+// ChannelFilter returns the filter to list the notification channels of the
+// same type as channel that publish to the same topic.
+func ChannelFilter(channel *monitoringpb.NotificationChannel) string {
+	return fmt.Sprintf("type = %q AND labels.%s = %q", channel.GetType(), TopicLabel, ChannelTopic(channel))
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+)
+
+func newSource() *v1.CloudMonitoringAlertSource {
+	return &v1.CloudMonitoringAlertSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "alerts",
+			Namespace: "ns",
+			UID:       "uid",
+		},
+		Status: v1.CloudMonitoringAlertSourceStatus{
+			PubSubStatus: duckv1.PubSubStatus{
+				ProjectID: "project",
+			},
+		},
+	}
+}
+
+func TestMakeNotificationChannel(t *testing.T) {
+	want := &monitoringpb.NotificationChannel{
+		Type:        "pubsub",
+		DisplayName: "CloudMonitoringAlertSource ns/alerts",
+		Labels: map[string]string{
+			"topic": "projects/project/topics/topic",
+		},
+	}
+	got := MakeNotificationChannel(newSource(), "topic")
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+	if got, want := ChannelTopic(got), "projects/project/topics/topic"; got != want {
+		t.Errorf("ChannelTopic got=%s, want=%s", got, want)
+	}
+	if got, want := ChannelFilter(got), `type = "pubsub" AND labels.topic = "projects/project/topics/topic"`; got != want {
+		t.Errorf("ChannelFilter got=%s, want=%s", got, want)
+	}
+}
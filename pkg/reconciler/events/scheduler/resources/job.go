@@ -0,0 +1,152 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"time"
+
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	"github.com/google/knative-gcp/pkg/reconciler/utils"
+)
+
+// defaultTimeZone is the time zone Cloud Scheduler uses when none is set.
+const defaultTimeZone = "Etc/UTC"
+
+// defaultRetryConfig is the retry config Cloud Scheduler uses when none is set.
+var defaultRetryConfig = &schedulerpb.RetryConfig{
+	MinBackoffDuration: durationpb.New(5 * time.Second),
+	MaxBackoffDuration: durationpb.New(time.Hour),
+}
+
+// MakeJob generates the Cloud Scheduler job that publishes the data of the
+// CloudSchedulerSource to topic on its schedule.
+func MakeJob(scheduler *v1.CloudSchedulerSource, topic, jobName string) *schedulerpb.Job {
+	attributes := make(map[string]string, len(scheduler.Spec.Attributes)+1)
+	for k, v := range scheduler.Spec.Attributes {
+		attributes[k] = v
+	}
+	// Add jobName as customAttribute.
+	attributes[v1.CloudSchedulerSourceJobName] = jobName
+
+	job := &schedulerpb.Job{
+		Name:        jobName,
+		Description: scheduler.Spec.Description,
+		Target: &schedulerpb.Job_PubsubTarget{
+			PubsubTarget: &schedulerpb.PubsubTarget{
+				TopicName:  GeneratePubSubTargetTopic(scheduler, topic),
+				Data:       []byte(scheduler.Spec.Data),
+				Attributes: attributes,
+			},
+		},
+		Schedule: scheduler.Spec.Schedule,
+		TimeZone: scheduler.Spec.TimeZone,
+	}
+	if rc := scheduler.Spec.RetryConfig; rc != nil {
+		job.RetryConfig = &schedulerpb.RetryConfig{
+			RetryCount:         rc.RetryCount,
+			MinBackoffDuration: makeDuration(rc.MinBackoffDuration),
+			MaxBackoffDuration: makeDuration(rc.MaxBackoffDuration),
+		}
+	}
+	return job
+}
+
+// makeDuration converts a validated duration string into a proto duration,
+// or nil if it is not set.
+func makeDuration(d *string) *durationpb.Duration {
+	if d == nil {
+		return nil
+	}
+	pd, _ := time.ParseDuration(*d)
+	return durationpb.New(pd)
+}
+
+// JobDrift returns the fields of the existing job that differ from the
+// desired one, along with the update mask paths to correct them. Both are
+// empty if the existing job matches.
+func JobDrift(existing, desired *schedulerpb.Job) (fields []string, paths []string) {
+	if existing.GetSchedule() != desired.GetSchedule() {
+		fields = append(fields, "schedule")
+		paths = append(paths, "schedule")
+	}
+	if timeZone(existing) != timeZone(desired) {
+		fields = append(fields, "timeZone")
+		paths = append(paths, "time_zone")
+	}
+	if existing.GetDescription() != desired.GetDescription() {
+		fields = append(fields, "description")
+		paths = append(paths, "description")
+	}
+	// Cloud Scheduler fills in a default retry config, so only the settings
+	// explicitly desired are compared. Without a desired retry config, the
+	// update clears the existing one, which resets it to the defaults.
+	if !retryConfigMatches(retryConfig(existing), retryConfig(desired)) {
+		fields = append(fields, "retryConfig")
+		paths = append(paths, "retry_config")
+	}
+	e, d := existing.GetPubsubTarget(), desired.GetPubsubTarget()
+	targetFields := len(fields)
+	if e.GetTopicName() != d.GetTopicName() {
+		fields = append(fields, "topic")
+	}
+	if string(e.GetData()) != string(d.GetData()) {
+		fields = append(fields, "data")
+	}
+	if !utils.EqualStringMaps(e.GetAttributes(), d.GetAttributes()) {
+		fields = append(fields, "attributes")
+	}
+	if len(fields) > targetFields {
+		// The target is updated as a whole.
+		paths = append(paths, "pubsub_target")
+	}
+	return fields, paths
+}
+
+// JobPaused tells whether the job is paused.
+func JobPaused(job *schedulerpb.Job) bool {
+	return job.GetState() == schedulerpb.Job_PAUSED
+}
+
+func timeZone(job *schedulerpb.Job) string {
+	if tz := job.GetTimeZone(); tz != "" {
+		return tz
+	}
+	return defaultTimeZone
+}
+
+func retryConfig(job *schedulerpb.Job) *schedulerpb.RetryConfig {
+	if rc := job.GetRetryConfig(); rc != nil {
+		return rc
+	}
+	return defaultRetryConfig
+}
+
+func retryConfigMatches(existing, desired *schedulerpb.RetryConfig) bool {
+	if existing.GetRetryCount() != desired.GetRetryCount() {
+		return false
+	}
+	if d := desired.GetMinBackoffDuration(); d != nil && existing.GetMinBackoffDuration().AsDuration() != d.AsDuration() {
+		return false
+	}
+	if d := desired.GetMaxBackoffDuration(); d != nil && existing.GetMaxBackoffDuration().AsDuration() != d.AsDuration() {
+		return false
+	}
+	return true
+}
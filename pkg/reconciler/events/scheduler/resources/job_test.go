@@ -0,0 +1,220 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"knative.dev/pkg/ptr"
+
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+)
+
+func TestJobDrift(t *testing.T) {
+	newJob := func(schedule, topic, data string, attributes map[string]string) *schedulerpb.Job {
+		return &schedulerpb.Job{
+			Name: "job",
+			Target: &schedulerpb.Job_PubsubTarget{
+				PubsubTarget: &schedulerpb.PubsubTarget{
+					TopicName:  topic,
+					Data:       []byte(data),
+					Attributes: attributes,
+				},
+			},
+			Schedule: schedule,
+		}
+	}
+	attributes := map[string]string{"jobName": "job"}
+	desired := newJob("* * * * *", "topic", "data", attributes)
+
+	tests := []struct {
+		name       string
+		existing   *schedulerpb.Job
+		wantFields []string
+		wantPaths  []string
+	}{{
+		name:     "in sync",
+		existing: newJob("* * * * *", "topic", "data", map[string]string{"jobName": "job"}),
+	}, {
+		name:       "schedule",
+		existing:   newJob("0 * * * *", "topic", "data", attributes),
+		wantFields: []string{"schedule"},
+		wantPaths:  []string{"schedule"},
+	}, {
+		name:       "data and attributes",
+		existing:   newJob("* * * * *", "topic", "other", map[string]string{"jobName": "other"}),
+		wantFields: []string{"data", "attributes"},
+		wantPaths:  []string{"pubsub_target"},
+	}, {
+		name:       "target changed to HTTP",
+		existing:   &schedulerpb.Job{Name: "job", Schedule: "* * * * *", Target: &schedulerpb.Job_HttpTarget{}},
+		wantFields: []string{"topic", "data", "attributes"},
+		wantPaths:  []string{"pubsub_target"},
+	}, {
+		name: "default time zone",
+		existing: func() *schedulerpb.Job {
+			j := newJob("* * * * *", "topic", "data", attributes)
+			j.TimeZone = "Etc/UTC"
+			return j
+		}(),
+	}, {
+		name: "time zone and description",
+		existing: func() *schedulerpb.Job {
+			j := newJob("* * * * *", "topic", "data", attributes)
+			j.TimeZone = "Europe/Paris"
+			j.Description = "description"
+			return j
+		}(),
+		wantFields: []string{"timeZone", "description"},
+		wantPaths:  []string{"time_zone", "description"},
+	}, {
+		name:       "schedule and topic",
+		existing:   newJob("0 * * * *", "other", "data", attributes),
+		wantFields: []string{"schedule", "topic"},
+		wantPaths:  []string{"schedule", "pubsub_target"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields, paths := JobDrift(test.existing, desired)
+			if diff := cmp.Diff(test.wantFields, fields); diff != "" {
+				t.Errorf("unexpected fields (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff(test.wantPaths, paths); diff != "" {
+				t.Errorf("unexpected paths (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestJobDriftRetryConfig(t *testing.T) {
+	newJob := func(rc *schedulerpb.RetryConfig) *schedulerpb.Job {
+		return &schedulerpb.Job{Name: "job", RetryConfig: rc}
+	}
+	// The default retry config filled in by Cloud Scheduler.
+	defaults := &schedulerpb.RetryConfig{
+		MinBackoffDuration: durationpb.New(5 * time.Second),
+		MaxBackoffDuration: durationpb.New(time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		existing  *schedulerpb.RetryConfig
+		desired   *schedulerpb.RetryConfig
+		wantDrift bool
+	}{{
+		name: "not desired",
+	}, {
+		name: "removed from the spec",
+		existing: &schedulerpb.RetryConfig{
+			RetryCount:         3,
+			MinBackoffDuration: durationpb.New(5 * time.Second),
+			MaxBackoffDuration: durationpb.New(time.Hour),
+		},
+		wantDrift: true,
+	}, {
+		name: "backoff removed from the spec",
+		existing: &schedulerpb.RetryConfig{
+			MinBackoffDuration: durationpb.New(10 * time.Second),
+			MaxBackoffDuration: durationpb.New(time.Hour),
+		},
+		wantDrift: true,
+	}, {
+		name:    "only retry count desired",
+		desired: &schedulerpb.RetryConfig{},
+	}, {
+		name:      "retry count differs",
+		desired:   &schedulerpb.RetryConfig{RetryCount: 3},
+		wantDrift: true,
+	}, {
+		name:    "backoff matches",
+		desired: &schedulerpb.RetryConfig{MinBackoffDuration: durationpb.New(5 * time.Second)},
+	}, {
+		name:      "backoff differs",
+		desired:   &schedulerpb.RetryConfig{MaxBackoffDuration: durationpb.New(time.Minute)},
+		wantDrift: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := defaults
+			if test.existing != nil {
+				existing = test.existing
+			}
+			_, paths := JobDrift(newJob(existing), newJob(test.desired))
+			var wantPaths []string
+			if test.wantDrift {
+				wantPaths = []string{"retry_config"}
+			}
+			if diff := cmp.Diff(wantPaths, paths); diff != "" {
+				t.Errorf("unexpected paths (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMakeJob(t *testing.T) {
+	scheduler := &v1.CloudSchedulerSource{
+		Spec: v1.CloudSchedulerSourceSpec{
+			Schedule: "* * * * *",
+			Data:     "data",
+			TimeZone: "Europe/Paris",
+			RetryConfig: &v1.SchedulerRetryConfig{
+				RetryCount:         3,
+				MinBackoffDuration: ptr.String("10s"),
+			},
+			Attributes:  map[string]string{"team": "billing"},
+			Description: "description",
+		},
+		Status: v1.CloudSchedulerSourceStatus{
+			PubSubStatus: duckv1.PubSubStatus{
+				ProjectID: "project",
+			},
+		},
+	}
+	want := &schedulerpb.Job{
+		Name:        "job",
+		Description: "description",
+		Target: &schedulerpb.Job_PubsubTarget{
+			PubsubTarget: &schedulerpb.PubsubTarget{
+				TopicName: "projects/project/topics/topic",
+				Data:      []byte("data"),
+				Attributes: map[string]string{
+					"team":                         "billing",
+					v1.CloudSchedulerSourceJobName: "job",
+				},
+			},
+		},
+		Schedule: "* * * * *",
+		TimeZone: "Europe/Paris",
+		RetryConfig: &schedulerpb.RetryConfig{
+			RetryCount:         3,
+			MinBackoffDuration: durationpb.New(10 * time.Second),
+		},
+	}
+
+	got := MakeJob(scheduler, "topic", "job")
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
@@ -18,9 +18,11 @@ package scheduler
 
 import (
 	"context"
+	"strings"
 
 	"go.uber.org/zap"
 	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +45,8 @@ const (
 	deleteJobFailed              = "JobDeleteFailed"
 	deletePubSubFailed           = "PubSubDeleteFailed"
 	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
+	jobDriftCorrectedReason      = "JobDriftCorrected"
+	jobDriftDetectedReason       = "JobDriftDetected"
 	reconciledPubSubFailedReason = "PubSubReconcileFailed"
 	reconciledFailedReason       = "JobReconcileFailed"
 	reconciledSuccessReason      = "CloudSchedulerSourceReconciled"
@@ -110,8 +114,10 @@ func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1.CloudSchedu
 	}
 	defer client.Close()
 
+	desired := resources.MakeJob(scheduler, topic, jobName)
+
 	// Check if the job exists.
-	_, err = client.GetJob(ctx, &schedulerpb.GetJobRequest{Name: jobName})
+	existing, err := client.GetJob(ctx, &schedulerpb.GetJobRequest{Name: jobName})
 	if err != nil {
 		if st, ok := gstatus.FromError(err); !ok {
 			logging.FromContext(ctx).Desugar().Error("Failed from CloudSchedulerSource client while retrieving CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
@@ -119,36 +125,78 @@ func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1.CloudSchedu
 		} else if st.Code() == codes.NotFound {
 			// Create the job as it does not exist. For creation, we need a parent, extract it from the jobName.
 			parent := resources.ExtractParentName(jobName)
-			// Add jobName as customAttribute.
-			customAttributes := map[string]string{
-				v1.CloudSchedulerSourceJobName: jobName,
-			}
 			_, err = client.CreateJob(ctx, &schedulerpb.CreateJobRequest{
 				Parent: parent,
-				Job: &schedulerpb.Job{
-					Name: jobName,
-					Target: &schedulerpb.Job_PubsubTarget{
-						PubsubTarget: &schedulerpb.PubsubTarget{
-							TopicName:  resources.GeneratePubSubTargetTopic(scheduler, topic),
-							Data:       []byte(scheduler.Spec.Data),
-							Attributes: customAttributes,
-						},
-					},
-					Schedule: scheduler.Spec.Schedule,
-				},
+				Job:    desired,
 			})
 			if err != nil {
 				logging.FromContext(ctx).Desugar().Error("Failed to create CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
 				return err
 			}
+			// Jobs are created enabled.
+			if scheduler.Spec.Paused {
+				if err := setJobPaused(ctx, client, jobName, true); err != nil {
+					return err
+				}
+			}
+			scheduler.Status.MarkJobSynced()
+			return nil
 		} else {
 			logging.FromContext(ctx).Desugar().Error("Failed from CloudSchedulerSource client while retrieving CloudSchedulerSource job", zap.String("jobName", jobName), zap.Any("errorCode", st.Code()), zap.Error(err))
 			return err
 		}
 	}
+
+	// The job exists, update it if either the spec changed or the job was
+	// modified out of band.
+	fields, paths := resources.JobDrift(existing, desired)
+	pausedDrift := resources.JobPaused(existing) != scheduler.Spec.Paused
+	if pausedDrift {
+		fields = append(fields, "paused")
+	}
+	if len(fields) == 0 {
+		scheduler.Status.MarkJobSynced()
+		return nil
+	}
+	drift := strings.Join(fields, ", ")
+	logging.FromContext(ctx).Desugar().Info("CloudSchedulerSource job drifted from the spec", zap.String("jobName", jobName), zap.Strings("fields", fields))
+	scheduler.Status.MarkJobNotSynced(jobDriftDetectedReason, "CloudSchedulerSource job differs from the spec in: %s", drift)
+	if len(paths) > 0 {
+		_, err = client.UpdateJob(ctx, &schedulerpb.UpdateJobRequest{
+			Job:        desired,
+			UpdateMask: &field_mask.FieldMask{Paths: paths},
+		})
+		if err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to update CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
+			return err
+		}
+	}
+	// The state of the job is not part of the update, it is changed by
+	// pausing or resuming the job.
+	if pausedDrift {
+		if err := setJobPaused(ctx, client, jobName, scheduler.Spec.Paused); err != nil {
+			return err
+		}
+	}
+	scheduler.Status.MarkJobDriftCorrected(jobDriftCorrectedReason, "CloudSchedulerSource job was updated to match the spec in: %s", drift)
+	r.Recorder.Eventf(scheduler, corev1.EventTypeNormal, jobDriftCorrectedReason, "CloudSchedulerSource job updated to match the spec in: %s", drift)
 	return nil
 }
 
+// setJobPaused pauses or resumes the job.
+func setJobPaused(ctx context.Context, client gscheduler.Client, jobName string, paused bool) error {
+	var err error
+	if paused {
+		_, err = client.PauseJob(ctx, &schedulerpb.PauseJobRequest{Name: jobName})
+	} else {
+		_, err = client.ResumeJob(ctx, &schedulerpb.ResumeJobRequest{Name: jobName})
+	}
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to change the state of CloudSchedulerSource job", zap.String("jobName", jobName), zap.Bool("paused", paused), zap.Error(err))
+	}
+	return err
+}
+
 // deleteJob looks at the status.JobName and if non-empty,
 // hence indicating that we have created a job successfully
 // in the Scheduler, remove it.
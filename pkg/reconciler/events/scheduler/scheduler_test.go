@@ -51,6 +51,7 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
 
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 )
@@ -133,6 +134,24 @@ func patchFinalizers(namespace, name string, add bool) clientgotesting.PatchActi
 	return action
 }
 
+// newJob returns the Cloud Scheduler job of the test CloudSchedulerSource, with
+// the given data and schedule.
+func newJob(data, schedule string) *schedulerpb.Job {
+	return &schedulerpb.Job{
+		Name: jobName,
+		Target: &schedulerpb.Job_PubsubTarget{
+			PubsubTarget: &schedulerpb.PubsubTarget{
+				TopicName: "projects/" + testProject + "/topics/" + testTopicID,
+				Data:      []byte(data),
+				Attributes: map[string]string{
+					schedulerv1.CloudSchedulerSourceJobName: jobName,
+				},
+			},
+		},
+		Schedule: schedule,
+	}
+}
+
 func newSink() *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -1036,6 +1055,7 @@ func TestAllCases(t *testing.T) {
 					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
 					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
 					reconcilertestingv1.WithCloudSchedulerSourceJobReady(jobName),
+					reconcilertestingv1.WithCloudSchedulerSourceJobSynced,
 					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
 					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
 				),
@@ -1086,6 +1106,228 @@ func TestAllCases(t *testing.T) {
 				),
 				newSink(),
 			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					Job: newJob(testData, onceAMinuteSchedule),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithInitCloudSchedulerSourceConditions,
+					reconcilertestingv1.WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudSchedulerSourceJobReady(jobName),
+					reconcilertestingv1.WithCloudSchedulerSourceJobSynced,
+					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, testNS, schedulerName),
+			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job exists, pause requested",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithCloudSchedulerSourcePaused,
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(schedulerName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(schedulerName, testNS,
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					Job: newJob(testData, onceAMinuteSchedule),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithCloudSchedulerSourcePaused,
+					reconcilertestingv1.WithInitCloudSchedulerSourceConditions,
+					reconcilertestingv1.WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudSchedulerSourceJobReady(jobName),
+					reconcilertestingv1.WithCloudSchedulerSourceJobDriftCorrected(jobDriftCorrectedReason,
+						"CloudSchedulerSource job was updated to match the spec in: paused"),
+					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeNormal, jobDriftCorrectedReason, "CloudSchedulerSource job updated to match the spec in: paused"),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, testNS, schedulerName),
+			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job paused out of band, resume fails",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(schedulerName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(schedulerName, testNS,
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					Job: func() *schedulerpb.Job {
+						j := newJob(testData, onceAMinuteSchedule)
+						j.State = schedulerpb.Job_PAUSED
+						return j
+					}(),
+					ResumeJobErr: errors.New("resume-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithInitCloudSchedulerSourceConditions,
+					reconcilertestingv1.WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudSchedulerSourceJobNotSynced(jobDriftDetectedReason,
+						"CloudSchedulerSource job differs from the spec in: paused"),
+					reconcilertestingv1.WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: resume-job-induced-error", failedToReconcileJobMsg)),
+					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: resume-job-induced-error"),
+			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job drifted, update job succeeds",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(schedulerName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(schedulerName, testNS,
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					Job: newJob("otherdata", "0 * * * *"),
+				},
+			},
 			Key: testNS + "/" + schedulerName,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
@@ -1099,6 +1341,8 @@ func TestAllCases(t *testing.T) {
 					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
 					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
 					reconcilertestingv1.WithCloudSchedulerSourceJobReady(jobName),
+					reconcilertestingv1.WithCloudSchedulerSourceJobDriftCorrected(jobDriftCorrectedReason,
+						"CloudSchedulerSource job was updated to match the spec in: schedule, data"),
 					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
 					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
 				),
@@ -1108,8 +1352,80 @@ func TestAllCases(t *testing.T) {
 			},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeNormal, jobDriftCorrectedReason, "CloudSchedulerSource job updated to match the spec in: schedule, data"),
 				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, testNS, schedulerName),
 			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job drifted, update job fails",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(schedulerName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(schedulerName, testNS,
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					Job:          newJob(testData, "0 * * * *"),
+					UpdateJobErr: errors.New("update-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudSchedulerSource(schedulerName, testNS,
+					reconcilertestingv1.WithCloudSchedulerSourceProject(testProject),
+					reconcilertestingv1.WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudSchedulerSourceLocation(location),
+					reconcilertestingv1.WithCloudSchedulerSourceData(testData),
+					reconcilertestingv1.WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					reconcilertestingv1.WithInitCloudSchedulerSourceConditions,
+					reconcilertestingv1.WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					reconcilertestingv1.WithCloudSchedulerSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudSchedulerSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudSchedulerSourceJobNotSynced(jobDriftDetectedReason,
+						"CloudSchedulerSource job differs from the spec in: schedule"),
+					reconcilertestingv1.WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: update-job-induced-error", failedToReconcileJobMsg)),
+					reconcilertestingv1.WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					reconcilertestingv1.WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: update-job-induced-error"),
+			},
 		}, {
 			Name: "scheduler job fails to delete with no-grpc error",
 			Objects: []runtime.Object{
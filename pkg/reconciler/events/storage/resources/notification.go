@@ -0,0 +1,100 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"cloud.google.com/go/storage"
+
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	"github.com/google/knative-gcp/pkg/reconciler/utils"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+var (
+	// Mapping of the storage source CloudEvent types to google storage types.
+	storageEventTypes = map[string]string{
+		schemasv1.CloudStorageObjectFinalizedEventType:       "OBJECT_FINALIZE",
+		schemasv1.CloudStorageObjectArchivedEventType:        "OBJECT_ARCHIVE",
+		schemasv1.CloudStorageObjectDeletedEventType:         "OBJECT_DELETE",
+		schemasv1.CloudStorageObjectMetadataUpdatedEventType: "OBJECT_METADATA_UPDATE",
+	}
+)
+
+// MakeNotification generates the notification that GCS uses to publish the
+// events of the buckets of the CloudStorageSource to its topic.
+func MakeNotification(s *v1.CloudStorageSource) *storage.Notification {
+	payloadFormat := storage.JSONPayload
+	if s.Spec.PayloadFormat == v1.CloudStorageSourceNoPayload {
+		payloadFormat = storage.NoPayload
+	}
+	return &storage.Notification{
+		TopicProjectID:   s.Status.ProjectID,
+		TopicID:          s.Status.TopicID,
+		PayloadFormat:    payloadFormat,
+		EventTypes:       toStorageEventTypes(s.Spec.EventTypes),
+		ObjectNamePrefix: s.Spec.ObjectNamePrefix,
+		CustomAttributes: s.Spec.Attributes,
+	}
+}
+
+func toStorageEventTypes(eventTypes []string) []string {
+	storageTypes := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		storageTypes = append(storageTypes, storageEventTypes[eventType])
+	}
+	return storageTypes
+}
+
+// NotificationDrift returns the fields of the existing notification that
+// differ from the desired one. It is empty if the existing notification
+// matches.
+func NotificationDrift(existing, desired *storage.Notification) []string {
+	var fields []string
+	if existing.TopicProjectID != desired.TopicProjectID || existing.TopicID != desired.TopicID {
+		fields = append(fields, "topic")
+	}
+	if !equalEventTypes(existing.EventTypes, desired.EventTypes) {
+		fields = append(fields, "eventTypes")
+	}
+	if existing.ObjectNamePrefix != desired.ObjectNamePrefix {
+		fields = append(fields, "objectNamePrefix")
+	}
+	if existing.PayloadFormat != desired.PayloadFormat {
+		fields = append(fields, "payloadFormat")
+	}
+	if !utils.EqualStringMaps(existing.CustomAttributes, desired.CustomAttributes) {
+		fields = append(fields, "attributes")
+	}
+	return fields
+}
+
+// equalEventTypes compares event types regardless of their order.
+func equalEventTypes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[t] = true
+	}
+	for _, t := range b {
+		if !set[t] {
+			return false
+		}
+	}
+	return true
+}
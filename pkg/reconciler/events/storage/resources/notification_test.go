@@ -0,0 +1,132 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+
+	duckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
+	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
+	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
+)
+
+func TestMakeNotification(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1.CloudStorageSourceSpec
+		want *storage.Notification
+	}{{
+		name: "defaults",
+		spec: v1.CloudStorageSourceSpec{
+			Bucket: "bucket",
+		},
+		want: &storage.Notification{
+			TopicProjectID: "project",
+			TopicID:        "topic",
+			PayloadFormat:  storage.JSONPayload,
+			EventTypes:     []string{},
+		},
+	}, {
+		name: "all settings",
+		spec: v1.CloudStorageSourceSpec{
+			Buckets:          []string{"bucket-1", "bucket-2"},
+			EventTypes:       []string{schemasv1.CloudStorageObjectFinalizedEventType, schemasv1.CloudStorageObjectDeletedEventType},
+			ObjectNamePrefix: "prefix",
+			PayloadFormat:    v1.CloudStorageSourceNoPayload,
+			Attributes:       map[string]string{"team": "storage"},
+		},
+		want: &storage.Notification{
+			TopicProjectID:   "project",
+			TopicID:          "topic",
+			PayloadFormat:    storage.NoPayload,
+			EventTypes:       []string{"OBJECT_FINALIZE", "OBJECT_DELETE"},
+			ObjectNamePrefix: "prefix",
+			CustomAttributes: map[string]string{"team": "storage"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &v1.CloudStorageSource{
+				Spec: test.spec,
+				Status: v1.CloudStorageSourceStatus{
+					PubSubStatus: duckv1.PubSubStatus{
+						ProjectID: "project",
+						TopicID:   "topic",
+					},
+				},
+			}
+			if diff := cmp.Diff(test.want, MakeNotification(s)); diff != "" {
+				t.Errorf("unexpected notification (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationDrift(t *testing.T) {
+	desired := &storage.Notification{
+		TopicProjectID:   "project",
+		TopicID:          "topic",
+		PayloadFormat:    storage.JSONPayload,
+		EventTypes:       []string{"OBJECT_FINALIZE", "OBJECT_DELETE"},
+		ObjectNamePrefix: "prefix",
+		CustomAttributes: map[string]string{"team": "storage"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(n *storage.Notification)
+		want   []string
+	}{{
+		name:   "no drift",
+		modify: func(n *storage.Notification) {},
+	}, {
+		name: "event types in another order",
+		modify: func(n *storage.Notification) {
+			n.EventTypes = []string{"OBJECT_DELETE", "OBJECT_FINALIZE"}
+		},
+	}, {
+		name: "topic",
+		modify: func(n *storage.Notification) {
+			n.TopicID = "other-topic"
+		},
+		want: []string{"topic"},
+	}, {
+		name: "all fields",
+		modify: func(n *storage.Notification) {
+			n.TopicProjectID = "other-project"
+			n.EventTypes = nil
+			n.ObjectNamePrefix = ""
+			n.PayloadFormat = storage.NoPayload
+			n.CustomAttributes = nil
+		},
+		want: []string{"topic", "eventTypes", "objectNamePrefix", "payloadFormat", "attributes"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := *desired
+			test.modify(&existing)
+			if diff := cmp.Diff(test.want, NotificationDrift(&existing, desired)); diff != "" {
+				t.Errorf("unexpected drift (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -36,30 +38,21 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/identity"
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
-	schemasv1 "github.com/google/knative-gcp/pkg/schemas/v1"
 	"github.com/google/knative-gcp/pkg/utils"
 )
 
 const (
 	resourceGroup = "cloudstoragesources.events.cloud.google.com"
 
-	deleteNotificationFailed     = "NotificationDeleteFailed"
-	deletePubSubFailed           = "PubSubDeleteFailed"
-	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
-	reconciledNotificationFailed = "NotificationReconcileFailed"
-	reconciledPubSubFailed       = "PubSubReconcileFailed"
-	reconciledSuccessReason      = "CloudStorageSourceReconciled"
-	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
-)
-
-var (
-	// Mapping of the storage source CloudEvent types to google storage types.
-	storageEventTypes = map[string]string{
-		schemasv1.CloudStorageObjectFinalizedEventType:       "OBJECT_FINALIZE",
-		schemasv1.CloudStorageObjectArchivedEventType:        "OBJECT_ARCHIVE",
-		schemasv1.CloudStorageObjectDeletedEventType:         "OBJECT_DELETE",
-		schemasv1.CloudStorageObjectMetadataUpdatedEventType: "OBJECT_METADATA_UPDATE",
-	}
+	deleteNotificationFailed         = "NotificationDeleteFailed"
+	deletePubSubFailed               = "PubSubDeleteFailed"
+	deleteWorkloadIdentityFailed     = "WorkloadIdentityDeleteFailed"
+	notificationDriftCorrectedReason = "NotificationDriftCorrected"
+	notificationDriftDetectedReason  = "NotificationDriftDetected"
+	reconciledNotificationFailed     = "NotificationReconcileFailed"
+	reconciledPubSubFailed           = "PubSubReconcileFailed"
+	reconciledSuccessReason          = "CloudStorageSourceReconciled"
+	workloadIdentityFailed           = "WorkloadIdentityReconcileFailed"
 )
 
 // Reconciler is the controller implementation for Google Cloud Storage (GCS) event
@@ -98,22 +91,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, storage *v1.CloudStorage
 		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailed, "Failed to reconcile CloudStorageSource PubSub: %s", err.Error())
 	}
 
-	notification, err := r.reconcileNotification(ctx, storage)
-	if err != nil {
+	if err := r.reconcileNotifications(ctx, storage); err != nil {
 		storage.Status.MarkNotificationNotReady(reconciledNotificationFailed, "Failed to reconcile CloudStorageSource notification: %s", err.Error())
 		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledNotificationFailed, "Failed to reconcile CloudStorageSource notification: %s", err.Error())
 	}
-	storage.Status.MarkNotificationReady(notification)
+	storage.Status.MarkNotificationsReady()
 
 	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, storage.Namespace, storage.Name)
 }
 
-func (r *Reconciler) reconcileNotification(ctx context.Context, storage *v1.CloudStorageSource) (string, error) {
+func (r *Reconciler) reconcileNotifications(ctx context.Context, storage *v1.CloudStorageSource) error {
 	if storage.Status.ProjectID == "" {
 		projectID, err := utils.ProjectIDOrDefault(storage.Spec.Project)
 		if err != nil {
 			logging.FromContext(ctx).Desugar().Error("Failed to find project id", zap.Error(err))
-			return "", err
+			return err
 		}
 		// Set the projectID in the status.
 		storage.Status.ProjectID = projectID
@@ -122,64 +114,93 @@ func (r *Reconciler) reconcileNotification(ctx context.Context, storage *v1.Clou
 	client, err := r.createClientFn(ctx)
 	if err != nil {
 		logging.FromContext(ctx).Desugar().Error("Failed to create CloudStorageSource client", zap.Error(err))
-		return "", err
+		return err
 	}
 	defer client.Close()
 
-	// Load the Bucket.
-	bucket := client.Bucket(storage.Spec.Bucket)
+	var drifts []string
+	for _, bucketName := range storage.Spec.BucketNames() {
+		notificationID, fields, err := r.reconcileNotification(ctx, storage, client.Bucket(bucketName), bucketName)
+		if err != nil {
+			return err
+		}
+		storage.SetBucketNotificationID(bucketName, notificationID)
+		if len(fields) > 0 {
+			drifts = append(drifts, fmt.Sprintf("%s (%s)", bucketName, strings.Join(fields, ", ")))
+		}
+	}
+	if len(drifts) == 0 {
+		storage.Status.MarkNotificationSynced()
+		return nil
+	}
+	drift := strings.Join(drifts, ", ")
+	storage.Status.MarkNotificationDriftCorrected(notificationDriftCorrectedReason, "CloudStorageSource notifications were recreated to match the spec for: %s", drift)
+	r.Recorder.Eventf(storage, corev1.EventTypeNormal, notificationDriftCorrectedReason, "CloudStorageSource notifications recreated to match the spec for: %s", drift)
+	return nil
+}
+
+// reconcileNotification ensures that the notification of the bucket exists
+// and matches the spec. It returns the ID of the notification, along with the
+// fields that had drifted if it had to be recreated.
+func (r *Reconciler) reconcileNotification(ctx context.Context, storage *v1.CloudStorageSource, bucket gstorage.Bucket, bucketName string) (string, []string, error) {
 	//Check whether Bucket exists or not
 	if _, err := bucket.Attrs(ctx); err != nil {
 		if err == ErrBucketNotExist {
-			logging.FromContext(ctx).Desugar().Error("Bucket doesn't exist", zap.String("bucketName", storage.Spec.Bucket), zap.Error(err))
-			return "", err
+			logging.FromContext(ctx).Desugar().Error("Bucket doesn't exist", zap.String("bucketName", bucketName), zap.Error(err))
+			return "", nil, err
 		}
-		logging.FromContext(ctx).Desugar().Error("Failed to fetch attrs of bucket", zap.String("bucketName", storage.Spec.Bucket), zap.Error(err))
-		return "", err
+		logging.FromContext(ctx).Desugar().Error("Failed to fetch attrs of bucket", zap.String("bucketName", bucketName), zap.Error(err))
+		return "", nil, err
 	}
 
 	notifications, err := bucket.Notifications(ctx)
 	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to fetch existing notifications", zap.Error(err))
-		return "", err
+		logging.FromContext(ctx).Desugar().Error("Failed to fetch existing notifications", zap.String("bucketName", bucketName), zap.Error(err))
+		return "", nil, err
 	}
 
-	// If the notification does exist, then return its ID.
-	if existing, ok := notifications[storage.Status.NotificationID]; ok {
-		return existing.ID, nil
+	desired := resources.MakeNotification(storage)
+	notificationID := storage.BucketNotificationID(bucketName)
+	var fields []string
+	if existing, ok := notifications[notificationID]; ok {
+		// If the notification does exist and matches the spec, then return its ID.
+		if fields = resources.NotificationDrift(existing, desired); len(fields) == 0 {
+			return existing.ID, nil, nil
+		}
+		// Notifications cannot be updated, so the drifted one is replaced.
+		logging.FromContext(ctx).Desugar().Info("CloudStorageSource notification drifted from the spec", zap.String("bucketName", bucketName), zap.String("notificationId", notificationID), zap.Strings("fields", fields))
+		storage.Status.MarkNotificationNotSynced(notificationDriftDetectedReason, "CloudStorageSource notification of bucket %s differs from the spec in: %s", bucketName, strings.Join(fields, ", "))
+		if err := bucket.DeleteNotification(ctx, notificationID); err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to delete drifted CloudStorageSource notification", zap.String("bucketName", bucketName), zap.String("notificationId", notificationID), zap.Error(err))
+			return "", nil, err
+		}
+	} else if notificationID != "" {
+		// The notification was created, and then deleted out of band.
+		fields = []string{"deleted"}
+		logging.FromContext(ctx).Desugar().Info("CloudStorageSource notification was deleted", zap.String("bucketName", bucketName), zap.String("notificationId", notificationID))
+		storage.Status.MarkNotificationNotSynced(notificationDriftDetectedReason, "CloudStorageSource notification of bucket %s was deleted", bucketName)
 	}
 
 	// If the notification does not exist, then create it.
-
-	nc := &Notification{
-		TopicProjectID:   storage.Status.ProjectID,
-		TopicID:          storage.Status.TopicID,
-		PayloadFormat:    JSONPayload,
-		EventTypes:       r.toCloudStorageSourceEventTypes(storage.Spec.EventTypes),
-		ObjectNamePrefix: storage.Spec.ObjectNamePrefix,
-	}
-
-	notification, err := bucket.AddNotification(ctx, nc)
+	notification, err := bucket.AddNotification(ctx, desired)
 	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to create CloudStorageSource notification", zap.Error(err))
-		return "", err
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudStorageSource notification", zap.String("bucketName", bucketName), zap.Error(err))
+		return "", nil, err
 	}
-	return notification.ID, nil
+	return notification.ID, fields, nil
 }
 
-func (r *Reconciler) toCloudStorageSourceEventTypes(eventTypes []string) []string {
-	storageTypes := make([]string, 0, len(eventTypes))
-	for _, eventType := range eventTypes {
-		storageTypes = append(storageTypes, storageEventTypes[eventType])
+// deleteNotifications removes the notifications recorded in the status,
+// hence indicating that we have created them successfully in the buckets of
+// the CloudStorageSource.
+func (r *Reconciler) deleteNotifications(ctx context.Context, storage *v1.CloudStorageSource) error {
+	var bucketNames []string
+	for _, bucketName := range storage.Spec.BucketNames() {
+		if storage.BucketNotificationID(bucketName) != "" {
+			bucketNames = append(bucketNames, bucketName)
+		}
 	}
-	return storageTypes
-}
-
-// deleteNotification looks at the status.NotificationID and if non-empty,
-// hence indicating that we have created a notification successfully
-// in the CloudStorageSource, remove it.
-func (r *Reconciler) deleteNotification(ctx context.Context, storage *v1.CloudStorageSource) error {
-	if storage.Status.NotificationID == "" {
+	if len(bucketNames) == 0 {
 		return nil
 	}
 
@@ -193,24 +214,30 @@ func (r *Reconciler) deleteNotification(ctx context.Context, storage *v1.CloudSt
 	}
 	defer client.Close()
 
-	// Load the Bucket.
-	bucket := client.Bucket(storage.Spec.Bucket)
+	for _, bucketName := range bucketNames {
+		if err := r.deleteNotification(ctx, storage, client.Bucket(bucketName), bucketName, storage.BucketNotificationID(bucketName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+func (r *Reconciler) deleteNotification(ctx context.Context, storage *v1.CloudStorageSource, bucket gstorage.Bucket, bucketName, notificationID string) error {
 	// Check whether bucket exists or not
 	if _, err := bucket.Attrs(ctx); err != nil {
 		// If the bucket was already deleted, then we should  proceed.
 		if err == ErrBucketNotExist {
-			logging.FromContext(ctx).Desugar().Info("Bucket does not exist.", zap.String("bucketName", storage.Spec.Bucket), zap.Error(err))
+			logging.FromContext(ctx).Desugar().Info("Bucket does not exist.", zap.String("bucketName", bucketName), zap.Error(err))
 			return nil
 		}
-		logging.FromContext(ctx).Desugar().Error("Failed to fetch attrs of bucket", zap.String("bucketName", storage.Spec.Bucket), zap.Error(err))
+		logging.FromContext(ctx).Desugar().Error("Failed to fetch attrs of bucket", zap.String("bucketName", bucketName), zap.Error(err))
 		storage.Status.MarkNotificationUnknown(deleteNotificationFailed, "Failed to fetch attrs of bucket: %s", err.Error())
 		return err
 	}
 
 	notifications, err := bucket.Notifications(ctx)
 	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to fetch existing notifications", zap.Error(err))
+		logging.FromContext(ctx).Desugar().Error("Failed to fetch existing notifications", zap.String("bucketName", bucketName), zap.Error(err))
 		storage.Status.MarkNotificationUnknown(deleteNotificationFailed, "Failed to fetch existing notifications: %s", err.Error())
 		return err
 	}
@@ -218,19 +245,19 @@ func (r *Reconciler) deleteNotification(ctx context.Context, storage *v1.CloudSt
 	// This is bit wonky because, we could always just try to delete, but figuring out
 	// if an error returned is NotFound seems to not really work, so, we'll try
 	// checking first the list and only then deleting.
-	if existing, ok := notifications[storage.Status.NotificationID]; ok {
+	if existing, ok := notifications[notificationID]; ok {
 		logging.FromContext(ctx).Desugar().Debug("Found existing notification", zap.Any("notification", existing))
-		err = bucket.DeleteNotification(ctx, storage.Status.NotificationID)
+		err = bucket.DeleteNotification(ctx, notificationID)
 		if err == nil {
-			logging.FromContext(ctx).Desugar().Debug("Deleted Notification", zap.String("notificationId", storage.Status.NotificationID))
+			logging.FromContext(ctx).Desugar().Debug("Deleted Notification", zap.String("notificationId", notificationID))
 			return nil
 		}
 		if st, ok := gstatus.FromError(err); !ok {
-			logging.FromContext(ctx).Desugar().Error("Failed from CloudStorageSource client while deleting CloudStorageSource notification", zap.String("notificationId", storage.Status.NotificationID), zap.Error(err))
+			logging.FromContext(ctx).Desugar().Error("Failed from CloudStorageSource client while deleting CloudStorageSource notification", zap.String("notificationId", notificationID), zap.Error(err))
 			storage.Status.MarkNotificationUnknown(deleteNotificationFailed, "Failed from CloudStorageSource client while deleting CloudStorageSource notification: %s", err.Error())
 			return err
 		} else if st.Code() != codes.NotFound {
-			logging.FromContext(ctx).Desugar().Error("Failed to delete CloudStorageSource notification", zap.String("notificationId", storage.Status.NotificationID), zap.Error(err))
+			logging.FromContext(ctx).Desugar().Error("Failed to delete CloudStorageSource notification", zap.String("notificationId", notificationID), zap.Error(err))
 			storage.Status.MarkNotificationUnknown(deleteNotificationFailed, "Failed to delete CloudStorageSource notification: %s", err.Error())
 			return err
 		}
@@ -249,7 +276,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, storage *v1.CloudStorageS
 	}
 
 	logging.FromContext(ctx).Desugar().Debug("Deleting CloudStorageSource notification")
-	if err := r.deleteNotification(ctx, storage); err != nil {
+	if err := r.deleteNotifications(ctx, storage); err != nil {
 		return reconciler.NewEvent(corev1.EventTypeWarning, deleteNotificationFailed, "Failed to delete CloudStorageSource notification: %s", err.Error())
 	}
 
@@ -57,16 +57,18 @@ import (
 )
 
 const (
-	storageName    = "my-test-storage"
-	storageUID     = "test-storage-uid"
-	bucket         = "my-test-bucket"
-	sinkName       = "sink"
-	notificationId = "135"
-	testNS         = "testnamespace"
-	testImage      = "notification-ops-image"
-	testProject    = "test-project-id"
-	testTopicURI   = "http://" + storageName + "-topic." + testNS + ".svc.cluster.local"
-	generation     = 1
+	storageName             = "my-test-storage"
+	storageUID              = "test-storage-uid"
+	bucket                  = "my-test-bucket"
+	otherBucket             = "my-other-test-bucket"
+	sinkName                = "sink"
+	notificationId          = "135"
+	recreatedNotificationId = "246"
+	testNS                  = "testnamespace"
+	testImage               = "notification-ops-image"
+	testProject             = "test-project-id"
+	testTopicURI            = "http://" + storageName + "-topic." + testNS + ".svc.cluster.local"
+	generation              = 1
 
 	// Message for when the topic and pullsubscription with the above variables are not ready.
 	failedToReconcileTopicMsg                  = `Topic has not yet been reconciled`
@@ -1058,6 +1060,338 @@ func TestAllCases(t *testing.T) {
 					reconcilertestingv1.WithCloudStorageSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
 					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
 					reconcilertestingv1.WithCloudStorageSourceNotificationReady(notificationId),
+					reconcilertestingv1.WithCloudStorageSourceNotificationSynced,
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		},
+		{
+			Name: "successfully created notifications of multiple buckets",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBuckets(bucket, otherBucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(storageName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(storageName, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						AddNotificationID: notificationId,
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, testNS, storageName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceStatusObservedGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBuckets(bucket, otherBucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithInitCloudStorageSourceConditions,
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceTopicReady(testTopicID),
+					reconcilertestingv1.WithCloudStorageSourceProjectID(testProject),
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudStorageSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
+					reconcilertestingv1.WithCloudStorageSourceBucketNotificationsReady(
+						storagev1.BucketNotification{Bucket: bucket, NotificationID: notificationId},
+						storagev1.BucketNotification{Bucket: otherBucket, NotificationID: notificationId},
+					),
+					reconcilertestingv1.WithCloudStorageSourceNotificationSynced,
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		},
+		{
+			Name: "drifted notification recreated",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceNotificationID(notificationId),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(storageName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(storageName, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: {
+								ID:               notificationId,
+								TopicProjectID:   testProject,
+								TopicID:          testTopicID,
+								PayloadFormat:    storage.JSONPayload,
+								EventTypes:       []string{"OBJECT_DELETE"},
+								ObjectNamePrefix: "logs/",
+							},
+						},
+						AddNotificationID: recreatedNotificationId,
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeNormal, notificationDriftCorrectedReason, "CloudStorageSource notifications recreated to match the spec for: %s (eventTypes, objectNamePrefix)", bucket),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, testNS, storageName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceStatusObservedGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithInitCloudStorageSourceConditions,
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceTopicReady(testTopicID),
+					reconcilertestingv1.WithCloudStorageSourceProjectID(testProject),
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudStorageSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
+					reconcilertestingv1.WithCloudStorageSourceNotificationReady(recreatedNotificationId),
+					reconcilertestingv1.WithCloudStorageSourceNotificationDriftCorrected(notificationDriftCorrectedReason,
+						fmt.Sprintf("CloudStorageSource notifications were recreated to match the spec for: %s (eventTypes, objectNamePrefix)", bucket)),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		},
+		{
+			Name: "deleted notification recreated",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceNotificationID(notificationId),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(storageName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(storageName, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						AddNotificationID: recreatedNotificationId,
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeNormal, notificationDriftCorrectedReason, "CloudStorageSource notifications recreated to match the spec for: %s (deleted)", bucket),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, testNS, storageName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceStatusObservedGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithInitCloudStorageSourceConditions,
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceTopicReady(testTopicID),
+					reconcilertestingv1.WithCloudStorageSourceProjectID(testProject),
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudStorageSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
+					reconcilertestingv1.WithCloudStorageSourceNotificationReady(recreatedNotificationId),
+					reconcilertestingv1.WithCloudStorageSourceNotificationDriftCorrected(notificationDriftCorrectedReason,
+						fmt.Sprintf("CloudStorageSource notifications were recreated to match the spec for: %s (deleted)", bucket)),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		},
+		{
+			Name: "drifted notification delete fails",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceNotificationID(notificationId),
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(storageName, testNS,
+					reconcilertestingv1.WithTopicSpec(inteventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(storageName, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(inteventsv1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: gcpduckv1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: {
+								ID:             notificationId,
+								TopicProjectID: testProject,
+								TopicID:        "other-topic",
+								PayloadFormat:  storage.JSONPayload,
+								EventTypes:     []string{"OBJECT_FINALIZE"},
+							},
+						},
+						DeleteErr: errors.New("delete-notification-induced-error"),
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeWarning, reconciledNotificationFailed, fmt.Sprintf("%s: %s", failedToReconcileNotificationMsg, "delete-notification-induced-error")),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceStatusObservedGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucket(bucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceNotificationID(notificationId),
+					reconcilertestingv1.WithInitCloudStorageSourceConditions,
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceTopicReady(testTopicID),
+					reconcilertestingv1.WithCloudStorageSourceProjectID(testProject),
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionReady,
+					reconcilertestingv1.WithCloudStorageSourceSubscriptionID(reconcilertestingv1.SubscriptionID),
+					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
+					reconcilertestingv1.WithCloudStorageSourceNotificationNotSynced(notificationDriftDetectedReason,
+						fmt.Sprintf("CloudStorageSource notification of bucket %s differs from the spec in: topic", bucket)),
+					reconcilertestingv1.WithCloudStorageSourceNotificationNotReady(reconciledNotificationFailed, fmt.Sprintf("%s: %s", failedToReconcileNotificationMsg, "delete-notification-induced-error")),
 					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
 				),
 			}},
@@ -1305,6 +1639,77 @@ func TestAllCases(t *testing.T) {
 				),
 			}},
 		},
+		{
+			Name: "successfully deleted storage with multiple buckets",
+			Objects: []runtime.Object{
+				reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBuckets(bucket, otherBucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceSinkURI(storageSinkURL),
+					reconcilertestingv1.WithCloudStorageSourceBucketNotificationsReady(
+						storagev1.BucketNotification{Bucket: bucket, NotificationID: notificationId},
+						storagev1.BucketNotification{Bucket: otherBucket, NotificationID: notificationId},
+					),
+					reconcilertestingv1.WithCloudStorageSourceTopicReady(testTopicID),
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionReady,
+					reconcilertestingv1.WithDeletionTimestamp,
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+				reconcilertestingv1.NewTopic(storageName, testNS,
+					reconcilertestingv1.WithTopicReady(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicProjectID(testProject),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(storageName, testNS,
+					reconcilertestingv1.WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: {
+								ID: notificationId,
+							},
+						},
+					},
+				},
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				{ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "reconcilertestingv1", Resource: "topics"}},
+					Name: storageName,
+				},
+				{ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "reconcilertestingv1", Resource: "pullsubscriptions"}},
+					Name: storageName,
+				},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconcilertestingv1.NewCloudStorageSource(storageName, testNS,
+					reconcilertestingv1.WithCloudStorageSourceProject(testProject),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBuckets(bucket, otherBucket),
+					reconcilertestingv1.WithCloudStorageSourceSink(sinkGVK, sinkName),
+					reconcilertestingv1.WithCloudStorageSourceEventTypes([]string{schemasv1.CloudStorageObjectFinalizedEventType}),
+					reconcilertestingv1.WithCloudStorageSourceObjectMetaGeneration(generation),
+					reconcilertestingv1.WithCloudStorageSourceBucketNotificationsReady(
+						storagev1.BucketNotification{Bucket: bucket, NotificationID: notificationId},
+						storagev1.BucketNotification{Bucket: otherBucket, NotificationID: notificationId},
+					),
+					reconcilertestingv1.WithCloudStorageSourceTopicDeleted,
+					reconcilertestingv1.WithCloudStorageSourcePullSubscriptionDeleted,
+					reconcilertestingv1.WithDeletionTimestamp,
+					reconcilertestingv1.WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		},
 	}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
@@ -26,6 +26,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -138,6 +139,18 @@ func (r *Base) ReconcileKind(ctx context.Context, ps *v1.PullSubscription) recon
 		ps.Status.TransformerURI = nil
 	}
 
+	// Reply is optional.
+	if ps.Spec.Reply != nil {
+		replyURI, err := r.resolveDestination(ctx, *ps.Spec.Reply, ps)
+		if err != nil {
+			ps.Status.MarkNoReply("InvalidReply", err.Error())
+		} else {
+			ps.Status.MarkReply(replyURI)
+		}
+	} else {
+		ps.Status.ClearReply()
+	}
+
 	subscriptionID, err := r.reconcileSubscription(ctx, ps)
 	if err != nil {
 		ps.Status.MarkNoSubscription(reconciledPubSubFailedReason, "Failed to reconcile Pub/Sub subscription: %s", err.Error())
@@ -197,8 +210,11 @@ func (r *Base) reconcileSubscription(ctx context.Context, ps *v1.PullSubscriptio
 
 	// subConfig is the wanted config based on settings.
 	subConfig := pubsub.SubscriptionConfig{
-		Topic:               t,
-		RetainAckedMessages: ps.Spec.RetainAckedMessages,
+		Topic:                 t,
+		RetainAckedMessages:   ps.Spec.RetainAckedMessages,
+		EnableMessageOrdering: ps.Spec.EnableMessageOrdering,
+		RetryPolicy:           resources.SubscriptionRetryPolicy(ps.Spec.Delivery),
+		DeadLetterPolicy:      resources.SubscriptionDeadLetterPolicy(ps.Status.ProjectID, ps.Spec.Delivery),
 	}
 
 	if ps.Spec.AckDeadline != nil {
@@ -239,6 +255,24 @@ func (r *Base) reconcileSubscription(ctx context.Context, ps *v1.PullSubscriptio
 				logging.FromContext(ctx).Desugar().Error("Failed to create subscription", zap.Error(err))
 				return "", err
 			}
+		} else if !equality.Semantic.DeepEqual(config.RetryPolicy, subConfig.RetryPolicy) ||
+			!equality.Semantic.DeepEqual(config.DeadLetterPolicy, subConfig.DeadLetterPolicy) {
+			// Update the subscription config in case the retry or dead letter policy changed.
+			// The zero value of a policy removes it from the subscription.
+			updateSubConfig := pubsub.SubscriptionConfigToUpdate{
+				RetryPolicy:      &pubsub.RetryPolicy{},
+				DeadLetterPolicy: &pubsub.DeadLetterPolicy{},
+			}
+			if subConfig.RetryPolicy != nil {
+				updateSubConfig.RetryPolicy = subConfig.RetryPolicy
+			}
+			if subConfig.DeadLetterPolicy != nil {
+				updateSubConfig.DeadLetterPolicy = subConfig.DeadLetterPolicy
+			}
+			if _, err := sub.Update(ctx, updateSubConfig); err != nil {
+				logging.FromContext(ctx).Desugar().Error("Failed to update Pub/Sub subscription config", zap.Error(err))
+				return "", err
+			}
 		}
 	} else {
 		sub, err = client.CreateSubscription(ctx, subID, subConfig)
@@ -316,6 +350,7 @@ func (r *Base) reconcileDataPlaneResources(ctx context.Context, ps *v1.PullSubsc
 		SubscriptionID:   ps.Status.SubscriptionID,
 		SinkURI:          ps.Status.SinkURI,
 		TransformerURI:   ps.Status.TransformerURI,
+		ReplyURI:         ps.Status.ReplyURI,
 		LoggingConfig:    loggingConfig,
 		MetricsConfig:    metricsConfig,
 		TracingConfig:    tracingConfig,
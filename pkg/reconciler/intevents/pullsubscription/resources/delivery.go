@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/knative-gcp/pkg/apis/duck"
+	"github.com/rickb777/date/period"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+const (
+	// maxRetryBackoff is the longest backoff Pub/Sub supports in a retry policy.
+	maxRetryBackoff = 600 * time.Second
+)
+
+// SubscriptionRetryPolicy translates the delivery spec into the retry policy of
+// the Pub/Sub subscription. It returns nil if no backoff delay is configured.
+func SubscriptionRetryPolicy(delivery *eventingduckv1.DeliverySpec) *pubsub.RetryPolicy {
+	if delivery == nil || delivery.BackoffDelay == nil {
+		return nil
+	}
+	p, _ := period.Parse(*delivery.BackoffDelay)
+	minimumBackoff, _ := p.Duration()
+	if minimumBackoff > maxRetryBackoff {
+		minimumBackoff = maxRetryBackoff
+	}
+	// Same translation as the Broker's, a linear policy has a constant
+	// backoff, while an exponential one grows up to the maximum.
+	maximumBackoff := maxRetryBackoff
+	if delivery.BackoffPolicy != nil && *delivery.BackoffPolicy == eventingduckv1.BackoffPolicyLinear {
+		maximumBackoff = minimumBackoff
+	}
+	return &pubsub.RetryPolicy{
+		MinimumBackoff: minimumBackoff,
+		MaximumBackoff: maximumBackoff,
+	}
+}
+
+// SubscriptionDeadLetterPolicy translates the delivery spec into the dead
+// letter policy of the Pub/Sub subscription. It returns nil if no dead letter
+// sink is configured. The number of delivery attempts is the delivery retry,
+// which is validated to be within the bounds supported by Pub/Sub.
+func SubscriptionDeadLetterPolicy(projectID string, delivery *eventingduckv1.DeliverySpec) *pubsub.DeadLetterPolicy {
+	topicID := DeadLetterTopicID(delivery)
+	if topicID == "" {
+		return nil
+	}
+	attempts := duck.MinDeadLetterDeliveryAttempts
+	if delivery.Retry != nil {
+		attempts = int(*delivery.Retry)
+	}
+	return &pubsub.DeadLetterPolicy{
+		MaxDeliveryAttempts: attempts,
+		DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", projectID, topicID),
+	}
+}
+
+// DeadLetterTopicID returns the ID of the dead letter topic from the delivery
+// spec, or an empty string if there is none. The dead letter sink is validated
+// to be in the form pubsub://<topic-id>.
+func DeadLetterTopicID(delivery *eventingduckv1.DeliverySpec) string {
+	if delivery == nil || delivery.DeadLetterSink == nil || delivery.DeadLetterSink.URI == nil {
+		return ""
+	}
+	return delivery.DeadLetterSink.URI.Host
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestSubscriptionRetryPolicy(t *testing.T) {
+	linear := eventingduckv1.BackoffPolicyLinear
+	exponential := eventingduckv1.BackoffPolicyExponential
+	testCases := []struct {
+		name     string
+		delivery *eventingduckv1.DeliverySpec
+		want     *pubsub.RetryPolicy
+	}{{
+		name: "nil delivery",
+	}, {
+		name:     "no backoff delay",
+		delivery: &eventingduckv1.DeliverySpec{Retry: ptr.Int32(3)},
+	}, {
+		name: "linear",
+		delivery: &eventingduckv1.DeliverySpec{
+			BackoffPolicy: &linear,
+			BackoffDelay:  ptr.String("PT10S"),
+		},
+		want: &pubsub.RetryPolicy{
+			MinimumBackoff: 10 * time.Second,
+			MaximumBackoff: 10 * time.Second,
+		},
+	}, {
+		name: "exponential",
+		delivery: &eventingduckv1.DeliverySpec{
+			BackoffPolicy: &exponential,
+			BackoffDelay:  ptr.String("PT10S"),
+		},
+		want: &pubsub.RetryPolicy{
+			MinimumBackoff: 10 * time.Second,
+			MaximumBackoff: 600 * time.Second,
+		},
+	}, {
+		name: "capped",
+		delivery: &eventingduckv1.DeliverySpec{
+			BackoffPolicy: &linear,
+			BackoffDelay:  ptr.String("PT1H"),
+		},
+		want: &pubsub.RetryPolicy{
+			MinimumBackoff: 600 * time.Second,
+			MaximumBackoff: 600 * time.Second,
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubscriptionRetryPolicy(tc.delivery)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected retry policy (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionDeadLetterPolicy(t *testing.T) {
+	dls := &duckv1.Destination{
+		URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+	}
+	testCases := []struct {
+		name     string
+		delivery *eventingduckv1.DeliverySpec
+		want     *pubsub.DeadLetterPolicy
+	}{{
+		name: "nil delivery",
+	}, {
+		name:     "no dead letter sink",
+		delivery: &eventingduckv1.DeliverySpec{Retry: ptr.Int32(3)},
+	}, {
+		name:     "default attempts",
+		delivery: &eventingduckv1.DeliverySpec{DeadLetterSink: dls},
+		want: &pubsub.DeadLetterPolicy{
+			MaxDeliveryAttempts: 5,
+			DeadLetterTopic:     "projects/project-id/topics/dead-letter-topic",
+		},
+	}, {
+		name:     "retry attempts",
+		delivery: &eventingduckv1.DeliverySpec{DeadLetterSink: dls, Retry: ptr.Int32(10)},
+		want: &pubsub.DeadLetterPolicy{
+			MaxDeliveryAttempts: 10,
+			DeadLetterTopic:     "projects/project-id/topics/dead-letter-topic",
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubscriptionDeadLetterPolicy("project-id", tc.delivery)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected dead letter policy (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
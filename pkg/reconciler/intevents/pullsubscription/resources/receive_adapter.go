@@ -18,14 +18,19 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/rickb777/date/period"
 	"go.uber.org/zap"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	"github.com/google/knative-gcp/pkg/apis/intevents"
 	intereventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
@@ -46,6 +51,7 @@ type ReceiveAdapterArgs struct {
 	SubscriptionID   string
 	SinkURI          *apis.URL
 	TransformerURI   *apis.URL
+	ReplyURI         *apis.URL
 	MetricsConfig    string
 	LoggingConfig    string
 	TracingConfig    string
@@ -94,6 +100,18 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 	if !isFromSource && !isFromChannel {
 		adapterType = string(converters.PubSubPull)
 	}
+	// A declared converter takes precedence over the adapter type.
+	var converterConfig string
+	if converter := args.PullSubscription.Spec.Converter; converter != nil {
+		if b, err := json.Marshal(converter); err != nil {
+			logging.FromContext(ctx).Warnw("failed to make converter config",
+				zap.Error(err),
+				zap.Any("converter", converter))
+		} else {
+			adapterType = string(converters.Custom)
+			converterConfig = string(b)
+		}
+	}
 
 	receiveAdapterContainer := corev1.Container{
 		Name:  "receive-adapter",
@@ -159,6 +177,84 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 		}},
 	}
 
+	// Only set the batching variables when batching is enabled, so that the
+	// deployments of non-batching PullSubscriptions don't change.
+	if batching := args.PullSubscription.Spec.Batching; batching != nil {
+		receiveAdapterContainer.Env = append(
+			receiveAdapterContainer.Env,
+			corev1.EnvVar{
+				Name:  "BATCH_MAX_EVENTS",
+				Value: strconv.Itoa(int(batching.MaxEvents)),
+			},
+			corev1.EnvVar{
+				Name:  "BATCH_MAX_DELAY",
+				Value: batching.GetMaxDelay().String(),
+			})
+	}
+
+	if mode := sendMode(args.PullSubscription.Spec.Mode); mode != "" {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, corev1.EnvVar{
+			Name:  "SEND_MODE",
+			Value: string(mode),
+		})
+	}
+
+	if converterConfig != "" {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, corev1.EnvVar{
+			Name:  "CONVERTER_CONFIG",
+			Value: converterConfig,
+		})
+	}
+
+	if args.ReplyURI != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, corev1.EnvVar{
+			Name:  "REPLY_URI",
+			Value: args.ReplyURI.String(),
+		})
+	}
+
+	if delivery := args.PullSubscription.Spec.Delivery; delivery != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, makeDeliveryEnv(delivery)...)
+	}
+
+	if flowControl := args.PullSubscription.Spec.FlowControl; flowControl != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, makeFlowControlEnv(flowControl)...)
+	}
+
+	if filter := args.PullSubscription.Spec.Filter; filter != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, makeFilterEnv(ctx, filter)...)
+	}
+
+	if dedup := args.PullSubscription.Spec.Dedup; dedup != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, makeDedupEnv(dedup)...)
+	}
+
+	if buildFilter := args.PullSubscription.Spec.BuildFilter; buildFilter != nil {
+		if b, err := json.Marshal(buildFilter); err != nil {
+			logging.FromContext(ctx).Warnw("failed to make build filter",
+				zap.Error(err),
+				zap.Any("buildFilter", buildFilter))
+		} else {
+			receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, corev1.EnvVar{
+				Name:  "BUILD_FILTER",
+				Value: string(b),
+			})
+		}
+	}
+
+	if imageFilter := args.PullSubscription.Spec.ImageFilter; imageFilter != nil {
+		if b, err := json.Marshal(imageFilter); err != nil {
+			logging.FromContext(ctx).Warnw("failed to make image filter",
+				zap.Error(err),
+				zap.Any("imageFilter", imageFilter))
+		} else {
+			receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, corev1.EnvVar{
+				Name:  "IMAGE_FILTER",
+				Value: string(b),
+			})
+		}
+	}
+
 	// If there is no secret to embed, return what we have.
 	if args.PullSubscription.Spec.Secret == nil {
 		return &corev1.PodSpec{
@@ -207,6 +303,21 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 	}
 }
 
+// sendMode returns the receive adapter mode for the PullSubscription mode, or
+// an empty string for the default mode.
+func sendMode(mode intereventsv1.ModeType) converters.ModeType {
+	switch mode {
+	case intereventsv1.ModeCloudEventsBinary:
+		return converters.Binary
+	case intereventsv1.ModeCloudEventsStructured:
+		return converters.Structured
+	case intereventsv1.ModePushCompatible:
+		return converters.Push
+	default:
+		return ""
+	}
+}
+
 // MakeReceiveAdapter generates (but does not insert into K8s) the Receive Adapter Deployment for
 // PullSubscriptions.
 func MakeReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) *v1.Deployment {
@@ -236,3 +347,111 @@ func MakeReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) *v1.Deplo
 		},
 	}
 }
+
+// makeDeliveryEnv translates the delivery spec into the environment variables
+// used to configure the receive adapter retries. The backoff delay has already
+// been validated as an ISO 8601 duration, and is passed as a Go duration.
+// With a dead letter sink, the retries are the Pub/Sub delivery attempts of
+// the subscription, so the receive adapter does not retry itself.
+func makeDeliveryEnv(delivery *eventingduckv1.DeliverySpec) []corev1.EnvVar {
+	if topicID := DeadLetterTopicID(delivery); topicID != "" {
+		return []corev1.EnvVar{{
+			Name:  "DEAD_LETTER_TOPIC_ID",
+			Value: topicID,
+		}}
+	}
+	var env []corev1.EnvVar
+	if delivery.Retry != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "DELIVERY_RETRY",
+			Value: strconv.Itoa(int(*delivery.Retry)),
+		})
+	}
+	if delivery.BackoffPolicy != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "DELIVERY_BACKOFF_POLICY",
+			Value: string(*delivery.BackoffPolicy),
+		})
+	}
+	if delivery.BackoffDelay != nil {
+		p, _ := period.Parse(*delivery.BackoffDelay)
+		d, _ := p.Duration()
+		env = append(env, corev1.EnvVar{
+			Name:  "DELIVERY_BACKOFF_DELAY",
+			Value: d.String(),
+		})
+	}
+	return env
+}
+
+// makeFlowControlEnv returns the environment variables of the receive adapter
+// for the flow control settings that are set.
+func makeFlowControlEnv(flowControl *gcpduckv1.FlowControlSpec) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if flowControl.MaxOutstandingMessages != 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "MAX_OUTSTANDING_MESSAGES",
+			Value: strconv.Itoa(int(flowControl.MaxOutstandingMessages)),
+		})
+	}
+	if flowControl.MaxOutstandingBytes != 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "MAX_OUTSTANDING_BYTES",
+			Value: strconv.FormatInt(flowControl.MaxOutstandingBytes, 10),
+		})
+	}
+	if flowControl.NumGoroutines != 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "NUM_GOROUTINES",
+			Value: strconv.Itoa(int(flowControl.NumGoroutines)),
+		})
+	}
+	if flowControl.MaxExtension != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "MAX_EXTENSION",
+			Value: *flowControl.MaxExtension,
+		})
+	}
+	return env
+}
+
+// makeDedupEnv returns the environment variables of the receive adapter for
+// the deduplication of the delivered messages.
+func makeDedupEnv(dedup *gcpduckv1.DedupSpec) []corev1.EnvVar {
+	env := []corev1.EnvVar{{
+		Name:  "DEDUP_CACHE_SIZE",
+		Value: strconv.Itoa(int(dedup.CacheSize)),
+	}}
+	if dedup.TTL != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "DEDUP_TTL",
+			Value: *dedup.TTL,
+		})
+	}
+	return env
+}
+
+// makeFilterEnv returns the environment variables of the receive adapter for
+// the event filter.
+func makeFilterEnv(ctx context.Context, filter *gcpduckv1.EventFilterSpec) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if len(filter.Attributes) > 0 {
+		if attributes, err := utils.MapToBase64(filter.Attributes); err != nil {
+			logging.FromContext(ctx).Warnw("failed to make filter attributes",
+				zap.Error(err),
+				zap.Any("attributes", filter.Attributes))
+		} else {
+			env = append(env, corev1.EnvVar{
+				Name:  "FILTER_ATTRIBUTES",
+				Value: attributes,
+			})
+		}
+	}
+	if filter.SampleRate != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "SAMPLE_RATE",
+			Value: strconv.FormatFloat(filter.GetSampleRate(), 'f', -1, 64),
+		})
+	}
+	return env
+}
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -32,8 +33,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestMakeMinimumReceiveAdapter(t *testing.T) {
@@ -514,3 +517,460 @@ func TestMakeReceiveAdapterWithServiceAccount(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterWithBatching(t *testing.T) {
+	maxDelay := "500ms"
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic: "topic",
+			Batching: &intereventsv1.BatchingSpec{
+				MaxEvents: 100,
+				MaxDelay:  &maxDelay,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := []corev1.EnvVar{{
+		Name:  "BATCH_MAX_EVENTS",
+		Value: "100",
+	}, {
+		Name:  "BATCH_MAX_DELAY",
+		Value: "500ms",
+	}}
+	if diff := cmp.Diff(want, env[len(env)-2:]); diff != "" {
+		t.Errorf("unexpected batching env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithDelivery(t *testing.T) {
+	policy := eventingduckv1.BackoffPolicyLinear
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry:         ptr.Int32(3),
+					BackoffPolicy: &policy,
+					BackoffDelay:  ptr.String("PT0.5S"),
+				},
+			},
+			Topic: "topic",
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := []corev1.EnvVar{{
+		Name:  "DELIVERY_RETRY",
+		Value: "3",
+	}, {
+		Name:  "DELIVERY_BACKOFF_POLICY",
+		Value: "linear",
+	}, {
+		Name:  "DELIVERY_BACKOFF_DELAY",
+		Value: "500ms",
+	}}
+	if diff := cmp.Diff(want, env[len(env)-3:]); diff != "" {
+		t.Errorf("unexpected delivery env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithDeadLetterSink(t *testing.T) {
+	policy := eventingduckv1.BackoffPolicyLinear
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry:         ptr.Int32(3),
+					BackoffPolicy: &policy,
+					BackoffDelay:  ptr.String("PT0.5S"),
+					DeadLetterSink: &duckv1.Destination{
+						URI: &apis.URL{Scheme: "pubsub", Host: "dead-letter-topic"},
+					},
+				},
+			},
+			Topic: "topic",
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	// The retries are left to Pub/Sub, which forwards the message to the
+	// dead letter topic once they are exhausted.
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if strings.HasPrefix(e.Name, "DELIVERY_") {
+			t.Errorf("unexpected delivery env %q with a dead letter sink", e.Name)
+		}
+	}
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := corev1.EnvVar{
+		Name:  "DEAD_LETTER_TOPIC_ID",
+		Value: "dead-letter-topic",
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected dead letter env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithReply(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic: "topic",
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+		ReplyURI:         apis.HTTP("reply-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := corev1.EnvVar{
+		Name:  "REPLY_URI",
+		Value: "http://reply-uri",
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected reply env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithConverter(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic: "topic",
+			Converter: &intereventsv1.ConverterSpec{
+				Type:       "com.example.{{.Attributes.kind}}",
+				Source:     "source",
+				Attributes: map[string]string{"trace-id": "traceid"},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	for _, e := range env {
+		if e.Name == "ADAPTER_TYPE" && e.Value != "custom" {
+			t.Errorf("unexpected adapter type, want %q got %q", "custom", e.Value)
+		}
+	}
+	want := corev1.EnvVar{
+		Name:  "CONVERTER_CONFIG",
+		Value: `{"type":"com.example.{{.Attributes.kind}}","source":"source","attributes":{"trace-id":"traceid"}}`,
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected converter env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithMode(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic: "topic",
+			Mode:  intereventsv1.ModePushCompatible,
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := corev1.EnvVar{
+		Name:  "SEND_MODE",
+		Value: "push",
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected mode env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithFlowControl(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+				FlowControl: &gcpduckv1.FlowControlSpec{
+					MaxOutstandingMessages: 100,
+					MaxOutstandingBytes:    -1,
+					NumGoroutines:          2,
+					MaxExtension:           ptr.String("10m"),
+				},
+			},
+			Topic: "topic",
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := []corev1.EnvVar{{
+		Name:  "MAX_OUTSTANDING_MESSAGES",
+		Value: "100",
+	}, {
+		Name:  "MAX_OUTSTANDING_BYTES",
+		Value: "-1",
+	}, {
+		Name:  "NUM_GOROUTINES",
+		Value: "2",
+	}, {
+		Name:  "MAX_EXTENSION",
+		Value: "10m",
+	}}
+	if diff := cmp.Diff(want, env[len(env)-len(want):]); diff != "" {
+		t.Errorf("unexpected flow control env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithDedup(t *testing.T) {
+	testCases := map[string]struct {
+		dedup *gcpduckv1.DedupSpec
+		want  []corev1.EnvVar
+	}{
+		"dedup set": {
+			dedup: &gcpduckv1.DedupSpec{
+				CacheSize: 1000,
+				TTL:       ptr.String("10m"),
+			},
+			want: []corev1.EnvVar{{
+				Name:  "DEDUP_CACHE_SIZE",
+				Value: "1000",
+			}, {
+				Name:  "DEDUP_TTL",
+				Value: "10m",
+			}},
+		},
+		"dedup set without ttl": {
+			dedup: &gcpduckv1.DedupSpec{
+				CacheSize: 1000,
+			},
+			want: []corev1.EnvVar{{
+				Name:  "DEDUP_CACHE_SIZE",
+				Value: "1000",
+			}},
+		},
+		"dedup unset": {
+			dedup: nil,
+			want:  nil,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ps := &intereventsv1.PullSubscription{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testname",
+					Namespace: "testnamespace",
+				},
+				Spec: intereventsv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Project: "eventing-name",
+						Dedup:   tc.dedup,
+					},
+					Topic: "topic",
+				},
+			}
+
+			got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+				Image:            "test-image",
+				PullSubscription: ps,
+				SubscriptionID:   "sub-id",
+				SinkURI:          apis.HTTP("sink-uri"),
+			})
+
+			var env []corev1.EnvVar
+			for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+				if strings.HasPrefix(e.Name, "DEDUP_") {
+					env = append(env, e)
+				}
+			}
+			if diff := cmp.Diff(tc.want, env); diff != "" {
+				t.Errorf("unexpected dedup env (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMakeReceiveAdapterWithFilter(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+				Filter: &gcpduckv1.EventFilterSpec{
+					Attributes: map[string]string{"type": "foo"},
+					SampleRate: ptr.String("0.25"),
+				},
+			},
+			Topic: "topic",
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := []corev1.EnvVar{{
+		Name:  "FILTER_ATTRIBUTES",
+		Value: "eyJ0eXBlIjoiZm9vIn0=",
+	}, {
+		Name:  "SAMPLE_RATE",
+		Value: "0.25",
+	}}
+	if diff := cmp.Diff(want, env[len(env)-len(want):]); diff != "" {
+		t.Errorf("unexpected filter env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithBuildFilter(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic:       "topic",
+			AdapterType: "build",
+			BuildFilter: &gcpduckv1.BuildFilterSpec{
+				Statuses: []string{"SUCCESS", "FAILURE"},
+				Tags:     []string{"release"},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := corev1.EnvVar{
+		Name:  "BUILD_FILTER",
+		Value: `{"statuses":["SUCCESS","FAILURE"],"tags":["release"]}`,
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected build filter env (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeReceiveAdapterWithImageFilter(t *testing.T) {
+	ps := &intereventsv1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testname",
+			Namespace: "testnamespace",
+		},
+		Spec: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: gcpduckv1.PubSubSpec{
+				Project: "eventing-name",
+			},
+			Topic:       "gcr",
+			AdapterType: "artifactregistry",
+			ImageFilter: &gcpduckv1.ImageFilterSpec{
+				Repositories: []string{"gcr.io/my-project"},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+		Image:            "test-image",
+		PullSubscription: ps,
+		SubscriptionID:   "sub-id",
+		SinkURI:          apis.HTTP("sink-uri"),
+	})
+
+	env := got.Spec.Template.Spec.Containers[0].Env
+	want := corev1.EnvVar{
+		Name:  "IMAGE_FILTER",
+		Value: `{"repositories":["gcr.io/my-project"]}`,
+	}
+	if diff := cmp.Diff(want, env[len(env)-1]); diff != "" {
+		t.Errorf("unexpected image filter env (-want, +got) = %v", diff)
+	}
+}
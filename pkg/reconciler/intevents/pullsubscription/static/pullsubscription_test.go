@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/pubsub/pstest"
@@ -39,8 +40,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 
 	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
@@ -72,9 +75,10 @@ const (
 
 	sourceUID = sourceName + "-abc-123"
 
-	testProject = "test-project-id"
-	testTopicID = sourceUID + "-TOPIC"
-	generation  = 1
+	testProject           = "test-project-id"
+	testTopicID           = sourceUID + "-TOPIC"
+	testDeadLetterTopicID = sourceUID + "-DEAD-LETTER"
+	generation            = 1
 
 	secretName = "testing-secret"
 
@@ -97,6 +101,18 @@ var (
 
 	testSubscriptionID = fmt.Sprintf("cre-ps_%s_%s_%s", testNS, sourceName, sourceUID)
 
+	testBackoffPolicy = eventingduckv1.BackoffPolicyLinear
+	testDelivery      = &eventingduckv1.DeliverySpec{
+		Retry:         ptr.Int32(10),
+		BackoffPolicy: &testBackoffPolicy,
+		BackoffDelay:  ptr.String("PT1S"),
+		DeadLetterSink: &duckv1.Destination{
+			URI: &apis.URL{Scheme: "pubsub", Host: testDeadLetterTopicID},
+		},
+	}
+
+	replyURI = apis.HTTP("reply.mynamespace.svc.cluster.local")
+
 	transformerGVK = metav1.GroupVersionKind{
 		Group:   "testing.cloud.google.com",
 		Version: "v1",
@@ -570,6 +586,367 @@ func TestAllCases(t *testing.T) {
 		PostConditions: []func(*testing.T, *TableRow){
 			OnlySubscriptions(testSubscriptionID),
 		},
+	}, {
+		Name: "successfully created subscription with message ordering",
+		Objects: []runtime.Object{
+			reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:                 testTopicID,
+					EnableMessageOrdering: true,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				Topic(testTopicID),
+			},
+		},
+		WantCreates: []runtime.Object{
+			newReceiveAdapter(context.Background(), testImage, nil),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:                 testTopicID,
+					EnableMessageOrdering: true,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionProjectID(testProject),
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				reconcilertestingv1.WithPullSubscriptionTransformerURI(nil),
+				// Updates
+				reconcilertestingv1.WithPullSubscriptionStatusObservedGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				reconcilertestingv1.WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			OnlySubscriptions(testSubscriptionID),
+			SubscriptionHasMessageOrdering(testSubscriptionID, true),
+		},
+	}, {
+		Name: "successfully created subscription with reply",
+		Objects: []runtime.Object{
+			reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+						Reply:   &duckv1.Destination{URI: replyURI},
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				Topic(testTopicID),
+			},
+		},
+		WantCreates: []runtime.Object{
+			newReceiveAdapterWithReply(context.Background(), testImage, replyURI),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+						Reply:   &duckv1.Destination{URI: replyURI},
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionProjectID(testProject),
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				reconcilertestingv1.WithPullSubscriptionTransformerURI(nil),
+				reconcilertestingv1.WithPullSubscriptionMarkReply(replyURI),
+				// Updates
+				reconcilertestingv1.WithPullSubscriptionStatusObservedGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				reconcilertestingv1.WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			OnlySubscriptions(testSubscriptionID),
+		},
+	}, {
+		Name: "successfully created subscription with delivery policies",
+		Objects: []runtime.Object{
+			reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:   &secret,
+						Project:  testProject,
+						Delivery: testDelivery,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				Topic(testTopicID),
+				Topic(testDeadLetterTopicID),
+			},
+		},
+		WantCreates: []runtime.Object{
+			newReceiveAdapterWithDelivery(context.Background(), testImage, testDelivery),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:   &secret,
+						Project:  testProject,
+						Delivery: testDelivery,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionProjectID(testProject),
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				reconcilertestingv1.WithPullSubscriptionTransformerURI(nil),
+				// Updates
+				reconcilertestingv1.WithPullSubscriptionStatusObservedGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				reconcilertestingv1.WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			OnlySubscriptions(testSubscriptionID),
+			SubscriptionHasRetryPolicy(testSubscriptionID, &pubsub.RetryPolicy{
+				MinimumBackoff: time.Second,
+				MaximumBackoff: time.Second,
+			}),
+			SubscriptionHasDeadLetterPolicy(testSubscriptionID, &pubsub.DeadLetterPolicy{
+				MaxDeliveryAttempts: 10,
+				DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", testProject, testDeadLetterTopicID),
+			}),
+		},
+	}, {
+		Name: "successfully updated subscription delivery policies",
+		Objects: []runtime.Object{
+			reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:   &secret,
+						Project:  testProject,
+						Delivery: testDelivery,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				TopicAndSub(testTopicID, testSubscriptionID),
+				Topic(testDeadLetterTopicID),
+			},
+		},
+		WantCreates: []runtime.Object{
+			newReceiveAdapterWithDelivery(context.Background(), testImage, testDelivery),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:   &secret,
+						Project:  testProject,
+						Delivery: testDelivery,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionProjectID(testProject),
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				reconcilertestingv1.WithPullSubscriptionTransformerURI(nil),
+				// Updates
+				reconcilertestingv1.WithPullSubscriptionStatusObservedGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				reconcilertestingv1.WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			OnlySubscriptions(testSubscriptionID),
+			SubscriptionHasRetryPolicy(testSubscriptionID, &pubsub.RetryPolicy{
+				MinimumBackoff: time.Second,
+				MaximumBackoff: time.Second,
+			}),
+			SubscriptionHasDeadLetterPolicy(testSubscriptionID, &pubsub.DeadLetterPolicy{
+				MaxDeliveryAttempts: 10,
+				DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", testProject, testDeadLetterTopicID),
+			}),
+		},
+	}, {
+		Name: "successfully removed subscription delivery policies",
+		Objects: []runtime.Object{
+			reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{
+				Topic(testTopicID),
+				Topic(testDeadLetterTopicID),
+				SubscriptionWithConfig(testSubscriptionID, testTopicID, pubsub.SubscriptionConfig{
+					RetryPolicy: &pubsub.RetryPolicy{
+						MinimumBackoff: time.Second,
+						MaximumBackoff: time.Second,
+					},
+					DeadLetterPolicy: &pubsub.DeadLetterPolicy{
+						MaxDeliveryAttempts: 10,
+						DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", testProject, testDeadLetterTopicID),
+					},
+				}),
+			},
+		},
+		WantCreates: []runtime.Object{
+			newReceiveAdapter(context.Background(), testImage, nil),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertestingv1.NewPullSubscription(sourceName, testNS,
+				reconcilertestingv1.WithPullSubscriptionUID(sourceUID),
+				reconcilertestingv1.WithPullSubscriptionObjectMetaGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionSpec(pubsubv1.PullSubscriptionSpec{
+					PubSubSpec: gcpduckv1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic: testTopicID,
+				}),
+				reconcilertestingv1.WithInitPullSubscriptionConditions,
+				reconcilertestingv1.WithPullSubscriptionProjectID(testProject),
+				reconcilertestingv1.WithPullSubscriptionSink(sinkGVK, sinkName),
+				reconcilertestingv1.WithPullSubscriptionMarkSink(sinkURI),
+				reconcilertestingv1.WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				reconcilertestingv1.WithPullSubscriptionTransformerURI(nil),
+				// Updates
+				reconcilertestingv1.WithPullSubscriptionStatusObservedGeneration(generation),
+				reconcilertestingv1.WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				reconcilertestingv1.WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				reconcilertestingv1.WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			OnlySubscriptions(testSubscriptionID),
+			SubscriptionHasRetryPolicy(testSubscriptionID, nil),
+			SubscriptionHasDeadLetterPolicy(testSubscriptionID, nil),
+		},
 	}, {
 		Name: "sink namespace empty, default to the source one",
 		Objects: []runtime.Object{
@@ -1227,3 +1604,30 @@ func patchFinalizers(namespace, name, finalizer string, existingFinalizers ...st
 	action.Patch = []byte(patch)
 	return action
 }
+
+func newReceiveAdapterWithDelivery(ctx context.Context, image string, delivery *eventingduckv1.DeliverySpec) runtime.Object {
+	ps := newPullSubscription()
+	ps.Spec.Delivery = delivery
+	args := &resources.ReceiveAdapterArgs{
+		Image:            image,
+		PullSubscription: ps,
+		Labels:           resources.GetLabels(controllerAgentName, sourceName),
+		SubscriptionID:   testSubscriptionID,
+		SinkURI:          sinkURI,
+	}
+	return resources.MakeReceiveAdapter(ctx, args)
+}
+
+func newReceiveAdapterWithReply(ctx context.Context, image string, reply *apis.URL) runtime.Object {
+	ps := newPullSubscription()
+	ps.Spec.Reply = &duckv1.Destination{URI: reply}
+	args := &resources.ReceiveAdapterArgs{
+		Image:            image,
+		PullSubscription: ps,
+		Labels:           resources.GetLabels(controllerAgentName, sourceName),
+		SubscriptionID:   testSubscriptionID,
+		SinkURI:          sinkURI,
+		ReplyURI:         reply,
+	}
+	return resources.MakeReceiveAdapter(ctx, args)
+}
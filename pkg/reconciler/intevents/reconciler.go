@@ -65,12 +65,20 @@ type PubSubBase struct {
 // Also sets the following fields in the pubsubable.Status upon success
 // TopicID, ProjectID, and SinkURI
 func (psb *PubSubBase) ReconcilePubSub(ctx context.Context, pubsubable duck.PubSubable, topic, resourceGroup string) (*inteventsv1.Topic, *inteventsv1.PullSubscription, error) {
+	return psb.ReconcilePubSubWithAdapterType(ctx, pubsubable, topic, resourceGroup, psb.receiveAdapterType)
+}
+
+// ReconcilePubSubWithAdapterType is like ReconcilePubSub, but the receive
+// adapter converts messages with adapterType rather than with the receive
+// adapter type of the PubSubBase. It is meant for sources whose events depend
+// on their spec.
+func (psb *PubSubBase) ReconcilePubSubWithAdapterType(ctx context.Context, pubsubable duck.PubSubable, topic, resourceGroup, adapterType string) (*inteventsv1.Topic, *inteventsv1.PullSubscription, error) {
 	t, err := psb.reconcileTopic(ctx, pubsubable, topic)
 	if err != nil {
 		return t, nil, err
 	}
 
-	ps, err := psb.ReconcilePullSubscription(ctx, pubsubable, topic, resourceGroup)
+	ps, err := psb.reconcilePullSubscription(ctx, pubsubable, topic, resourceGroup, adapterType)
 	if err != nil {
 		return t, ps, err
 	}
@@ -130,6 +138,10 @@ func (psb *PubSubBase) reconcileTopic(ctx context.Context, pubsubable duck.PubSu
 }
 
 func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable duck.PubSubable, topic, resourceGroup string) (*inteventsv1.PullSubscription, pkgreconciler.Event) {
+	return psb.reconcilePullSubscription(ctx, pubsubable, topic, resourceGroup, psb.receiveAdapterType)
+}
+
+func (psb *PubSubBase) reconcilePullSubscription(ctx context.Context, pubsubable duck.PubSubable, topic, resourceGroup, adapterType string) (*inteventsv1.PullSubscription, pkgreconciler.Event) {
 	if pubsubable == nil {
 		logging.FromContext(ctx).Desugar().Error("Nil pubsubable passed in")
 		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, nilPubsubableReason, "nil pubsubable passed in")
@@ -148,11 +160,18 @@ func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable
 		Spec:        spec,
 		Owner:       pubsubable,
 		Topic:       topic,
-		AdapterType: psb.receiveAdapterType,
+		AdapterType: adapterType,
 		Labels:      resources.GetLabels(psb.receiveAdapterName, name),
 		Annotations: resources.GetAnnotations(annotations, resourceGroup),
 	}
 
+	if bf, ok := pubsubable.(duck.BuildFilterable); ok {
+		args.BuildFilter = bf.BuildFilterSpec()
+	}
+	if imf, ok := pubsubable.(duck.ImageFilterable); ok {
+		args.ImageFilter = imf.ImageFilterSpec()
+	}
+
 	newPS := resources.MakePullSubscription(args)
 
 	pullSubscriptions := psb.pubsubClient.InternalV1().PullSubscriptions(namespace)
@@ -169,7 +188,7 @@ func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable
 			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, pullSubscriptionCreateFailedReason, "Creating PullSubscription failed with: %s", err.Error())
 		}
 		// Check whether the specs differ and update the PS if so.
-	} else if !equality.Semantic.DeepDerivative(newPS.Spec, ps.Spec) {
+	} else if pullSubscriptionSpecChanged(&newPS.Spec, &ps.Spec) {
 		// Don't modify the informers copy.
 		desired := ps.DeepCopy()
 		desired.Spec = newPS.Spec
@@ -191,6 +210,20 @@ func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable
 	return ps, nil
 }
 
+// pullSubscriptionSpecChanged reports whether the existing PullSubscription spec
+// needs to be updated to the desired one. The optional fields are removed by
+// setting them to nil, which DeepDerivative never reports as a difference.
+func pullSubscriptionSpecChanged(desired, existing *inteventsv1.PullSubscriptionSpec) bool {
+	return !equality.Semantic.DeepDerivative(*desired, *existing) ||
+		!equality.Semantic.DeepEqual(desired.Delivery, existing.Delivery) ||
+		!equality.Semantic.DeepEqual(desired.Reply, existing.Reply) ||
+		!equality.Semantic.DeepEqual(desired.FlowControl, existing.FlowControl) ||
+		!equality.Semantic.DeepEqual(desired.Filter, existing.Filter) ||
+		!equality.Semantic.DeepEqual(desired.Dedup, existing.Dedup) ||
+		!equality.Semantic.DeepEqual(desired.BuildFilter, existing.BuildFilter) ||
+		!equality.Semantic.DeepEqual(desired.ImageFilter, existing.ImageFilter)
+}
+
 func propagatePullSubscriptionStatus(ps *inteventsv1.PullSubscription, status *duckv1.PubSubStatus, cs *apis.ConditionSet) error {
 	pc := ps.Status.GetTopLevelCondition()
 	if pc == nil {
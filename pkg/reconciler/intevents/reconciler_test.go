@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgotesting "k8s.io/client-go/testing"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -917,6 +918,136 @@ func TestCreates(t *testing.T) {
 	}
 }
 
+func TestUpdatesRemovedFields(t *testing.T) {
+	retry := int32(3)
+	testCases := []struct {
+		name     string
+		existing intereventsv1.PullSubscriptionSpec
+	}{{
+		name: "delivery is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: v1.PubSubSpec{
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry: &retry,
+				},
+			},
+		},
+	}, {
+		name: "reply is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: v1.PubSubSpec{
+				Reply: &duckv1.Destination{
+					URI: apis.HTTP("reply"),
+				},
+			},
+		},
+	}, {
+		name: "flowControl is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: v1.PubSubSpec{
+				FlowControl: &v1.FlowControlSpec{
+					MaxOutstandingMessages: 10,
+				},
+			},
+		},
+	}, {
+		name: "filter is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: v1.PubSubSpec{
+				Filter: &v1.EventFilterSpec{
+					Attributes: map[string]string{"type": "com.example"},
+				},
+			},
+		},
+	}, {
+		name: "dedup is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			PubSubSpec: v1.PubSubSpec{
+				Dedup: &v1.DedupSpec{
+					CacheSize: 1000,
+				},
+			},
+		},
+	}, {
+		name: "buildFilter is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			BuildFilter: &v1.BuildFilterSpec{
+				Statuses: []string{"FAILURE"},
+			},
+		},
+	}, {
+		name: "imageFilter is removed",
+		existing: intereventsv1.PullSubscriptionSpec{
+			ImageFilter: &v1.ImageFilterSpec{
+				Repositories: []string{"gcr.io/my-project"},
+			},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := tc.existing
+			existing.Topic = testTopicID
+			existing.Secret = &secret
+			existing.Sink = sink
+			cs := fakePubsubClient.NewSimpleClientset(
+				reconcilertestingv1.NewTopic(name, testNS,
+					reconcilertestingv1.WithTopicSpec(intereventsv1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						EnablePublisher:   &falseVal,
+					}),
+					reconcilertestingv1.WithTopicLabels(map[string]string{
+						"receive-adapter":                     receiveAdapterName,
+						"events.cloud.google.com/source-name": name,
+					}),
+					reconcilertestingv1.WithTopicOwnerReferences([]metav1.OwnerReference{ownerRef()}),
+					reconcilertestingv1.WithTopicProjectID(testProjectID),
+					reconcilertestingv1.WithTopicReadyAndPublisherDeployed(testTopicID),
+					reconcilertestingv1.WithTopicAddress(testTopicURI),
+					reconcilertestingv1.WithTopicSetDefaults,
+				),
+				reconcilertestingv1.NewPullSubscription(name, testNS,
+					reconcilertestingv1.WithPullSubscriptionSpec(existing),
+					reconcilertestingv1.WithPullSubscriptionLabels(map[string]string{
+						"receive-adapter":                     receiveAdapterName,
+						"events.cloud.google.com/source-name": name,
+					}),
+					reconcilertestingv1.WithPullSubscriptionAnnotations(map[string]string{
+						"metrics-resource-group": resourceGroup,
+					}),
+					reconcilertestingv1.WithPullSubscriptionOwnerReferences([]metav1.OwnerReference{ownerRef()}),
+					reconcilertestingv1.WithPullSubscriptionReady(sink.URI),
+				),
+			)
+
+			psBase := &PubSubBase{
+				Base:               &reconciler.Base{},
+				pubsubClient:       cs,
+				receiveAdapterName: receiveAdapterName,
+			}
+			psBase.Logger = logtesting.TestLogger(t)
+
+			_, ps, err := psBase.ReconcilePubSub(context.Background(), pubsubable, testTopicID, resourceGroup)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			want := intereventsv1.PullSubscriptionSpec{
+				Topic: testTopicID,
+				PubSubSpec: v1.PubSubSpec{
+					Secret: &secret,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: sink,
+					},
+				},
+			}
+			if diff := cmp.Diff(want, ps.Spec); diff != "" {
+				t.Errorf("Unexpected pullsubscription spec (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func verifyCreateActions(t *testing.T, actual []clientgotesting.CreateAction, expected []runtime.Object) {
 	for i, want := range expected {
 		if i >= len(actual) {
@@ -32,6 +32,8 @@ type PullSubscriptionArgs struct {
 	Owner       kmeta.OwnerRefable
 	Topic       string
 	AdapterType string
+	BuildFilter *gcpduckv1.BuildFilterSpec
+	ImageFilter *gcpduckv1.ImageFilterSpec
 	Labels      map[string]string
 	Annotations map[string]string
 }
@@ -57,9 +59,16 @@ func MakePullSubscription(args *PullSubscriptionArgs) *inteventsv1.PullSubscript
 				SourceSpec: duckv1.SourceSpec{
 					Sink: args.Spec.SourceSpec.Sink,
 				},
+				Delivery:    args.Spec.Delivery,
+				Reply:       args.Spec.Reply,
+				FlowControl: args.Spec.FlowControl,
+				Filter:      args.Spec.Filter,
+				Dedup:       args.Spec.Dedup,
 			},
 			Topic:       args.Topic,
 			AdapterType: args.AdapterType,
+			BuildFilter: args.BuildFilter,
+			ImageFilter: args.ImageFilter,
 		},
 	}
 	if args.Spec.CloudEventOverrides != nil && args.Spec.CloudEventOverrides.Extensions != nil {
@@ -25,7 +25,9 @@ import (
 	inteventsv1 "github.com/google/knative-gcp/pkg/apis/intevents/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestMakePullSubscription(t *testing.T) {
@@ -59,6 +61,9 @@ func TestMakePullSubscription(t *testing.T) {
 						},
 					},
 				},
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry: ptr.Int32(3),
+				},
 			},
 		},
 	}
@@ -121,6 +126,9 @@ func TestMakePullSubscription(t *testing.T) {
 						},
 					},
 				},
+				Delivery: &eventingduckv1.DeliverySpec{
+					Retry: ptr.Int32(3),
+				},
 			},
 			Topic:       "topic-abc",
 			AdapterType: "google.storage",
@@ -157,6 +157,18 @@ func WithBrokerClass(bc string) BrokerOption {
 	}
 }
 
+// WithBrokerProject sets the Broker's project annotation.
+func WithBrokerProject(project string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[brokerv1beta1.ProjectAnnotationKey] = project
+		b.SetAnnotations(annotations)
+	}
+}
+
 func WithBrokerSetDefaults(b *brokerv1beta1.Broker) {
 	b.SetDefaults(context.Background())
 }
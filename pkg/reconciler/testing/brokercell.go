@@ -23,6 +23,7 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // BrokerCellOption enables further configuration of a BrokerCell.
@@ -42,6 +43,13 @@ func NewBrokerCell(name, namespace string, o ...BrokerCellOption) *intv1alpha1.B
 	return bc
 }
 
+// WithBrokerCellUID sets the UID of the BrokerCell.
+func WithBrokerCellUID(uid types.UID) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.UID = uid
+	}
+}
+
 func WithBrokerCellAnnotations(annotations map[string]string) BrokerCellOption {
 	return func(bc *intv1alpha1.BrokerCell) {
 		bc.SetAnnotations(annotations)
@@ -134,6 +142,20 @@ func WithBrokerCellRetryUnknown(reason, msg string) BrokerCellOption {
 	}
 }
 
+// WithBrokerCellFanoutBacklogScaling makes the fanout component of a defaulted
+// BrokerCell scale on its backlog.
+func WithBrokerCellFanoutBacklogScaling(spec *intv1alpha1.BacklogScalingSpec) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Spec.Components.Fanout.BacklogScaling = spec
+	}
+}
+
+func WithBrokerCellBacklogScalingIncomplete(reason, msg string) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Status.MarkBacklogScalingIncomplete(reason, msg)
+	}
+}
+
 func WithTargetsCofigReady() BrokerCellOption {
 	return func(bc *intv1alpha1.BrokerCell) {
 		bc.Status.MarkTargetsConfigReady()
@@ -149,3 +171,16 @@ func WithTargetsCofigFailed(reason, msg string) BrokerCellOption {
 func WithBrokerCellSetDefaults(bc *intv1alpha1.BrokerCell) {
 	bc.SetDefaults(context.Background())
 }
+
+func WithBrokerCellTargetsConfigGeneration(gen int64) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Status.TargetsConfigGeneration = gen
+	}
+}
+
+func WithBrokerCellTargetsCount(brokers, triggers int32) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Status.BrokerCount = brokers
+		bc.Status.TriggerCount = triggers
+	}
+}
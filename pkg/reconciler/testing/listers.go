@@ -21,6 +21,7 @@ import (
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +30,7 @@ import (
 	hpav2beta2listers "k8s.io/client-go/listers/autoscaling/v2beta2"
 	batchv1listers "k8s.io/client-go/listers/batch/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	policyv1beta1listers "k8s.io/client-go/listers/policy/v1beta1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -154,6 +156,18 @@ func (l *Listers) GetCloudBuildSourceLister() eventslisters.CloudBuildSourceList
 	return eventslisters.NewCloudBuildSourceLister(l.indexerFor(&EventsV1.CloudBuildSource{}))
 }
 
+func (l *Listers) GetCloudBillingBudgetSourceLister() eventslisters.CloudBillingBudgetSourceLister {
+	return eventslisters.NewCloudBillingBudgetSourceLister(l.indexerFor(&EventsV1.CloudBillingBudgetSource{}))
+}
+
+func (l *Listers) GetCloudMonitoringAlertSourceLister() eventslisters.CloudMonitoringAlertSourceLister {
+	return eventslisters.NewCloudMonitoringAlertSourceLister(l.indexerFor(&EventsV1.CloudMonitoringAlertSource{}))
+}
+
+func (l *Listers) GetCloudArtifactRegistrySourceLister() eventslisters.CloudArtifactRegistrySourceLister {
+	return eventslisters.NewCloudArtifactRegistrySourceLister(l.indexerFor(&EventsV1.CloudArtifactRegistrySource{}))
+}
+
 func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
@@ -205,3 +219,7 @@ func (l *Listers) GetBrokerCellLister() intlisters.BrokerCellLister {
 func (l *Listers) GetHPALister() hpav2beta2listers.HorizontalPodAutoscalerLister {
 	return hpav2beta2listers.NewHorizontalPodAutoscalerLister(l.indexerFor(&hpav2beta2.HorizontalPodAutoscaler{}))
 }
+
+func (l *Listers) GetPDBLister() policyv1beta1listers.PodDisruptionBudgetLister {
+	return policyv1beta1listers.NewPodDisruptionBudgetLister(l.indexerFor(&policyv1beta1.PodDisruptionBudget{}))
+}
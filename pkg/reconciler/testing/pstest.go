@@ -53,6 +53,19 @@ func SubscriptionWithTopic(id string, tid string) PubsubAction {
 	}
 }
 
+// SubscriptionWithConfig creates a subscription to the given topic with the
+// rest of the given config.
+func SubscriptionWithConfig(id string, tid string, cfg pubsub.SubscriptionConfig) PubsubAction {
+	return func(ctx context.Context, t *testing.T, c *pubsub.Client) {
+		cfg.Topic = c.Topic(tid)
+		_, err := c.CreateSubscription(ctx, id, cfg)
+		if err != nil {
+			t.Fatalf("Error creating subscription %q: %v", id, err)
+		}
+		t.Logf("Created subscription %q", id)
+	}
+}
+
 func TopicAndSub(tid, sid string) PubsubAction {
 	return func(ctx context.Context, t *testing.T, c *pubsub.Client) {
 		Topic(tid)(ctx, t, c)
@@ -72,6 +85,19 @@ func TopicExists(id string) func(*testing.T, *rtesting.TableRow) {
 	}
 }
 
+// TopicExistsInProject checks that the topic exists in the given project.
+func TopicExistsInProject(project, id string) func(*testing.T, *rtesting.TableRow) {
+	return func(t *testing.T, r *rtesting.TableRow) {
+		c := getPubsubClient(r)
+		exist, err := c.TopicInProject(id, project).Exists(context.Background())
+		if err != nil {
+			t.Errorf("Error checking topic existence: %v", err)
+		} else if !exist {
+			t.Errorf("Expected topic %q to exist in project %q", id, project)
+		}
+	}
+}
+
 func TopicExistsWithConfig(id string, expectedTopicConfig *pubsub.TopicConfig) func(*testing.T, *rtesting.TableRow) {
 	return func(t *testing.T, r *rtesting.TableRow) {
 		c := getPubsubClient(r)
@@ -131,6 +157,20 @@ func SubscriptionExists(id string) func(*testing.T, *rtesting.TableRow) {
 	}
 }
 
+// SubscriptionExistsInProject checks that the subscription exists in the given
+// project.
+func SubscriptionExistsInProject(project, id string) func(*testing.T, *rtesting.TableRow) {
+	return func(t *testing.T, r *rtesting.TableRow) {
+		c := getPubsubClient(r)
+		exist, err := c.SubscriptionInProject(id, project).Exists(context.Background())
+		if err != nil {
+			t.Errorf("Error checking subscription existence: %v", err)
+		} else if !exist {
+			t.Errorf("Expected subscription %q to exist in project %q", id, project)
+		}
+	}
+}
+
 func SubscriptionHasRetryPolicy(id string, wantPolicy *pubsub.RetryPolicy) func(*testing.T, *rtesting.TableRow) {
 	return func(t *testing.T, r *rtesting.TableRow) {
 		c := getPubsubClient(r)
@@ -159,6 +199,35 @@ func SubscriptionHasDeadLetterPolicy(id string, wantPolicy *pubsub.DeadLetterPol
 	}
 }
 
+// SubscriptionHasLabels checks that the subscription has exactly the given labels.
+func SubscriptionHasLabels(id string, wantLabels map[string]string) func(*testing.T, *rtesting.TableRow) {
+	return func(t *testing.T, r *rtesting.TableRow) {
+		c := getPubsubClient(r)
+		sub := c.Subscription(id)
+		cfg, err := sub.Config(context.Background())
+		if err != nil {
+			t.Errorf("Error getting pubsub config: %v", err)
+		}
+		if diff := cmp.Diff(wantLabels, cfg.Labels); diff != "" {
+			t.Errorf("Pubsub config labels (-want,+got): %v", diff)
+		}
+	}
+}
+
+func SubscriptionHasMessageOrdering(id string, want bool) func(*testing.T, *rtesting.TableRow) {
+	return func(t *testing.T, r *rtesting.TableRow) {
+		c := getPubsubClient(r)
+		sub := c.Subscription(id)
+		cfg, err := sub.Config(context.Background())
+		if err != nil {
+			t.Errorf("Error getting pubsub config: %v", err)
+		}
+		if cfg.EnableMessageOrdering != want {
+			t.Errorf("Pubsub config message ordering, want %v got %v", want, cfg.EnableMessageOrdering)
+		}
+	}
+}
+
 func OnlySubscriptions(ids ...string) func(*testing.T, *rtesting.TableRow) {
 	return func(t *testing.T, r *rtesting.TableRow) {
 		c := getPubsubClient(r)
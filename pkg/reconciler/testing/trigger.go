@@ -142,6 +142,17 @@ func WithInjectionAnnotation(injectionAnnotation string) TriggerOption {
 	}
 }
 
+// WithTriggerRetryProject records the project of the Trigger's retry topic and
+// subscription.
+func WithTriggerRetryProject(project string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		if t.Annotations == nil {
+			t.Annotations = make(map[string]string)
+		}
+		t.Annotations[brokerv1beta1.RetryProjectAnnotationKey] = project
+	}
+}
+
 func WithDependencyAnnotation(dependencyAnnotation string) TriggerOption {
 	return func(t *brokerv1beta1.Trigger) {
 		if t.Annotations == nil {
@@ -206,6 +206,18 @@ func WithCloudAuditLogsSourceResourceName(resourceName string) CloudAuditLogsSou
 	}
 }
 
+func WithCloudAuditLogsSourceParent(parent string) CloudAuditLogsSourceOption {
+	return func(s *v1.CloudAuditLogsSource) {
+		s.Spec.Parent = parent
+	}
+}
+
+func WithCloudAuditLogsSourceLogFilter(logFilter string) CloudAuditLogsSourceOption {
+	return func(s *v1.CloudAuditLogsSource) {
+		s.Spec.LogFilter = logFilter
+	}
+}
+
 func WithCloudAuditLogsSourceServiceName(serviceName string) CloudAuditLogsSourceOption {
 	return func(s *v1.CloudAuditLogsSource) {
 		s.Spec.ServiceName = serviceName
@@ -28,6 +28,7 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	gcpduckv1 "github.com/google/knative-gcp/pkg/apis/duck/v1"
 	v1 "github.com/google/knative-gcp/pkg/apis/events/v1"
 )
 
@@ -72,6 +73,12 @@ func WithCloudBuildSourceProject(project string) CloudBuildSourceOption {
 	}
 }
 
+func WithCloudBuildSourceBuildFilter(filter *gcpduckv1.BuildFilterSpec) CloudBuildSourceOption {
+	return func(bs *v1.CloudBuildSource) {
+		bs.Spec.BuildFilter = filter
+	}
+}
+
 func WithCloudBuildSourceServiceAccount(kServiceAccount string) CloudBuildSourceOption {
 	return func(bs *v1.CloudBuildSource) {
 		bs.Spec.ServiceAccountName = kServiceAccount
@@ -126,6 +126,12 @@ func WithPullSubscriptionMarkNoTransformer(reason, message string) PullSubscript
 	}
 }
 
+func WithPullSubscriptionMarkReply(uri *apis.URL) PullSubscriptionOption {
+	return func(s *v1.PullSubscription) {
+		s.Status.MarkReply(uri)
+	}
+}
+
 func WithPullSubscriptionMarkSubscribed(subscriptionID string) PullSubscriptionOption {
 	return func(s *v1.PullSubscription) {
 		s.Status.MarkSubscribed(subscriptionID)
@@ -171,6 +171,11 @@ func WithCloudSchedulerSourcePullSubscriptionDeleted(s *v1.CloudSchedulerSource)
 	s.Status.MarkPullSubscriptionReady(s.ConditionSet())
 }
 
+// WithCloudSchedulerSourcePaused sets the CloudSchedulerSource to be paused.
+func WithCloudSchedulerSourcePaused(s *v1.CloudSchedulerSource) {
+	s.Spec.Paused = true
+}
+
 // WithCloudSchedulerSourceJobNotReady marks the condition that the
 // CloudSchedulerSource Job is not ready.
 func WithCloudSchedulerSourceJobNotReady(reason, message string) CloudSchedulerSourceOption {
@@ -195,6 +200,28 @@ func WithCloudSchedulerSourceJobReady(jobName string) CloudSchedulerSourceOption
 	}
 }
 
+// WithCloudSchedulerSourceJobSynced marks the condition that the
+// CloudSchedulerSource Job matches the spec.
+func WithCloudSchedulerSourceJobSynced(s *v1.CloudSchedulerSource) {
+	s.Status.MarkJobSynced()
+}
+
+// WithCloudSchedulerSourceJobNotSynced marks the condition that the
+// CloudSchedulerSource Job differs from the spec.
+func WithCloudSchedulerSourceJobNotSynced(reason, message string) CloudSchedulerSourceOption {
+	return func(s *v1.CloudSchedulerSource) {
+		s.Status.MarkJobNotSynced(reason, message)
+	}
+}
+
+// WithCloudSchedulerSourceJobDriftCorrected marks the condition that the
+// CloudSchedulerSource Job was updated to match the spec.
+func WithCloudSchedulerSourceJobDriftCorrected(reason, message string) CloudSchedulerSourceOption {
+	return func(s *v1.CloudSchedulerSource) {
+		s.Status.MarkJobDriftCorrected(reason, message)
+	}
+}
+
 // WithCloudSchedulerSourceJobDeleted is a wrapper to indicate that the
 // job is deleted. Inside the function, we still mark the status of job to be ready,
 // as the status of job is unchanged if the deletion is successful.
@@ -60,6 +60,12 @@ func WithCloudStorageSourceBucket(bucket string) CloudStorageSourceOption {
 	}
 }
 
+func WithCloudStorageSourceBuckets(buckets ...string) CloudStorageSourceOption {
+	return func(s *v1.CloudStorageSource) {
+		s.Spec.Buckets = buckets
+	}
+}
+
 func WithCloudStorageSourceProject(project string) CloudStorageSourceOption {
 	return func(s *v1.CloudStorageSource) {
 		s.Spec.Project = project
@@ -200,6 +206,37 @@ func WithCloudStorageSourceNotificationReady(notificationID string) CloudStorage
 	}
 }
 
+// WithCloudStorageSourceBucketNotificationsReady marks the condition that the
+// GCS Notifications of all the buckets are ready.
+func WithCloudStorageSourceBucketNotificationsReady(notifications ...v1.BucketNotification) CloudStorageSourceOption {
+	return func(s *v1.CloudStorageSource) {
+		s.Status.Notifications = notifications
+		s.Status.MarkNotificationsReady()
+	}
+}
+
+// WithCloudStorageSourceNotificationSynced marks the condition that the GCS
+// Notifications match the spec.
+func WithCloudStorageSourceNotificationSynced(s *v1.CloudStorageSource) {
+	s.Status.MarkNotificationSynced()
+}
+
+// WithCloudStorageSourceNotificationDriftCorrected marks the condition that
+// the GCS Notifications were recreated to match the spec.
+func WithCloudStorageSourceNotificationDriftCorrected(reason, message string) CloudStorageSourceOption {
+	return func(s *v1.CloudStorageSource) {
+		s.Status.MarkNotificationDriftCorrected(reason, message)
+	}
+}
+
+// WithCloudStorageSourceNotificationNotSynced marks the condition that the
+// GCS Notifications differ from the spec.
+func WithCloudStorageSourceNotificationNotSynced(reason, message string) CloudStorageSourceOption {
+	return func(s *v1.CloudStorageSource) {
+		s.Status.MarkNotificationNotSynced(reason, message)
+	}
+}
+
 // WithCloudStorageSourceNotificationDeleted a wrapper to indicate that the
 // notification is deleted. Inside the function, we still mark the status of
 // notification to be ready, as the status of notification is unchanged if
@@ -41,8 +41,10 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/configs/dataresidency"
 	brokerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker"
 	triggerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/trigger"
+	brokercellinformer "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 	"github.com/google/knative-gcp/pkg/utils"
 )
 
@@ -91,10 +93,19 @@ func newController(ctx context.Context, cmw configmap.Watcher, drs *dataresidenc
 			client.Close()
 		}()
 	}
+	// Clients for other projects are cached across reconciles and closed on
+	// shutdown.
+	clients := reconcilerutilspubsub.NewClientCache(ctx, pubsub.NewClient)
+	go func() {
+		<-ctx.Done()
+		clients.Close()
+	}()
 	r := &Reconciler{
 		Base:               reconciler.NewBase(ctx, controllerAgentName, cmw),
 		brokerLister:       brokerinformer.Get(ctx).Lister(),
+		brokerCellLister:   brokercellinformer.Get(ctx).Lister(),
 		pubsubClient:       client,
+		clients:            clients,
 		projectID:          projectID,
 		dataresidencyStore: drs,
 	}
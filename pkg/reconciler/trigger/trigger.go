@@ -18,6 +18,7 @@ package trigger
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,6 +27,8 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/google/knative-gcp/pkg/logging"
 	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
@@ -33,14 +36,17 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
 
 	"cloud.google.com/go/pubsub"
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	"github.com/google/knative-gcp/pkg/apis/configs/dataresidency"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	brokerlisters "github.com/google/knative-gcp/pkg/client/listers/broker/v1beta1"
+	inteventslisters "github.com/google/knative-gcp/pkg/client/listers/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/reconciler"
 	"github.com/google/knative-gcp/pkg/reconciler/broker/resources"
+	brokercellresources "github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 	"github.com/google/knative-gcp/pkg/utils"
 	"knative.dev/eventing/pkg/apis/eventing/v1beta1"
@@ -68,7 +74,8 @@ var (
 type Reconciler struct {
 	*reconciler.Base
 
-	brokerLister brokerlisters.BrokerLister
+	brokerLister     brokerlisters.BrokerLister
+	brokerCellLister inteventslisters.BrokerCellLister
 
 	// Dynamic tracker to track KResources. It tracks the dependency between Triggers and Sources.
 	kresourceTracker duck.ListableTracker
@@ -82,6 +89,11 @@ type Reconciler struct {
 	// pubsubClient is used as the Pubsub client when present.
 	pubsubClient *pubsub.Client
 
+	// clients caches the Pub/Sub clients for triggers whose broker's topics
+	// and subscriptions live in another project, or when pubsubClient isn't
+	// present.
+	clients *reconcilerutilspubsub.ClientCache
+
 	dataresidencyStore *dataresidency.Store
 }
 
@@ -149,7 +161,10 @@ func (r *Reconciler) reconcile(ctx context.Context, t *brokerv1beta1.Trigger, b
 	if b.Spec.Delivery == nil {
 		b.SetDefaults(ctx)
 	}
-	if err := r.reconcileRetryTopicAndSubscription(ctx, t, b.Spec.Delivery); err != nil {
+	if err := r.recordRetryProject(ctx, t, b.Project()); err != nil {
+		return err
+	}
+	if err := r.reconcileRetryTopicAndSubscription(ctx, t, b.Project(), b.Spec.Delivery); err != nil {
 		return err
 	}
 
@@ -172,7 +187,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, t *brokerv1beta1.Trigger)
 	if !hasGCPBrokerFinalizer(t) {
 		return nil
 	}
-	if err := r.deleteRetryTopicAndSubscription(ctx, t); err != nil {
+	if err := r.deleteRetryTopicAndSubscription(ctx, t, r.retryProject(t)); err != nil {
 		return err
 	}
 	return pkgreconciler.NewEvent(corev1.EventTypeNormal, triggerFinalized, "Trigger finalized: \"%s/%s\"", t.Namespace, t.Name)
@@ -208,12 +223,15 @@ func hasGCPBrokerFinalizer(t *brokerv1beta1.Trigger) bool {
 	return false
 }
 
-func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger, deliverySpec *eventingduckv1beta1.DeliverySpec) error {
+// reconcileRetryTopicAndSubscription reconciles the retry topic and
+// subscription of the trigger in the broker's project, or in the controller's
+// project if brokerProject is empty.
+func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger, brokerProject string, deliverySpec *eventingduckv1beta1.DeliverySpec) error {
 	logger := logging.FromContext(ctx)
 	logger.Debug("Reconciling retry topic")
-	// get ProjectID from metadata
+	// get ProjectID from the broker, or from metadata
 	//TODO get from context
-	projectID, err := utils.ProjectIDOrDefault(r.projectID)
+	projectID, err := r.triggerProjectID(brokerProject)
 	if err != nil {
 		logger.Error("Failed to find project id", zap.Error(err))
 		trig.Status.MarkTopicUnknown("ProjectIdNotFound", "Failed to find project id: %v", err)
@@ -225,15 +243,14 @@ func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, tri
 	//trig.Status.ProjectID = projectID
 
 	client := r.pubsubClient
-	if client == nil {
-		client, err := pubsub.NewClient(ctx, projectID)
+	if client == nil || brokerProject != "" {
+		client, err = r.clients.Get(projectID)
 		if err != nil {
 			logger.Error("Failed to create Pub/Sub client", zap.Error(err))
 			trig.Status.MarkTopicUnknown("PubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			trig.Status.MarkSubscriptionUnknown("PubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			return err
 		}
-		defer client.Close()
 	}
 
 	pubsubReconciler := reconcilerutilspubsub.NewReconciler(client, r.Recorder)
@@ -266,11 +283,30 @@ func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, tri
 	retryPolicy := getPubsubRetryPolicy(deliverySpec)
 	deadLetterPolicy := getPubsubDeadLetterPolicy(projectID, deliverySpec)
 
+	// Label the subscription with the BrokerCell serving the trigger so that
+	// the retry backlog metric only covers the subscriptions of the BrokerCell.
+	// The BrokerCell is created by the Broker reconciler, the label is added
+	// once it exists.
+	subLabels := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		subLabels[k] = v
+	}
+	// TODO(#866) Get brokercell based on the label (or annotation) on the broker.
+	bc, err := r.brokerCellLister.BrokerCells(system.Namespace()).Get(resources.DefaultBrokerCellName)
+	if err != nil && !apierrs.IsNotFound(err) {
+		logger.Error("Failed to get brokercell", zap.Error(err))
+		trig.Status.MarkSubscriptionUnknown("BrokerCellUnknown", "Failed to get brokercell: %v", err)
+		return err
+	}
+	if err == nil {
+		subLabels[brokercellresources.BrokerCellPubsubLabelKey] = brokercellresources.BrokerCellPubsubLabelValue(bc)
+	}
+
 	// Check if PullSub exists, and if not, create it.
 	subID := resources.GenerateRetrySubscriptionName(trig)
 	subConfig := pubsub.SubscriptionConfig{
 		Topic:            topic,
-		Labels:           labels,
+		Labels:           subLabels,
 		RetryPolicy:      retryPolicy,
 		DeadLetterPolicy: deadLetterPolicy,
 		//TODO(grantr): configure these settings?
@@ -321,13 +357,16 @@ func getPubsubDeadLetterPolicy(projectID string, spec *eventingduckv1beta1.Deliv
 	}
 }
 
-func (r *Reconciler) deleteRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger) error {
+// deleteRetryTopicAndSubscription deletes the retry topic and subscription of
+// the trigger from the broker's project, or from the controller's project if
+// brokerProject is empty.
+func (r *Reconciler) deleteRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger, brokerProject string) error {
 	logger := logging.FromContext(ctx)
 	logger.Debug("Deleting retry topic")
 
-	// get ProjectID from metadata
+	// get ProjectID from the broker, or from metadata
 	//TODO get from context
-	projectID, err := utils.ProjectIDOrDefault(r.projectID)
+	projectID, err := r.triggerProjectID(brokerProject)
 	if err != nil {
 		logger.Error("Failed to find project id", zap.Error(err))
 		trig.Status.MarkTopicUnknown("FinalizeTopicProjectIdNotFound", "Failed to find project id: %v", err)
@@ -336,15 +375,14 @@ func (r *Reconciler) deleteRetryTopicAndSubscription(ctx context.Context, trig *
 	}
 
 	client := r.pubsubClient
-	if client == nil {
-		client, err := pubsub.NewClient(ctx, projectID)
+	if client == nil || brokerProject != "" {
+		client, err = r.clients.Get(projectID)
 		if err != nil {
 			logger.Error("Failed to create Pub/Sub client", zap.Error(err))
 			trig.Status.MarkTopicUnknown("FinalizeTopicPubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			trig.Status.MarkSubscriptionUnknown("FinalizeSubscriptionPubSubClientCreationFailed", "Failed to create Pub/Sub client: %v", err)
 			return err
 		}
-		defer client.Close()
 	}
 	pubsubReconciler := reconcilerutilspubsub.NewReconciler(client, r.Recorder)
 
@@ -358,6 +396,62 @@ func (r *Reconciler) deleteRetryTopicAndSubscription(ctx context.Context, trig *
 	return err
 }
 
+// triggerProjectID returns the project the trigger's retry topic and
+// subscription live in: the broker's project if set, otherwise the
+// controller's project.
+func (r *Reconciler) triggerProjectID(brokerProject string) (string, error) {
+	if brokerProject != "" {
+		return brokerProject, nil
+	}
+	return utils.ProjectIDOrDefault(r.projectID)
+}
+
+// retryProject returns the project the trigger's retry topic and subscription
+// were created in, or an empty string for the controller's project. Triggers
+// reconciled before the project was recorded fall back to the project of
+// their broker, if it still exists.
+func (r *Reconciler) retryProject(t *brokerv1beta1.Trigger) string {
+	if project, ok := t.GetAnnotations()[brokerv1beta1.RetryProjectAnnotationKey]; ok {
+		return project
+	}
+	b, err := r.brokerLister.Brokers(t.Namespace).Get(t.Spec.Broker)
+	if err != nil {
+		return ""
+	}
+	return b.Project()
+}
+
+// recordRetryProject records the broker's project on the trigger before its
+// retry topic and subscription are created there. The annotation is removed
+// when the broker uses the controller's project.
+func (r *Reconciler) recordRetryProject(ctx context.Context, t *brokerv1beta1.Trigger, brokerProject string) error {
+	if t.GetAnnotations()[brokerv1beta1.RetryProjectAnnotationKey] == brokerProject {
+		return nil
+	}
+	// A null value removes the annotation in a merge patch.
+	var value interface{}
+	if brokerProject != "" {
+		value = brokerProject
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				brokerv1beta1.RetryProjectAnnotationKey: value,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal the retry project patch: %w", err)
+	}
+	if _, err := r.RunClientSet.EventingV1beta1().Triggers(t.Namespace).Patch(ctx, t.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		logging.FromContext(ctx).Error("Failed to record the retry project", zap.Error(err))
+		t.Status.MarkTopicUnknown("RetryProjectNotRecorded", "Failed to record the retry project: %v", err)
+		t.Status.MarkSubscriptionUnknown("RetryProjectNotRecorded", "Failed to record the retry project: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (r *Reconciler) checkDependencyAnnotation(ctx context.Context, t *brokerv1beta1.Trigger) error {
 	if dependencyAnnotation, ok := t.GetAnnotations()[v1beta1.DependencyAnnotation]; ok {
 		dependencyObjRef, err := v1beta1.GetObjRefFromDependencyAnnotation(dependencyAnnotation)
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -46,16 +47,21 @@ import (
 	"github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1alpha1/resource"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+	reconcilerutilspubsub "github.com/google/knative-gcp/pkg/reconciler/utils/pubsub"
 )
 
 const (
 	testNS      = "testnamespace"
+	systemNS    = "knative-testing"
 	triggerName = "test-trigger"
 	brokerName  = "test-broker"
 	testUID     = "abc123"
 	testProject = "test-project-id"
 
+	tenantProject = "tenant-project-id"
+
 	subscriberURI     = "http://example.com/subscriber/"
 	subscriberKind    = "Service"
 	subscriberName    = "subscriber-name"
@@ -166,6 +172,46 @@ func TestAllCasesTrigger(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Trigger is being deleted, topic and sub exist in the broker's project",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithBrokerProject(tenantProject),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerDeletionTimestamp,
+					WithTriggerUID(testUID),
+					WithTriggerFinalizers(finalizerName),
+					WithTriggerSetDefaults),
+			},
+			WantEvents: []string{
+				topicDeletedEvent,
+				subscriptionDeletedEvent,
+				triggerFinalizerUpdatedEvent,
+				triggerFinalizedEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchRemoveFinalizers(testNS, triggerName),
+			},
+			OtherTestData: map[string]interface{}{
+				"pre": []PubsubAction{
+					TopicAndSub("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr_testnamespace_test-trigger_abc123"),
+				},
+				"tenantPre": []PubsubAction{
+					TopicAndSub("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr_testnamespace_test-trigger_abc123"),
+				},
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				// Only the topic and sub in the broker's project are deleted.
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
 		{
 			Name: "Broker not found, Trigger with finalizer should be finalized",
 			Key:  testKey,
@@ -188,6 +234,37 @@ func TestAllCasesTrigger(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Broker not found, Trigger with finalizer should be finalized in its retry project",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerFinalizers(finalizerName),
+					WithTriggerRetryProject(tenantProject),
+					WithTriggerSetDefaults,
+					WithInitTriggerConditions,
+				),
+			},
+			WantEvents: []string{
+				topicDeletedEvent,
+				subscriptionDeletedEvent,
+				triggerFinalizedEvent,
+			},
+			OtherTestData: map[string]interface{}{
+				"pre": []PubsubAction{
+					TopicAndSub("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr_testnamespace_test-trigger_abc123"),
+				},
+				"tenantPre": []PubsubAction{
+					TopicAndSub("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr_testnamespace_test-trigger_abc123"),
+				},
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				// Only the topic and sub in the recorded project are deleted.
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
 		{
 			Name: "Broker is being deleted, Trigger with finalizer should be finalized",
 			Key:  testKey,
@@ -398,6 +475,59 @@ func TestAllCasesTrigger(t *testing.T) {
 				}),
 			},
 		},
+		{
+			Name: "Trigger created, broker with project annotation, topic and sub are created in the broker's project",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithBrokerProject(tenantProject),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerDeliverySpec(brokerDeliverySpec),
+					WithBrokerSetDefaults,
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				topicCreatedEvent,
+				subscriptionCreatedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+				patchRetryProject(testNS, triggerName, tenantProject),
+			},
+			OtherTestData: map[string]interface{}{
+				"tenantPre": []PubsubAction{
+					Topic("test-dead-letter-topic-id"),
+				},
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				NoTopicsExist(),
+				NoSubscriptionsExist(),
+				TopicExistsInProject(tenantProject, "cre-tgr_testnamespace_test-trigger_abc123"),
+				SubscriptionExistsInProject(tenantProject, "cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
 		{
 			Name: "Sub already exists, update config",
 			Key:  testKey,
@@ -457,6 +587,59 @@ func TestAllCasesTrigger(t *testing.T) {
 					}),
 			},
 		},
+		{
+			Name: "Sub already exists, label with its brokercell",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerDeliverySpec(brokerDeliverySpec),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerresources.DefaultBrokerCellName, systemNS,
+					WithBrokerCellUID("bc-uid"),
+					WithBrokerCellReady),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				subscriptionConfigUpdatedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			OtherTestData: map[string]interface{}{
+				"pre": []PubsubAction{
+					TopicAndSub("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr_testnamespace_test-trigger_abc123"),
+					Topic("test-dead-letter-topic-id"),
+				},
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				SubscriptionHasLabels("cre-tgr_testnamespace_test-trigger_abc123", map[string]string{
+					"name": "test-trigger", "namespace": "testnamespace", "resource": "triggers", "brokercell": "bc-uid",
+				}),
+			},
+		},
 		{
 			Name: "Check topic config and labels",
 			Key:  testKey,
@@ -529,8 +712,11 @@ func TestAllCasesTrigger(t *testing.T) {
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
 		// Insert pubsub client for PostConditions and create fixtures
-		psclient, close := TestPubsubClient(ctx, testProject)
-		t.Cleanup(close)
+		srv := pstest.NewServer()
+		t.Cleanup(func() { srv.Close() })
+		createClientFn := GetTestClientCreateFunc(srv.Addr)
+		psclient, _ := createClientFn(ctx, testProject)
+		t.Cleanup(func() { psclient.Close() })
 		var drStore *dataresidency.Store
 		if testData != nil {
 			InjectPubsubClient(testData, psclient)
@@ -540,6 +726,13 @@ func TestAllCasesTrigger(t *testing.T) {
 					f(ctx, t, psclient)
 				}
 			}
+			if testData["tenantPre"] != nil {
+				tenantClient, _ := createClientFn(ctx, tenantProject)
+				t.Cleanup(func() { tenantClient.Close() })
+				for _, f := range testData["tenantPre"].([]PubsubAction) {
+					f(ctx, t, tenantClient)
+				}
+			}
 
 			// If we found "dataResidencyConfigMap" in OtherData, we create a store with the configmap
 			if cm, ok := testData["dataResidencyConfigMap"]; ok {
@@ -554,11 +747,13 @@ func TestAllCasesTrigger(t *testing.T) {
 		r := &Reconciler{
 			Base:               reconciler.NewBase(ctx, controllerAgentName, cmw),
 			brokerLister:       listers.GetBrokerLister(),
+			brokerCellLister:   listers.GetBrokerCellLister(),
 			kresourceTracker:   duck.NewListableTracker(ctx, conditions.Get, func(types.NamespacedName) {}, 0),
 			addressableTracker: duck.NewListableTracker(ctx, addressable.Get, func(types.NamespacedName) {}, 0),
 			uriResolver:        resolver.NewURIResolver(ctx, func(types.NamespacedName) {}),
 			projectID:          testProject,
 			pubsubClient:       psclient,
+			clients:            reconcilerutilspubsub.NewClientCache(ctx, createClientFn),
 			dataresidencyStore: drStore,
 		}
 
@@ -594,6 +789,15 @@ func patchFinalizers(namespace, name, finalizer string) clientgotesting.PatchAct
 	return action
 }
 
+func patchRetryProject(namespace, name, project string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	patch := `{"metadata":{"annotations":{"` + brokerv1beta1.RetryProjectAnnotationKey + `":"` + project + `"}}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
 // TODO Move to a util package so all reconciler tests can use.
 func patchRemoveFinalizers(namespace, name string) clientgotesting.PatchActionImpl {
 	action := clientgotesting.PatchActionImpl{}
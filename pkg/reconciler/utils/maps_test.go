@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "testing"
+
+func TestEqualStringMaps(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    map[string]string
+		b    map[string]string
+		want bool
+	}{{
+		name: "nil and empty",
+		a:    nil,
+		b:    map[string]string{},
+		want: true,
+	}, {
+		name: "same entries",
+		a:    map[string]string{"k1": "v1", "k2": "v2"},
+		b:    map[string]string{"k2": "v2", "k1": "v1"},
+		want: true,
+	}, {
+		name: "different values",
+		a:    map[string]string{"k1": "v1"},
+		b:    map[string]string{"k1": "v2"},
+		want: false,
+	}, {
+		name: "different keys",
+		a:    map[string]string{"k1": ""},
+		b:    map[string]string{"k2": ""},
+		want: false,
+	}, {
+		name: "missing entry",
+		a:    map[string]string{"k1": "v1", "k2": "v2"},
+		b:    map[string]string{"k1": "v1"},
+		want: false,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EqualStringMaps(tc.a, tc.b); got != tc.want {
+				t.Errorf("EqualStringMaps() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/multierr"
+)
+
+// ClientCache caches Pub/Sub clients by project, so that reconcilers don't
+// create a new client on every reconcile of a resource in another project.
+type ClientCache struct {
+	// ctx is the context the clients are created with. Clients live as long
+	// as the cache rather than a single reconcile, so it's the controller's
+	// context.
+	ctx     context.Context
+	create  CreateFn
+	mux     sync.Mutex
+	clients map[string]*pubsub.Client
+}
+
+// NewClientCache creates a client cache creating its clients with create and
+// the given context.
+func NewClientCache(ctx context.Context, create CreateFn) *ClientCache {
+	return &ClientCache{
+		ctx:     ctx,
+		create:  create,
+		clients: make(map[string]*pubsub.Client),
+	}
+}
+
+// Get returns the cached client of the given project, creating it if needed.
+// Clients that fail to be created are not cached, so that they are created
+// again on the next call.
+func (c *ClientCache) Get(projectID string) (*pubsub.Client, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if client, ok := c.clients[projectID]; ok {
+		return client, nil
+	}
+	client, err := c.create(c.ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[projectID] = client
+	return client, nil
+}
+
+// Close closes and removes all the cached clients. It should be called when the
+// controller shuts down.
+func (c *ClientCache) Close() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var err error
+	for projectID, client := range c.clients {
+		err = multierr.Append(err, client.Close())
+		delete(c.clients, projectID)
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+
+	reconcilertesting "github.com/google/knative-gcp/pkg/reconciler/testing"
+)
+
+func TestClientCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	created := make(map[string]int)
+	var fail bool
+	testCreate := reconcilertesting.GetTestClientCreateFunc(srv.Addr)
+	create := func(ctx context.Context, projectID string, opts ...option.ClientOption) (*pubsub.Client, error) {
+		if fail {
+			return nil, errors.New("creation failure")
+		}
+		created[projectID]++
+		return testCreate(ctx, projectID, opts...)
+	}
+	cache := NewClientCache(ctx, create)
+
+	first, err := cache.Get("tenant-project")
+	if err != nil {
+		t.Fatalf("Get got error: %v", err)
+	}
+	second, err := cache.Get("tenant-project")
+	if err != nil {
+		t.Fatalf("Get got error: %v", err)
+	}
+	if first != second {
+		t.Error("Get got a new client for the same project, want the cached client")
+	}
+	if _, err := cache.Get("other-project"); err != nil {
+		t.Fatalf("Get got error: %v", err)
+	}
+	if created["tenant-project"] != 1 || created["other-project"] != 1 {
+		t.Errorf("Created clients got=%v, want one per project", created)
+	}
+
+	fail = true
+	if _, err := cache.Get("failing-project"); err == nil {
+		t.Error("Get got no error, want the creation error")
+	}
+	fail = false
+	if _, err := cache.Get("failing-project"); err != nil {
+		t.Errorf("Get after a creation failure got error: %v", err)
+	}
+
+	// The test clients share a connection they don't own, so closing them
+	// reports errors.
+	cache.Close()
+	if _, err := cache.Get("tenant-project"); err != nil {
+		t.Fatalf("Get after close got error: %v", err)
+	}
+	if created["tenant-project"] != 2 {
+		t.Errorf("Created clients for tenant-project after close got=%d, want=2", created["tenant-project"])
+	}
+	cache.Close()
+}
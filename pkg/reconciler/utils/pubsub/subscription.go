@@ -66,13 +66,19 @@ func (r *Reconciler) ReconcileSubscription(ctx context.Context, id string, subCo
 			}
 			return r.createSubscription(ctx, id, subConfig, obj, updater)
 		}
-		// Update the subscription config in case the retry or dead letter policy changed. A nil policy indicates no change.
+		// Update the subscription config in case the retry or dead letter policy or the labels changed. A nil policy
+		// or nil labels indicate no change.
+		labelsChanged := subConfig.Labels != nil && !equality.Semantic.DeepEqual(config.Labels, subConfig.Labels)
 		if (subConfig.RetryPolicy != nil && !equality.Semantic.DeepEqual(config.RetryPolicy, subConfig.RetryPolicy)) ||
-			(subConfig.DeadLetterPolicy != nil && !equality.Semantic.DeepEqual(config.DeadLetterPolicy, subConfig.DeadLetterPolicy)) {
+			(subConfig.DeadLetterPolicy != nil && !equality.Semantic.DeepEqual(config.DeadLetterPolicy, subConfig.DeadLetterPolicy)) ||
+			labelsChanged {
 			updateSubConfig := pubsub.SubscriptionConfigToUpdate{
 				RetryPolicy:      subConfig.RetryPolicy,
 				DeadLetterPolicy: subConfig.DeadLetterPolicy,
 			}
+			if labelsChanged {
+				updateSubConfig.Labels = subConfig.Labels
+			}
 			if _, err := sub.Update(ctx, updateSubConfig); err != nil {
 				updater.MarkSubscriptionFailed("SubscriptionConfigUpdateFailed", "Failed to update Pub/Sub subscription config: %v", err)
 				return nil, err
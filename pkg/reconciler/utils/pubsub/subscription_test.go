@@ -78,6 +78,17 @@ func TestReconcileSub(t *testing.T) {
 			},
 			wantSubCondition: apis.Condition{Status: corev1.ConditionTrue},
 		},
+		{
+			name: "sub already exists, modify labels",
+			pre:  []reconcilertesting.PubsubAction{reconcilertesting.TopicAndSub(topic, sub)},
+			wantSubConfig: &pubsub.SubscriptionConfig{
+				Labels: map[string]string{"resource": "triggers", "brokercell": "uid"},
+			},
+			wantEvents: []string{
+				`Normal SubscriptionConfigUpdated Updated config for PubSub subscription "test-sub"`,
+			},
+			wantSubCondition: apis.Condition{Status: corev1.ConditionTrue},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
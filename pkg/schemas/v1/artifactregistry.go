@@ -0,0 +1,39 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "fmt"
+
+const (
+	// CloudArtifactRegistryImageInsertedEventType is the CloudEvent type of the notifications sent once an
+	// image, or a tag of an image, has been pushed to Artifact Registry or Container Registry.
+	CloudArtifactRegistryImageInsertedEventType = "google.cloud.artifactregistry.image.v1.inserted"
+	// CloudArtifactRegistryImageDeletedEventType is the CloudEvent type of the notifications sent once an
+	// image, or a tag of an image, has been deleted from Artifact Registry or Container Registry.
+	CloudArtifactRegistryImageDeletedEventType = "google.cloud.artifactregistry.image.v1.deleted"
+	// CloudArtifactRegistryDigestExtension is the CloudEvent extension with the image reference by digest,
+	// e.g. 'gcr.io/my-project/my-image@sha256:...'.
+	CloudArtifactRegistryDigestExtension = "imagedigest"
+	// CloudArtifactRegistryTagExtension is the CloudEvent extension with the image reference by tag,
+	// e.g. 'gcr.io/my-project/my-image:latest'.
+	CloudArtifactRegistryTagExtension = "imagetag"
+)
+
+// CloudArtifactRegistryEventSource returns the Artifact Registry CloudEvent source value.
+func CloudArtifactRegistryEventSource(googleCloudProject string) string {
+	return fmt.Sprintf("//artifactregistry.googleapis.com/projects/%s", googleCloudProject)
+}
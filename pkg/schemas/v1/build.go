@@ -27,6 +27,10 @@ const (
 	CloudBuildSourceBuildId = "buildId"
 	// CloudBuildSourceBuildStatus is the Pub/Sub message attribute key with the CloudBuildSource's build status.
 	CloudBuildSourceBuildStatus = "status"
+	// CloudBuildSourceStatusExtension is the CloudEvent extension with the build status.
+	CloudBuildSourceStatusExtension = "buildstatus"
+	// CloudBuildSourceTriggerIDExtension is the CloudEvent extension with the ID of the build trigger.
+	CloudBuildSourceTriggerIDExtension = "buildtriggerid"
 )
 
 // CloudBuildSourceEventSource returns the Cloud Build CloudEvent source value.
@@ -0,0 +1,40 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+)
+
+const (
+	CloudLoggingLogEntryWrittenEventType = "google.cloud.logging.logEntry.v1.written"
+	CloudLoggingEventDataSchema          = "https://raw.githubusercontent.com/googleapis/google-cloudevents/master/proto/google/events/cloud/logging/v1/data.proto"
+
+	SeverityExtension = "severity"
+)
+
+// CloudLoggingEventSource returns the Cloud Logging CloudEvent source value.
+// Format e.g. //logging.googleapis.com/projects/project-id/logs/log-id
+func CloudLoggingEventSource(logName string) string {
+	return fmt.Sprintf("//logging.googleapis.com/%s", logName)
+}
+
+// CloudLoggingEventID returns the Cloud Logging CloudEvent id value. Like for
+// audit logs, insert IDs are only unique per log and timestamp.
+func CloudLoggingEventID(id, logName, timestamp string) string {
+	return CloudAuditLogsEventID(id, logName, timestamp)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "fmt"
+
+const (
+	// CloudMonitoringIncidentOpenedEventType is the CloudEvent type of the notifications of opened incidents.
+	CloudMonitoringIncidentOpenedEventType = "google.cloud.monitoring.incident.v1.opened"
+	// CloudMonitoringIncidentClosedEventType is the CloudEvent type of the notifications of closed incidents.
+	CloudMonitoringIncidentClosedEventType = "google.cloud.monitoring.incident.v1.closed"
+	// CloudMonitoringIncidentStateExtension is the CloudEvent extension with the state of the incident.
+	CloudMonitoringIncidentStateExtension = "incidentstate"
+	// CloudMonitoringIncidentPolicyExtension is the CloudEvent extension with the display name of the alert policy.
+	CloudMonitoringIncidentPolicyExtension = "incidentpolicy"
+	// CloudMonitoringIncidentConditionExtension is the CloudEvent extension with the display name of the condition
+	// that opened the incident.
+	CloudMonitoringIncidentConditionExtension = "incidentcondition"
+)
+
+// CloudMonitoringEventSource returns the Cloud Monitoring CloudEvent source value.
+func CloudMonitoringEventSource(googleCloudProject string) string {
+	return fmt.Sprintf("//monitoring.googleapis.com/projects/%s", googleCloudProject)
+}
+
+// CloudMonitoringEventSubject returns the Cloud Monitoring CloudEvent subject value.
+func CloudMonitoringEventSubject(incidentID string) string {
+	return fmt.Sprintf("incidents/%s", incidentID)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+)
+
+func TestCloudMonitoringEventSource(t *testing.T) {
+	want := "//monitoring.googleapis.com/projects/PROJECT"
+	got := CloudMonitoringEventSource("PROJECT")
+	if got != want {
+		t.Errorf("CloudMonitoringEventSource got=%s, want=%s", got, want)
+	}
+}
+
+func TestCloudMonitoringEventSubject(t *testing.T) {
+	want := "incidents/INCIDENT_ID"
+	got := CloudMonitoringEventSubject("INCIDENT_ID")
+	if got != want {
+		t.Errorf("CloudMonitoringEventSubject got=%s, want=%s", got, want)
+	}
+}
@@ -24,6 +24,14 @@ import (
 const (
 	CloudPubSubMessagePublishedEventType = "google.cloud.pubsub.topic.v1.messagePublished"
 	CloudPubSubEventDataSchema           = "https://raw.githubusercontent.com/googleapis/google-cloudevents/master/proto/google/events/cloud/pubsub/v1/data.proto"
+
+	// OrderingKeyExtension is the extension holding the ordering key of the
+	// Pub/Sub message, if any.
+	OrderingKeyExtension = "orderingkey"
+	// DeliveryAttemptExtension is the extension holding the delivery attempt
+	// of the Pub/Sub message, only known if the subscription has a dead
+	// letter policy.
+	DeliveryAttemptExtension = "deliveryattempt"
 )
 
 // CloudPubSubEventSource returns the Cloud Pub/Sub CloudEvent source value.
@@ -37,6 +45,9 @@ type PushMessage struct {
 	Subscription string `json:"subscription"`
 	// Message holds the Pub/Sub message contents.
 	Message *PubSubMessage `json:"message,omitempty"`
+	// DeliveryAttempt is the number of times the message has been delivered,
+	// only set if the subscription has a dead letter policy.
+	DeliveryAttempt *int `json:"deliveryAttempt,omitempty"`
 }
 
 // PubSubMessage matches the inner message format used by Push Subscriptions.
@@ -57,4 +68,8 @@ type PubSubMessage struct {
 	// server for Messages obtained from a subscription.
 	// This field is read-only.
 	PublishTime time.Time `json:"publishTime,omitempty"`
+
+	// OrderingKey identifies related messages for which publish order is
+	// respected.
+	OrderingKey string `json:"orderingKey,omitempty"`
 }
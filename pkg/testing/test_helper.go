@@ -30,6 +30,7 @@ var (
 	RetentionDuration = "30s"
 	backoffPolicy     = eventingduckv1beta1.BackoffPolicyExponential
 	backoffDelay      = "backoffDelay"
+	dedupTTL          = "10m"
 
 	CompleteObjectMeta = metav1.ObjectMeta{
 		Name:            "name",
@@ -172,6 +173,10 @@ var (
 		IdentitySpec: CompleteV1beta1IdentitySpec,
 		Secret:       CompleteSecret,
 		Project:      "project",
+		Dedup: &duckv1beta1.DedupSpec{
+			CacheSize: 1000,
+			TTL:       &dedupTTL,
+		},
 	}
 
 	CompleteV1beta1IdentityStatus = duckv1beta1.IdentityStatus{
@@ -268,6 +273,10 @@ var (
 		IdentitySpec: CompleteV1IdentitySpec,
 		Secret:       CompleteSecret,
 		Project:      "project",
+		Dedup: &duckv1.DedupSpec{
+			CacheSize: 1000,
+			TTL:       &dedupTTL,
+		},
 	}
 
 	CompleteV1IdentityStatus = duckv1.IdentityStatus{